@@ -0,0 +1,429 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownValue is the cause of errors returned when parsing a string that
+// isn't the value of any constant of the type being parsed. The empty string
+// parses to the zero value of each type.
+var ErrUnknownValue = errors.New("unknown value")
+
+func unknownValue(typ, s string) error {
+	return errors.Wrapf(ErrUnknownValue, "parsing %s %q", typ, s)
+}
+
+// CallStatus is the status of a call, as sent in the CallStatus parameter of
+// webhook requests. The constants are in the order a call moves through them.
+type CallStatus uint8
+
+const (
+	// CallStatusQueued means the call is ready and waiting to be dialed.
+	CallStatusQueued CallStatus = iota + 1
+
+	// CallStatusInitiated means the call has started dialing.
+	CallStatusInitiated
+
+	// CallStatusRinging means the call is ringing.
+	CallStatusRinging
+
+	// CallStatusInProgress means the call was answered and is in progress.
+	CallStatusInProgress
+
+	// CallStatusCompleted means the call was answered and has ended.
+	CallStatusCompleted
+
+	// CallStatusBusy means the caller received a busy signal.
+	CallStatusBusy
+
+	// CallStatusFailed means the call couldn't be completed as dialed.
+	CallStatusFailed
+
+	// CallStatusNoAnswer means the call ended without being answered.
+	CallStatusNoAnswer
+
+	// CallStatusCanceled means the call was canceled while queued or
+	// ringing.
+	CallStatusCanceled
+)
+
+func (s CallStatus) String() string {
+	switch s {
+	case CallStatusQueued:
+		return "queued"
+	case CallStatusInitiated:
+		return "initiated"
+	case CallStatusRinging:
+		return "ringing"
+	case CallStatusInProgress:
+		return "in-progress"
+	case CallStatusCompleted:
+		return "completed"
+	case CallStatusBusy:
+		return "busy"
+	case CallStatusFailed:
+		return "failed"
+	case CallStatusNoAnswer:
+		return "no-answer"
+	case CallStatusCanceled:
+		return "canceled"
+	default:
+		return ""
+	}
+}
+
+// Final returns whether the status is one a call ends in.
+func (s CallStatus) Final() bool {
+	return s >= CallStatusCompleted
+}
+
+// CallStatusValues returns all of the CallStatus constants.
+func CallStatusValues() []CallStatus {
+	return []CallStatus{
+		CallStatusQueued,
+		CallStatusInitiated,
+		CallStatusRinging,
+		CallStatusInProgress,
+		CallStatusCompleted,
+		CallStatusBusy,
+		CallStatusFailed,
+		CallStatusNoAnswer,
+		CallStatusCanceled,
+	}
+}
+
+// ParseCallStatus returns the CallStatus whose value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseCallStatus(s string) (CallStatus, error) {
+	if s == "" {
+		return CallStatus(0), nil
+	}
+
+	for _, v := range CallStatusValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return CallStatus(0), unknownValue("CallStatus", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s CallStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *CallStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParseCallStatus(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
+
+// CallDirection is the direction of a call, as sent in the Direction
+// parameter of webhook requests.
+type CallDirection uint8
+
+const (
+	// CallDirectionInbound is a call made to a Twilio number.
+	CallDirectionInbound CallDirection = iota + 1
+
+	// CallDirectionOutboundAPI is a call made using the REST API.
+	CallDirectionOutboundAPI
+
+	// CallDirectionOutboundDial is a call made by the Dial verb.
+	CallDirectionOutboundDial
+
+	// CallDirectionTrunkingTerminating is a call made through Elastic SIP
+	// Trunking to the public phone network.
+	CallDirectionTrunkingTerminating
+
+	// CallDirectionTrunkingOriginating is a call made from the public phone
+	// network through Elastic SIP Trunking.
+	CallDirectionTrunkingOriginating
+)
+
+func (d CallDirection) String() string {
+	switch d {
+	case CallDirectionInbound:
+		return "inbound"
+	case CallDirectionOutboundAPI:
+		return "outbound-api"
+	case CallDirectionOutboundDial:
+		return "outbound-dial"
+	case CallDirectionTrunkingTerminating:
+		return "trunking-terminating"
+	case CallDirectionTrunkingOriginating:
+		return "trunking-originating"
+	default:
+		return ""
+	}
+}
+
+// CallDirectionValues returns all of the CallDirection constants.
+func CallDirectionValues() []CallDirection {
+	return []CallDirection{
+		CallDirectionInbound,
+		CallDirectionOutboundAPI,
+		CallDirectionOutboundDial,
+		CallDirectionTrunkingTerminating,
+		CallDirectionTrunkingOriginating,
+	}
+}
+
+// ParseCallDirection returns the CallDirection whose value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseCallDirection(s string) (CallDirection, error) {
+	if s == "" {
+		return CallDirection(0), nil
+	}
+
+	for _, v := range CallDirectionValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return CallDirection(0), unknownValue("CallDirection", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d CallDirection) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *CallDirection) UnmarshalText(text []byte) error {
+	parsed, err := ParseCallDirection(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// AnsweredBy is who answering machine detection determined answered a call,
+// as sent in the AnsweredBy parameter of webhook requests.
+type AnsweredBy uint8
+
+const (
+	// AnsweredByHuman means a person answered the call.
+	AnsweredByHuman AnsweredBy = iota + 1
+
+	// AnsweredByMachineStart means an answering machine answered the call,
+	// and detection ended before its greeting did.
+	AnsweredByMachineStart
+
+	// AnsweredByMachineEndBeep means an answering machine answered the
+	// call, and detection ended at the beep after its greeting.
+	AnsweredByMachineEndBeep
+
+	// AnsweredByMachineEndSilence means an answering machine answered the
+	// call, and detection ended at the silence after its greeting.
+	AnsweredByMachineEndSilence
+
+	// AnsweredByMachineEndOther means an answering machine answered the
+	// call, and detection ended for another reason after its greeting.
+	AnsweredByMachineEndOther
+
+	// AnsweredByFax means a fax machine answered the call.
+	AnsweredByFax
+
+	// AnsweredByUnknown means detection couldn't tell who answered the
+	// call.
+	AnsweredByUnknown
+)
+
+func (a AnsweredBy) String() string {
+	switch a {
+	case AnsweredByHuman:
+		return "human"
+	case AnsweredByMachineStart:
+		return "machine_start"
+	case AnsweredByMachineEndBeep:
+		return "machine_end_beep"
+	case AnsweredByMachineEndSilence:
+		return "machine_end_silence"
+	case AnsweredByMachineEndOther:
+		return "machine_end_other"
+	case AnsweredByFax:
+		return "fax"
+	case AnsweredByUnknown:
+		return "unknown"
+	default:
+		return ""
+	}
+}
+
+// Machine returns whether an answering machine answered the call.
+func (a AnsweredBy) Machine() bool {
+	return a >= AnsweredByMachineStart && a <= AnsweredByMachineEndOther
+}
+
+// AnsweredByValues returns all of the AnsweredBy constants.
+func AnsweredByValues() []AnsweredBy {
+	return []AnsweredBy{
+		AnsweredByHuman,
+		AnsweredByMachineStart,
+		AnsweredByMachineEndBeep,
+		AnsweredByMachineEndSilence,
+		AnsweredByMachineEndOther,
+		AnsweredByFax,
+		AnsweredByUnknown,
+	}
+}
+
+// ParseAnsweredBy returns the AnsweredBy whose value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseAnsweredBy(s string) (AnsweredBy, error) {
+	if s == "" {
+		return AnsweredBy(0), nil
+	}
+
+	for _, v := range AnsweredByValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return AnsweredBy(0), unknownValue("AnsweredBy", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a AnsweredBy) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *AnsweredBy) UnmarshalText(text []byte) error {
+	parsed, err := ParseAnsweredBy(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+
+	return nil
+}
+
+// DialCallStatus is the outcome of a Dial, as sent in the DialCallStatus
+// parameter of the request made to its action.
+type DialCallStatus uint8
+
+const (
+	// DialCallStatusCompleted means the called party answered, and the
+	// call between the parties ended normally.
+	DialCallStatusCompleted DialCallStatus = iota + 1
+
+	// DialCallStatusAnswered means the called party answered and was
+	// connected to the caller, when dialing a Conference or Queue.
+	DialCallStatusAnswered
+
+	// DialCallStatusBusy means the called party was busy.
+	DialCallStatusBusy
+
+	// DialCallStatusNoAnswer means the called party didn't answer before
+	// the Dial's timeout.
+	DialCallStatusNoAnswer
+
+	// DialCallStatusFailed means the call couldn't be made, such as when
+	// the number doesn't exist.
+	DialCallStatusFailed
+
+	// DialCallStatusCanceled means the call was canceled, such as by the
+	// caller hanging up before it was answered.
+	DialCallStatusCanceled
+)
+
+func (s DialCallStatus) String() string {
+	switch s {
+	case DialCallStatusCompleted:
+		return "completed"
+	case DialCallStatusAnswered:
+		return "answered"
+	case DialCallStatusBusy:
+		return "busy"
+	case DialCallStatusNoAnswer:
+		return "no-answer"
+	case DialCallStatusFailed:
+		return "failed"
+	case DialCallStatusCanceled:
+		return "canceled"
+	default:
+		return ""
+	}
+}
+
+// Answered returns whether the status is one where the called party
+// answered.
+func (s DialCallStatus) Answered() bool {
+	return s == DialCallStatusCompleted || s == DialCallStatusAnswered
+}
+
+// DialCallStatusValues returns all of the DialCallStatus constants.
+func DialCallStatusValues() []DialCallStatus {
+	return []DialCallStatus{
+		DialCallStatusCompleted,
+		DialCallStatusAnswered,
+		DialCallStatusBusy,
+		DialCallStatusNoAnswer,
+		DialCallStatusFailed,
+		DialCallStatusCanceled,
+	}
+}
+
+// ParseDialCallStatus returns the DialCallStatus whose value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseDialCallStatus(s string) (DialCallStatus, error) {
+	if s == "" {
+		return DialCallStatus(0), nil
+	}
+
+	for _, v := range DialCallStatusValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return DialCallStatus(0), unknownValue("DialCallStatus", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s DialCallStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *DialCallStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParseDialCallStatus(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimestampLayout is the layout of the times in the Timestamp parameter of
+// status callback requests.
+const TimestampLayout = time.RFC1123Z
+
+// CallStatusEvent is the parameters of a call status callback request, which
+// Twilio makes to the StatusCallback URL of a call, or of a Number, Client, or
+// Sip noun of a Dial, as the call's status changes.
+type CallStatusEvent struct {
+	CallSid       string
+	AccountSid    string
+	ParentCallSid string
+	APIVersion    string
+
+	From, To  string
+	Direction CallDirection
+
+	CallStatus CallStatus
+
+	// SequenceNumber is the order of the event among the status callbacks
+	// of the call, starting from 0. Callbacks can arrive out of order.
+	SequenceNumber int
+
+	// Timestamp is when the event happened.
+	Timestamp time.Time
+
+	// CallbackSource is what made the callback, such as
+	// "call-progress-events".
+	CallbackSource string
+
+	// CallDuration is how long the call lasted, once it's completed.
+	CallDuration time.Duration
+
+	// SipResponseCode is the SIP response code of the call, such as 200
+	// or 486, once it's completed.
+	SipResponseCode int
+
+	// AnsweredBy is the result of answering machine detection, if the
+	// call was made with it.
+	AnsweredBy AnsweredBy
+
+	// Form is all of the parameters of the request.
+	Form url.Values
+}
+
+// ParseCallStatusEvent parses the parameters of a call status callback
+// request, from its query string and form-encoded body. If any parameters
+// are malformed, the error's cause is a *ParamError listing them, and the
+// *CallStatusEvent is still returned with the rest. This function returns a
+// wrapped error (see package documentation for more info).
+func ParseCallStatusEvent(r *http.Request) (*CallStatusEvent, error) {
+	form, err := parseForm(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing call status event failed")
+	}
+
+	return decodeCallStatusEvent(form)
+}
+
+func decodeCallStatusEvent(form url.Values) (*CallStatusEvent, error) {
+	d := &paramDecoder{form: form}
+
+	e := &CallStatusEvent{
+		CallSid:         d.sid("CallSid", "CA"),
+		AccountSid:      d.sid("AccountSid", "AC"),
+		ParentCallSid:   d.sid("ParentCallSid", "CA"),
+		APIVersion:      d.string("ApiVersion"),
+		From:            d.string("From"),
+		To:              d.string("To"),
+		SequenceNumber:  d.int("SequenceNumber"),
+		Timestamp:       d.time("Timestamp", TimestampLayout),
+		CallbackSource:  d.string("CallbackSource"),
+		CallDuration:    d.seconds("CallDuration"),
+		SipResponseCode: d.int("SipResponseCode"),
+		Form:            form,
+	}
+
+	d.text("Direction", &e.Direction)
+	d.text("CallStatus", &e.CallStatus)
+	d.text("AnsweredBy", &e.AnsweredBy)
+
+	if e.SequenceNumber < 0 {
+		d.fail("SequenceNumber", form.Get("SequenceNumber"), errors.New("negative"))
+	}
+
+	return e, d.err("call status event")
+}
@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseCallStatusEvent(t *testing.T) {
+	params := url.Values{
+		"CallSid":         {testCallSid},
+		"AccountSid":      {testAccountSid},
+		"ParentCallSid":   {"CAffffffffffffffffffffffffffffffff"},
+		"ApiVersion":      {"2010-04-01"},
+		"From":            {"+15005550006"},
+		"To":              {"+15005550100"},
+		"Direction":       {"outbound-dial"},
+		"CallStatus":      {"completed"},
+		"SequenceNumber":  {"3"},
+		"Timestamp":       {"Mon, 19 Oct 2026 12:00:05 +0000"},
+		"CallbackSource":  {"call-progress-events"},
+		"CallDuration":    {"42"},
+		"SipResponseCode": {"200"},
+		"AnsweredBy":      {"machine_end_beep"},
+	}
+
+	e, err := ParseCallStatusEvent(formRequest(params))
+
+	if err != nil {
+		t.Fatalf("ParseCallStatusEvent() unexpected error: %s", err)
+	}
+
+	want := &CallStatusEvent{
+		CallSid:         testCallSid,
+		AccountSid:      testAccountSid,
+		ParentCallSid:   "CAffffffffffffffffffffffffffffffff",
+		APIVersion:      "2010-04-01",
+		From:            "+15005550006",
+		To:              "+15005550100",
+		Direction:       CallDirectionOutboundDial,
+		CallStatus:      CallStatusCompleted,
+		SequenceNumber:  3,
+		Timestamp:       time.Date(2026, time.October, 19, 12, 0, 5, 0, time.FixedZone("", 0)),
+		CallbackSource:  "call-progress-events",
+		CallDuration:    42 * time.Second,
+		SipResponseCode: 200,
+		AnsweredBy:      AnsweredByMachineEndBeep,
+		Form:            e.Form,
+	}
+
+	if !e.Timestamp.Equal(want.Timestamp) {
+		t.Errorf("Timestamp = %s, want %s", e.Timestamp, want.Timestamp)
+	}
+
+	want.Timestamp = e.Timestamp
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("ParseCallStatusEvent() = %+v, want %+v", e, want)
+	}
+
+	if !e.AnsweredBy.Machine() {
+		t.Error("AnsweredBy.Machine() = false, want true")
+	}
+}
+
+func TestParseCallStatusEvent_malformed(t *testing.T) {
+	tests := []struct {
+		desc   string
+		params url.Values
+		names  []string
+	}{
+		{"SequenceNumber that isn't a number", url.Values{"SequenceNumber": {"first"}}, []string{"SequenceNumber"}},
+		{"negative SequenceNumber", url.Values{"SequenceNumber": {"-1"}}, []string{"SequenceNumber"}},
+		{"Timestamp in another layout", url.Values{"Timestamp": {"2026-10-19T12:00:05Z"}}, []string{"Timestamp"}},
+		{"CallDuration that isn't a number", url.Values{"CallDuration": {"1.5"}}, []string{"CallDuration"}},
+		{"unknown AnsweredBy", url.Values{"AnsweredBy": {"robot"}}, []string{"AnsweredBy"}},
+	}
+
+	for _, test := range tests {
+		e, err := ParseCallStatusEvent(formRequest(test.params))
+
+		pe, ok := errors.Cause(err).(*ParamError)
+
+		if !ok {
+			t.Errorf("\nDescription: %s\nParseCallStatusEvent() error = %v, want a *ParamError", test.desc, err)
+			continue
+		}
+
+		var names []string
+
+		for _, p := range pe.Params {
+			names = append(names, p.Name)
+		}
+
+		if !reflect.DeepEqual(names, test.names) {
+			t.Errorf("\nDescription: %s\nmalformed parameters = %v, want %v", test.desc, names, test.names)
+		}
+
+		if e == nil {
+			t.Errorf("\nDescription: %s\nParseCallStatusEvent() should return the event with the error", test.desc)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseCallStatus(t *testing.T) {
+	for _, v := range CallStatusValues() {
+		parsed, err := ParseCallStatus(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseCallStatus(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	tests := []struct {
+		desc   string
+		in     string
+		status CallStatus
+		err    bool
+	}{
+		{"empty string should be the zero value", "", CallStatus(0), false},
+		{"value should be case-insensitive", "In-Progress", CallStatusInProgress, false},
+		{"unknown value should fail", "on-hold", CallStatus(0), true},
+	}
+
+	for _, test := range tests {
+		status, err := ParseCallStatus(test.in)
+
+		if test.err {
+			if errors.Cause(err) != ErrUnknownValue {
+				t.Errorf("\nDescription: %s\nParseCallStatus() error = %v, want cause %v", test.desc, err, ErrUnknownValue)
+			}
+
+			continue
+		}
+
+		if err != nil || status != test.status {
+			t.Errorf("\nDescription: %s\nParseCallStatus() = %s, %v, want %s", test.desc, status, err, test.status)
+		}
+	}
+}
+
+func TestCallStatus_Final(t *testing.T) {
+	tests := []struct {
+		status CallStatus
+		final  bool
+	}{
+		{CallStatusQueued, false},
+		{CallStatusRinging, false},
+		{CallStatusInProgress, false},
+		{CallStatusCompleted, true},
+		{CallStatusBusy, true},
+		{CallStatusNoAnswer, true},
+		{CallStatusCanceled, true},
+	}
+
+	for _, test := range tests {
+		if final := test.status.Final(); final != test.final {
+			t.Errorf("%s.Final() = %t, want %t", test.status, final, test.final)
+		}
+	}
+}
+
+func TestParseCallDirection(t *testing.T) {
+	for _, v := range CallDirectionValues() {
+		parsed, err := ParseCallDirection(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseCallDirection(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseCallDirection("sideways"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseCallDirection(sideways) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+
+	var d CallDirection
+
+	if err := d.UnmarshalText([]byte("outbound-dial")); err != nil || d != CallDirectionOutboundDial {
+		t.Errorf("UnmarshalText() = %s, %v, want %s", d, err, CallDirectionOutboundDial)
+	}
+}
+
+func TestParseAnsweredBy(t *testing.T) {
+	for _, v := range AnsweredByValues() {
+		parsed, err := ParseAnsweredBy(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseAnsweredBy(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseAnsweredBy("robot"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseAnsweredBy(robot) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+
+	tests := []struct {
+		answeredBy AnsweredBy
+		machine    bool
+	}{
+		{AnsweredByHuman, false},
+		{AnsweredByMachineStart, true},
+		{AnsweredByMachineEndOther, true},
+		{AnsweredByFax, false},
+		{AnsweredByUnknown, false},
+	}
+
+	for _, test := range tests {
+		if test.answeredBy.Machine() != test.machine {
+			t.Errorf("%s.Machine() = %t, want %t", test.answeredBy, !test.machine, test.machine)
+		}
+	}
+}
+
+func TestParseDialCallStatus(t *testing.T) {
+	for _, v := range DialCallStatusValues() {
+		parsed, err := ParseDialCallStatus(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseDialCallStatus(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseDialCallStatus("ringing"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseDialCallStatus(ringing) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+
+	var s DialCallStatus
+
+	if err := s.UnmarshalText([]byte("No-Answer")); err != nil || s != DialCallStatusNoAnswer {
+		t.Errorf("UnmarshalText() = %s, %v, want %s", s, err, DialCallStatusNoAnswer)
+	}
+
+	tests := []struct {
+		status   DialCallStatus
+		answered bool
+	}{
+		{DialCallStatusCompleted, true},
+		{DialCallStatusAnswered, true},
+		{DialCallStatusBusy, false},
+		{DialCallStatusNoAnswer, false},
+		{DialCallStatusFailed, false},
+		{DialCallStatusCanceled, false},
+	}
+
+	for _, test := range tests {
+		if test.status.Answered() != test.answered {
+			t.Errorf("%s.Answered() = %t, want %t", test.status, !test.answered, test.answered)
+		}
+	}
+}
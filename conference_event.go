@@ -0,0 +1,249 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ConferenceEventType is what happened in a conference, as sent in the
+// StatusCallbackEvent parameter of conference status callback requests.
+type ConferenceEventType uint8
+
+const (
+	// ConferenceStart means the conference started mixing audio.
+	ConferenceStart ConferenceEventType = iota + 1
+
+	// ConferenceEnd means the conference ended.
+	ConferenceEnd
+
+	// ParticipantJoin means a participant joined the conference.
+	ParticipantJoin
+
+	// ParticipantLeave means a participant left the conference.
+	ParticipantLeave
+
+	// ParticipantMute means a participant was muted.
+	ParticipantMute
+
+	// ParticipantUnmute means a participant was unmuted.
+	ParticipantUnmute
+
+	// ParticipantHold means a participant was put on hold.
+	ParticipantHold
+
+	// ParticipantUnhold means a participant was taken off hold.
+	ParticipantUnhold
+
+	// ParticipantModify means a participant's coaching settings changed.
+	ParticipantModify
+
+	// ParticipantSpeechStart means a participant started speaking.
+	ParticipantSpeechStart
+
+	// ParticipantSpeechStop means a participant stopped speaking.
+	ParticipantSpeechStop
+
+	// AnnouncementEnd means an announcement to the conference finished.
+	AnnouncementEnd
+
+	// AnnouncementFail means an announcement to the conference failed.
+	AnnouncementFail
+)
+
+func (t ConferenceEventType) String() string {
+	switch t {
+	case ConferenceStart:
+		return "conference-start"
+	case ConferenceEnd:
+		return "conference-end"
+	case ParticipantJoin:
+		return "participant-join"
+	case ParticipantLeave:
+		return "participant-leave"
+	case ParticipantMute:
+		return "participant-mute"
+	case ParticipantUnmute:
+		return "participant-unmute"
+	case ParticipantHold:
+		return "participant-hold"
+	case ParticipantUnhold:
+		return "participant-unhold"
+	case ParticipantModify:
+		return "participant-modify"
+	case ParticipantSpeechStart:
+		return "participant-speech-start"
+	case ParticipantSpeechStop:
+		return "participant-speech-stop"
+	case AnnouncementEnd:
+		return "announcement-end"
+	case AnnouncementFail:
+		return "announcement-fail"
+	default:
+		return ""
+	}
+}
+
+// Participant returns whether the event is about a participant, in which
+// case the CallSid of the ConferenceEvent is the participant's call.
+func (t ConferenceEventType) Participant() bool {
+	return t >= ParticipantJoin && t <= ParticipantSpeechStop
+}
+
+// ConferenceEventTypeValues returns all of the ConferenceEventType constants.
+func ConferenceEventTypeValues() []ConferenceEventType {
+	return []ConferenceEventType{
+		ConferenceStart,
+		ConferenceEnd,
+		ParticipantJoin,
+		ParticipantLeave,
+		ParticipantMute,
+		ParticipantUnmute,
+		ParticipantHold,
+		ParticipantUnhold,
+		ParticipantModify,
+		ParticipantSpeechStart,
+		ParticipantSpeechStop,
+		AnnouncementEnd,
+		AnnouncementFail,
+	}
+}
+
+// ParseConferenceEventType returns the ConferenceEventType whose value is s,
+// compared case-insensitively. This function returns a wrapped error (see
+// package documentation for more info).
+func ParseConferenceEventType(s string) (ConferenceEventType, error) {
+	if s == "" {
+		return ConferenceEventType(0), nil
+	}
+
+	for _, v := range ConferenceEventTypeValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return ConferenceEventType(0), unknownValue("ConferenceEventType", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t ConferenceEventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *ConferenceEventType) UnmarshalText(text []byte) error {
+	parsed, err := ParseConferenceEventType(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+// ConferenceEvent is the parameters of a conference status callback request,
+// which Twilio makes to the StatusCallback URL of the Conference noun of a
+// Dial for the events in its StatusCallbackEvent.
+type ConferenceEvent struct {
+	ConferenceSid string
+	AccountSid    string
+	FriendlyName  string
+
+	StatusCallbackEvent ConferenceEventType
+
+	// SequenceNumber is the order of the event among the status callbacks
+	// of the conference. Callbacks can arrive out of order.
+	SequenceNumber int
+
+	// Timestamp is when the event happened.
+	Timestamp time.Time
+
+	// CallSid and ParticipantLabel identify the participant of participant
+	// events.
+	CallSid          string
+	ParticipantLabel string
+
+	// Muted, Hold, Coaching, CallSidToCoach, EndConferenceOnExit, and
+	// StartConferenceOnEnter are the participant's settings after the
+	// event, for participant events.
+	Muted                  bool
+	Hold                   bool
+	Coaching               bool
+	CallSidToCoach         string
+	EndConferenceOnExit    bool
+	StartConferenceOnEnter bool
+
+	// ReasonParticipantLeft is why the participant left, for the
+	// participant-leave event, such as "participant_hung_up".
+	ReasonParticipantLeft string
+
+	// ReasonConferenceEnded is why the conference ended, for the
+	// conference-end event, such as "last-participant-left". The
+	// participant that ended it, if any, is CallSidEndingConference.
+	ReasonConferenceEnded            string
+	CallSidEndingConference          string
+	ParticipantLabelEndingConference string
+
+	// Form is all of the parameters of the request.
+	Form url.Values
+}
+
+// ParseConferenceEvent parses the parameters of a conference status callback
+// request, from its query string and form-encoded body. If any parameters
+// are malformed, the error's cause is a *ParamError listing them, and the
+// *ConferenceEvent is still returned with the rest. This function returns a
+// wrapped error (see package documentation for more info).
+func ParseConferenceEvent(r *http.Request) (*ConferenceEvent, error) {
+	form, err := parseForm(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing conference event failed")
+	}
+
+	return decodeConferenceEvent(form)
+}
+
+func decodeConferenceEvent(form url.Values) (*ConferenceEvent, error) {
+	d := &paramDecoder{form: form}
+
+	e := &ConferenceEvent{
+		ConferenceSid:                    d.sid("ConferenceSid", "CF"),
+		AccountSid:                       d.sid("AccountSid", "AC"),
+		FriendlyName:                     d.string("FriendlyName"),
+		SequenceNumber:                   d.int("SequenceNumber"),
+		Timestamp:                        d.time("Timestamp", TimestampLayout),
+		CallSid:                          d.sid("CallSid", "CA"),
+		ParticipantLabel:                 d.string("ParticipantLabel"),
+		Muted:                            d.bool("Muted"),
+		Hold:                             d.bool("Hold"),
+		Coaching:                         d.bool("Coaching"),
+		CallSidToCoach:                   d.sid("CallSidToCoach", "CA"),
+		EndConferenceOnExit:              d.bool("EndConferenceOnExit"),
+		StartConferenceOnEnter:           d.bool("StartConferenceOnEnter"),
+		ReasonParticipantLeft:            d.string("ReasonParticipantLeft"),
+		ReasonConferenceEnded:            d.string("ReasonConferenceEnded"),
+		CallSidEndingConference:          d.sid("CallSidEndingConference", "CA"),
+		ParticipantLabelEndingConference: d.string("ParticipantLabelEndingConference"),
+		Form:                             form,
+	}
+
+	d.text("StatusCallbackEvent", &e.StatusCallbackEvent)
+
+	if e.SequenceNumber < 0 {
+		d.fail("SequenceNumber", form.Get("SequenceNumber"), errors.New("negative"))
+	}
+
+	return e, d.err("conference event")
+}
@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+const testConferenceSid = "CF0123456789abcdef0123456789abcdef"
+
+func TestParseConferenceEventType(t *testing.T) {
+	for _, v := range ConferenceEventTypeValues() {
+		parsed, err := ParseConferenceEventType(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseConferenceEventType(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseConferenceEventType("participant-dance"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseConferenceEventType(participant-dance) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+
+	tests := []struct {
+		typ         ConferenceEventType
+		participant bool
+	}{
+		{ConferenceStart, false},
+		{ConferenceEnd, false},
+		{ParticipantJoin, true},
+		{ParticipantSpeechStop, true},
+		{AnnouncementEnd, false},
+	}
+
+	for _, test := range tests {
+		if test.typ.Participant() != test.participant {
+			t.Errorf("%s.Participant() = %t, want %t", test.typ, !test.participant, test.participant)
+		}
+	}
+}
+
+func TestParseConferenceEvent(t *testing.T) {
+	params := url.Values{
+		"ConferenceSid":          {testConferenceSid},
+		"AccountSid":             {testAccountSid},
+		"FriendlyName":           {"support-42"},
+		"StatusCallbackEvent":    {"participant-join"},
+		"SequenceNumber":         {"2"},
+		"Timestamp":              {"Mon, 19 Oct 2026 12:00:05 +0000"},
+		"CallSid":                {testCallSid},
+		"ParticipantLabel":       {"customer"},
+		"Muted":                  {"false"},
+		"Hold":                   {"true"},
+		"Coaching":               {"false"},
+		"EndConferenceOnExit":    {"true"},
+		"StartConferenceOnEnter": {"true"},
+	}
+
+	e, err := ParseConferenceEvent(formRequest(params))
+
+	if err != nil {
+		t.Fatalf("ParseConferenceEvent() unexpected error: %s", err)
+	}
+
+	want := &ConferenceEvent{
+		ConferenceSid:          testConferenceSid,
+		AccountSid:             testAccountSid,
+		FriendlyName:           "support-42",
+		StatusCallbackEvent:    ParticipantJoin,
+		SequenceNumber:         2,
+		Timestamp:              e.Timestamp,
+		CallSid:                testCallSid,
+		ParticipantLabel:       "customer",
+		Hold:                   true,
+		EndConferenceOnExit:    true,
+		StartConferenceOnEnter: true,
+		Form:                   e.Form,
+	}
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("ParseConferenceEvent() = %+v, want %+v", e, want)
+	}
+
+	if e.Timestamp.Unix() != 1792411205 {
+		t.Errorf("Timestamp = %s, want 2026-10-19 12:00:05 UTC", e.Timestamp)
+	}
+}
+
+func TestParseConferenceEvent_malformed(t *testing.T) {
+	tests := []struct {
+		desc   string
+		params url.Values
+		names  []string
+	}{
+		{"unknown StatusCallbackEvent", url.Values{"StatusCallbackEvent": {"participant-dance"}}, []string{"StatusCallbackEvent"}},
+		{"ConferenceSid of the wrong type", url.Values{"ConferenceSid": {testCallSid}}, []string{"ConferenceSid"}},
+		{"Muted that isn't a bool", url.Values{"Muted": {"maybe"}}, []string{"Muted"}},
+		{"negative SequenceNumber", url.Values{"SequenceNumber": {"-1"}}, []string{"SequenceNumber"}},
+	}
+
+	for _, test := range tests {
+		e, err := ParseConferenceEvent(formRequest(test.params))
+
+		pe, ok := errors.Cause(err).(*ParamError)
+
+		if !ok {
+			t.Errorf("\nDescription: %s\nParseConferenceEvent() error = %v, want a *ParamError", test.desc, err)
+			continue
+		}
+
+		var names []string
+
+		for _, p := range pe.Params {
+			names = append(names, p.Name)
+		}
+
+		if !reflect.DeepEqual(names, test.names) {
+			t.Errorf("\nDescription: %s\nmalformed parameters = %v, want %v", test.desc, names, test.names)
+		}
+
+		if e == nil {
+			t.Errorf("\nDescription: %s\nParseConferenceEvent() should return the event with the error", test.desc)
+		}
+	}
+}
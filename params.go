@@ -0,0 +1,190 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MalformedParam is a webhook request parameter whose value couldn't be
+// parsed.
+type MalformedParam struct {
+	Name  string
+	Value string
+
+	// Err is why the value couldn't be parsed.
+	Err error
+}
+
+func (p MalformedParam) String() string {
+	return fmt.Sprintf("parameter %s %q: %s", p.Name, p.Value, p.Err)
+}
+
+// ParamError is the error returned, wrapped, when a webhook request has
+// parameters that couldn't be parsed. Use errors.Cause() from
+// github.com/pkg/errors to get to it.
+type ParamError struct {
+	// Params are all of the malformed parameters, in the order they were
+	// parsed. There is always at least one.
+	Params []MalformedParam
+}
+
+func (e *ParamError) Error() string {
+	if len(e.Params) == 1 {
+		return e.Params[0].String()
+	}
+
+	return fmt.Sprintf("%s (and %d more)", e.Params[0], len(e.Params)-1)
+}
+
+// parseForm returns the parameters of a webhook request, from its query
+// string and, for POST requests, its form-encoded body.
+func parseForm(r *http.Request) (url.Values, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, errors.Wrap(err, "parsing form failed")
+	}
+
+	return r.Form, nil
+}
+
+// paramDecoder decodes the parameters of a webhook request, collecting the
+// malformed ones so they can all be reported at once.
+type paramDecoder struct {
+	form      url.Values
+	malformed []MalformedParam
+}
+
+func (d *paramDecoder) fail(name, value string, err error) {
+	d.malformed = append(d.malformed, MalformedParam{Name: name, Value: value, Err: err})
+}
+
+func (d *paramDecoder) string(name string) string {
+	return d.form.Get(name)
+}
+
+// sid decodes a Twilio SID, which is a two letter prefix followed by 32 hex
+// digits.
+func (d *paramDecoder) sid(name, prefix string) string {
+	s := d.form.Get(name)
+
+	if s != "" && !validSid(s, prefix) {
+		d.fail(name, s, errors.Errorf("not a SID starting with %s", prefix))
+	}
+
+	return s
+}
+
+func validSid(s, prefix string) bool {
+	if len(s) != 34 || !strings.HasPrefix(s, prefix) {
+		return false
+	}
+
+	for _, c := range s[2:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *paramDecoder) float(name string) float64 {
+	s := d.form.Get(name)
+
+	if s == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		d.fail(name, s, errors.New("not a number"))
+	}
+
+	return f
+}
+
+func (d *paramDecoder) bool(name string) bool {
+	s := d.form.Get(name)
+
+	if s == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(s)
+
+	if err != nil {
+		d.fail(name, s, errors.New("not true or false"))
+	}
+
+	return b
+}
+
+func (d *paramDecoder) int(name string) int {
+	s := d.form.Get(name)
+
+	if s == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(s)
+
+	if err != nil {
+		d.fail(name, s, errors.New("not an integer"))
+	}
+
+	return n
+}
+
+// seconds decodes a duration given in whole seconds.
+func (d *paramDecoder) seconds(name string) time.Duration {
+	return time.Duration(d.int(name)) * time.Second
+}
+
+// time decodes a time in the layout.
+func (d *paramDecoder) time(name, layout string) time.Time {
+	s := d.form.Get(name)
+
+	if s == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(layout, s)
+
+	if err != nil {
+		d.fail(name, s, errors.Errorf("not a time like %q", layout))
+	}
+
+	return t
+}
+
+// text decodes the parameter in to v, such as a CallStatus.
+func (d *paramDecoder) text(name string, v interface {
+	UnmarshalText([]byte) error
+}) {
+	s := d.form.Get(name)
+
+	if err := v.UnmarshalText([]byte(s)); err != nil {
+		d.fail(name, s, errors.Cause(err))
+	}
+}
+
+// err returns a *ParamError of the malformed parameters, if there are any.
+func (d *paramDecoder) err(what string) error {
+	if len(d.malformed) == 0 {
+		return nil
+	}
+
+	return errors.Wrapf(&ParamError{Params: d.malformed}, "parsing %s failed", what)
+}
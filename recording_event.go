@@ -0,0 +1,356 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RecordingStatus is the status of a recording, as sent in the
+// RecordingStatus parameter of recording status callback requests.
+type RecordingStatus uint8
+
+const (
+	// RecordingStatusInProgress means the recording has started.
+	RecordingStatusInProgress RecordingStatus = iota + 1
+
+	// RecordingStatusCompleted means the recording is available.
+	RecordingStatusCompleted
+
+	// RecordingStatusAbsent means the recording was silent, so it was
+	// discarded.
+	RecordingStatusAbsent
+
+	// RecordingStatusFailed means the recording couldn't be made.
+	RecordingStatusFailed
+)
+
+func (s RecordingStatus) String() string {
+	switch s {
+	case RecordingStatusInProgress:
+		return "in-progress"
+	case RecordingStatusCompleted:
+		return "completed"
+	case RecordingStatusAbsent:
+		return "absent"
+	case RecordingStatusFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+// RecordingStatusValues returns all of the RecordingStatus constants.
+func RecordingStatusValues() []RecordingStatus {
+	return []RecordingStatus{
+		RecordingStatusInProgress,
+		RecordingStatusCompleted,
+		RecordingStatusAbsent,
+		RecordingStatusFailed,
+	}
+}
+
+// ParseRecordingStatus returns the RecordingStatus whose value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseRecordingStatus(s string) (RecordingStatus, error) {
+	if s == "" {
+		return RecordingStatus(0), nil
+	}
+
+	for _, v := range RecordingStatusValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return RecordingStatus(0), unknownValue("RecordingStatus", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s RecordingStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *RecordingStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParseRecordingStatus(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
+
+// TranscriptionStatus is the status of a transcription, as sent in the
+// TranscriptionStatus parameter of transcription callback requests.
+type TranscriptionStatus uint8
+
+const (
+	// TranscriptionStatusCompleted means the transcription succeeded.
+	TranscriptionStatusCompleted TranscriptionStatus = iota + 1
+
+	// TranscriptionStatusFailed means the recording couldn't be
+	// transcribed.
+	TranscriptionStatusFailed
+)
+
+func (s TranscriptionStatus) String() string {
+	switch s {
+	case TranscriptionStatusCompleted:
+		return "completed"
+	case TranscriptionStatusFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+// TranscriptionStatusValues returns all of the TranscriptionStatus constants.
+func TranscriptionStatusValues() []TranscriptionStatus {
+	return []TranscriptionStatus{
+		TranscriptionStatusCompleted,
+		TranscriptionStatusFailed,
+	}
+}
+
+// ParseTranscriptionStatus returns the TranscriptionStatus whose value is s,
+// compared case-insensitively. This function returns a wrapped error (see
+// package documentation for more info).
+func ParseTranscriptionStatus(s string) (TranscriptionStatus, error) {
+	if s == "" {
+		return TranscriptionStatus(0), nil
+	}
+
+	for _, v := range TranscriptionStatusValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return TranscriptionStatus(0), unknownValue("TranscriptionStatus", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s TranscriptionStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *TranscriptionStatus) UnmarshalText(text []byte) error {
+	parsed, err := ParseTranscriptionStatus(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
+
+// MediaFormat is an audio format Twilio serves recordings in.
+type MediaFormat uint8
+
+const (
+	// MediaWAV is uncompressed WAV audio, which is what Twilio serves
+	// recordings in by default.
+	MediaWAV MediaFormat = iota + 1
+
+	// MediaMP3 is MP3 audio, which is smaller than WAV.
+	MediaMP3
+)
+
+func (f MediaFormat) String() string {
+	switch f {
+	case MediaWAV:
+		return "wav"
+	case MediaMP3:
+		return "mp3"
+	default:
+		return ""
+	}
+}
+
+// MediaURL returns the URL of the recording at recordingURL, such as the
+// RecordingUrl parameter of a callback, in the format. Any extension the URL
+// already has is replaced. This function returns a wrapped error (see package
+// documentation for more info).
+func MediaURL(recordingURL string, f MediaFormat) (string, error) {
+	if f.String() == "" {
+		return "", errors.Errorf("building media URL failed: unknown format %d", f)
+	}
+
+	u, err := url.Parse(recordingURL)
+
+	if err != nil {
+		return "", errors.Wrap(err, "building media URL failed")
+	}
+
+	if u.Path == "" || strings.HasSuffix(u.Path, "/") {
+		return "", errors.Errorf("building media URL failed: %q isn't the URL of a recording", recordingURL)
+	}
+
+	switch ext := path.Ext(u.Path); ext {
+	case ".wav", ".mp3", ".json":
+		u.Path = strings.TrimSuffix(u.Path, ext)
+	}
+
+	u.Path += "." + f.String()
+	u.RawPath = ""
+
+	return u.String(), nil
+}
+
+// RecordingStatusEvent is the parameters of a recording status callback
+// request, which Twilio makes to the RecordingStatusCallback URL of a Record,
+// a Dial, or the Conference noun of a Dial.
+type RecordingStatusEvent struct {
+	AccountSid    string
+	CallSid       string
+	ConferenceSid string
+
+	RecordingSid    string
+	RecordingURL    string
+	RecordingStatus RecordingStatus
+
+	// RecordingDuration is the length of the recording, once it's
+	// completed.
+	RecordingDuration time.Duration
+
+	// RecordingChannels is the number of channels in the recording, 1 or
+	// 2.
+	RecordingChannels int
+
+	// RecordingSource is what made the recording, such as "RecordVerb",
+	// "DialVerb", or "Conference".
+	RecordingSource string
+
+	// RecordingStartTime is when the recording started.
+	RecordingStartTime time.Time
+
+	// ErrorCode is the Twilio error code of a failed recording.
+	ErrorCode int
+
+	// Form is all of the parameters of the request.
+	Form url.Values
+}
+
+// MediaURL returns the URL of the recording in the format.
+func (e *RecordingStatusEvent) MediaURL(f MediaFormat) (string, error) {
+	return MediaURL(e.RecordingURL, f)
+}
+
+// ParseRecordingStatusEvent parses the parameters of a recording status
+// callback request, from its query string and form-encoded body. If any
+// parameters are malformed, the error's cause is a *ParamError listing them,
+// and the *RecordingStatusEvent is still returned with the rest. This
+// function returns a wrapped error (see package documentation for more
+// info).
+func ParseRecordingStatusEvent(r *http.Request) (*RecordingStatusEvent, error) {
+	form, err := parseForm(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing recording status event failed")
+	}
+
+	return decodeRecordingStatusEvent(form)
+}
+
+func decodeRecordingStatusEvent(form url.Values) (*RecordingStatusEvent, error) {
+	d := &paramDecoder{form: form}
+
+	e := &RecordingStatusEvent{
+		AccountSid:         d.sid("AccountSid", "AC"),
+		CallSid:            d.sid("CallSid", "CA"),
+		ConferenceSid:      d.sid("ConferenceSid", "CF"),
+		RecordingSid:       d.sid("RecordingSid", "RE"),
+		RecordingURL:       d.string("RecordingUrl"),
+		RecordingDuration:  d.seconds("RecordingDuration"),
+		RecordingChannels:  d.int("RecordingChannels"),
+		RecordingSource:    d.string("RecordingSource"),
+		RecordingStartTime: d.time("RecordingStartTime", TimestampLayout),
+		ErrorCode:          d.int("ErrorCode"),
+		Form:               form,
+	}
+
+	d.text("RecordingStatus", &e.RecordingStatus)
+
+	return e, d.err("recording status event")
+}
+
+// TranscriptionEvent is the parameters of a transcription callback request,
+// which Twilio makes to the TranscribeCallback URL of a Record.
+type TranscriptionEvent struct {
+	AccountSid string
+	CallSid    string
+
+	TranscriptionSid    string
+	TranscriptionText   string
+	TranscriptionStatus TranscriptionStatus
+	TranscriptionURL    string
+
+	// RecordingSid and RecordingURL are the recording that was
+	// transcribed.
+	RecordingSid string
+	RecordingURL string
+
+	From, To string
+
+	// Form is all of the parameters of the request.
+	Form url.Values
+}
+
+// MediaURL returns the URL of the transcribed recording in the format.
+func (e *TranscriptionEvent) MediaURL(f MediaFormat) (string, error) {
+	return MediaURL(e.RecordingURL, f)
+}
+
+// ParseTranscriptionEvent parses the parameters of a transcription callback
+// request, from its query string and form-encoded body. If any parameters
+// are malformed, the error's cause is a *ParamError listing them, and the
+// *TranscriptionEvent is still returned with the rest. This function returns
+// a wrapped error (see package documentation for more info).
+func ParseTranscriptionEvent(r *http.Request) (*TranscriptionEvent, error) {
+	form, err := parseForm(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing transcription event failed")
+	}
+
+	return decodeTranscriptionEvent(form)
+}
+
+func decodeTranscriptionEvent(form url.Values) (*TranscriptionEvent, error) {
+	d := &paramDecoder{form: form}
+
+	e := &TranscriptionEvent{
+		AccountSid:        d.sid("AccountSid", "AC"),
+		CallSid:           d.sid("CallSid", "CA"),
+		TranscriptionSid:  d.sid("TranscriptionSid", "TR"),
+		TranscriptionText: d.string("TranscriptionText"),
+		TranscriptionURL:  d.string("TranscriptionUrl"),
+		RecordingSid:      d.sid("RecordingSid", "RE"),
+		RecordingURL:      d.string("RecordingUrl"),
+		From:              d.string("From"),
+		To:                d.string("To"),
+		Form:              form,
+	}
+
+	d.text("TranscriptionStatus", &e.TranscriptionStatus)
+
+	return e, d.err("transcription event")
+}
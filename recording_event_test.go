@@ -0,0 +1,182 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	testRecordingSid = "RE0123456789abcdef0123456789abcdef"
+	testRecordingURL = "https://api.twilio.com/2010-04-01/Accounts/" + testAccountSid + "/Recordings/" + testRecordingSid
+)
+
+func TestParseRecordingStatus(t *testing.T) {
+	for _, v := range RecordingStatusValues() {
+		parsed, err := ParseRecordingStatus(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseRecordingStatus(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseRecordingStatus("paused"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseRecordingStatus(paused) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+}
+
+func TestParseTranscriptionStatus(t *testing.T) {
+	for _, v := range TranscriptionStatusValues() {
+		parsed, err := ParseTranscriptionStatus(v.String())
+
+		if err != nil || parsed != v {
+			t.Errorf("ParseTranscriptionStatus(%q) = %d, %v, want %d", v.String(), parsed, err, v)
+		}
+	}
+
+	if _, err := ParseTranscriptionStatus("pending"); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("ParseTranscriptionStatus(pending) error = %v, want cause %v", err, ErrUnknownValue)
+	}
+}
+
+func TestMediaURL(t *testing.T) {
+	tests := []struct {
+		desc   string
+		in     string
+		format MediaFormat
+		want   string
+		err    bool
+	}{
+		{"extension should be added", testRecordingURL, MediaMP3, testRecordingURL + ".mp3", false},
+		{"extension should be replaced", testRecordingURL + ".json", MediaWAV, testRecordingURL + ".wav", false},
+		{"query should be kept", testRecordingURL + "?RequestedChannels=2", MediaWAV, testRecordingURL + ".wav?RequestedChannels=2", false},
+		{"unknown format should fail", testRecordingURL, MediaFormat(0), "", true},
+		{"empty URL should fail", "", MediaMP3, "", true},
+		{"invalid URL should fail", "http://%zz", MediaMP3, "", true},
+	}
+
+	for _, test := range tests {
+		got, err := MediaURL(test.in, test.format)
+
+		if test.err {
+			if err == nil {
+				t.Errorf("\nDescription: %s\nMediaURL() = %q, want an error", test.desc, got)
+			}
+
+			continue
+		}
+
+		if err != nil || got != test.want {
+			t.Errorf("\nDescription: %s\nMediaURL() = %q, %v, want %q", test.desc, got, err, test.want)
+		}
+	}
+}
+
+func TestParseRecordingStatusEvent(t *testing.T) {
+	params := url.Values{
+		"AccountSid":         {testAccountSid},
+		"CallSid":            {testCallSid},
+		"RecordingSid":       {testRecordingSid},
+		"RecordingUrl":       {testRecordingURL},
+		"RecordingStatus":    {"completed"},
+		"RecordingDuration":  {"17"},
+		"RecordingChannels":  {"2"},
+		"RecordingSource":    {"DialVerb"},
+		"RecordingStartTime": {"Mon, 19 Oct 2026 12:00:05 +0000"},
+	}
+
+	e, err := ParseRecordingStatusEvent(formRequest(params))
+
+	if err != nil {
+		t.Fatalf("ParseRecordingStatusEvent() unexpected error: %s", err)
+	}
+
+	want := &RecordingStatusEvent{
+		AccountSid:         testAccountSid,
+		CallSid:            testCallSid,
+		RecordingSid:       testRecordingSid,
+		RecordingURL:       testRecordingURL,
+		RecordingStatus:    RecordingStatusCompleted,
+		RecordingDuration:  17 * time.Second,
+		RecordingChannels:  2,
+		RecordingSource:    "DialVerb",
+		RecordingStartTime: e.RecordingStartTime,
+		Form:               e.Form,
+	}
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("ParseRecordingStatusEvent() = %+v, want %+v", e, want)
+	}
+
+	if e.RecordingStartTime.Unix() != 1792411205 {
+		t.Errorf("RecordingStartTime = %s, want 2026-10-19 12:00:05 UTC", e.RecordingStartTime)
+	}
+
+	if u, err := e.MediaURL(MediaMP3); err != nil || u != testRecordingURL+".mp3" {
+		t.Errorf("MediaURL() = %q, %v, want %q", u, err, testRecordingURL+".mp3")
+	}
+
+	_, err = ParseRecordingStatusEvent(formRequest(url.Values{"RecordingStatus": {"paused"}, "ErrorCode": {"x"}}))
+
+	if pe, ok := errors.Cause(err).(*ParamError); !ok || len(pe.Params) != 2 {
+		t.Errorf("ParseRecordingStatusEvent() of malformed parameters error = %v, want a *ParamError of 2", err)
+	}
+}
+
+func TestParseTranscriptionEvent(t *testing.T) {
+	params := url.Values{
+		"AccountSid":          {testAccountSid},
+		"CallSid":             {testCallSid},
+		"TranscriptionSid":    {"TR0123456789abcdef0123456789abcdef"},
+		"TranscriptionText":   {"Please call me back."},
+		"TranscriptionStatus": {"completed"},
+		"TranscriptionUrl":    {"https://api.twilio.com/2010-04-01/Accounts/" + testAccountSid + "/Transcriptions/TR0123456789abcdef0123456789abcdef"},
+		"RecordingSid":        {testRecordingSid},
+		"RecordingUrl":        {testRecordingURL},
+		"From":                {"+15005550006"},
+		"To":                  {"+15005550100"},
+	}
+
+	e, err := ParseTranscriptionEvent(formRequest(params))
+
+	if err != nil {
+		t.Fatalf("ParseTranscriptionEvent() unexpected error: %s", err)
+	}
+
+	want := &TranscriptionEvent{
+		AccountSid:          testAccountSid,
+		CallSid:             testCallSid,
+		TranscriptionSid:    "TR0123456789abcdef0123456789abcdef",
+		TranscriptionText:   "Please call me back.",
+		TranscriptionStatus: TranscriptionStatusCompleted,
+		TranscriptionURL:    params.Get("TranscriptionUrl"),
+		RecordingSid:        testRecordingSid,
+		RecordingURL:        testRecordingURL,
+		From:                "+15005550006",
+		To:                  "+15005550100",
+		Form:                e.Form,
+	}
+
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("ParseTranscriptionEvent() = %+v, want %+v", e, want)
+	}
+
+	if u, err := e.MediaURL(MediaWAV); err != nil || u != testRecordingURL+".wav" {
+		t.Errorf("MediaURL() = %q, %v, want %q", u, err, testRecordingURL+".wav")
+	}
+
+	_, err = ParseTranscriptionEvent(formRequest(url.Values{"TranscriptionSid": {testRecordingSid}}))
+
+	if pe, ok := errors.Cause(err).(*ParamError); !ok || pe.Params[0].Name != "TranscriptionSid" {
+		t.Errorf("ParseTranscriptionEvent() with a RecordingSid as TranscriptionSid error = %v, want a *ParamError", err)
+	}
+}
@@ -0,0 +1,422 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader is the HTTP header Twilio sends the signature of a request
+// in.
+const SignatureHeader = "X-Twilio-Signature"
+
+// BodySHA256Param is the query parameter Twilio adds to the URL of requests
+// with a JSON body, holding the hex-encoded SHA-256 hash of the body.
+const BodySHA256Param = "bodySHA256"
+
+// MaxBodySize is the largest JSON body, in bytes, that a Validator reads to
+// check it against its bodySHA256 hash. Twilio's JSON bodies are much smaller.
+const MaxBodySize = 1 << 20
+
+// ErrMissingSignature is the cause of the error returned when validating a
+// request without an X-Twilio-Signature header.
+var ErrMissingSignature = errors.New("missing signature")
+
+// ErrInvalidSignature is the cause of the error returned when validating a
+// request whose signature doesn't match, meaning it wasn't sent by Twilio or
+// was changed in transit.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrBodyTooLarge is the cause of the error returned when validating a request
+// with a JSON body larger than MaxBodySize.
+var ErrBodyTooLarge = errors.New("body too large")
+
+// Signature returns the X-Twilio-Signature of a request to the URL with the
+// POST parameters, which is the base64-encoded HMAC-SHA1 of the URL followed
+// by each parameter name and value, sorted, keyed with the auth token. The
+// params are nil for GET requests and requests with a JSON body.
+func Signature(authToken, url string, params url.Values) string {
+	return base64.StdEncoding.EncodeToString(signatureMAC(authToken, url, params))
+}
+
+func signatureMAC(authToken, url string, params url.Values) []byte {
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(url))
+
+	keys := make([]string, 0, len(params))
+
+	for k := range params {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		values := make([]string, len(params[k]))
+		copy(values, params[k])
+		sort.Strings(values)
+
+		for _, v := range values {
+			mac.Write([]byte(k))
+			mac.Write([]byte(v))
+		}
+	}
+
+	return mac.Sum(nil)
+}
+
+// ValidatorOption configures optional behavior of a Validator.
+type ValidatorOption func(*Validator)
+
+// WithForwardedHeaders makes the Validator use the X-Forwarded-Proto and
+// X-Forwarded-Host headers to work out the URL Twilio requested, for services
+// behind a load balancer or reverse proxy. Only use it when the proxy sets
+// those headers, otherwise a client can choose the URL that's validated.
+func WithForwardedHeaders() ValidatorOption {
+	return func(v *Validator) {
+		v.forwarded = true
+	}
+}
+
+// WithMatchHook sets a function that's called with the Match of every request
+// the Validator's Handler accepts, such as to monitor when requests stop being
+// signed with an old auth token during a rotation.
+func WithMatchHook(fn func(r *http.Request, m Match)) ValidatorOption {
+	return func(v *Validator) {
+		v.onMatch = fn
+	}
+}
+
+// WithBaseURL sets the scheme, host, and path prefix of the URL Twilio
+// requests, such as https://example.com/twilio, for services behind a proxy
+// that changes them. The path of each request is appended to the base URL's
+// path. It takes precedence over WithForwardedHeaders.
+func WithBaseURL(base *url.URL) ValidatorOption {
+	return func(v *Validator) {
+		v.base = base
+	}
+}
+
+// Validator validates the X-Twilio-Signature of requests, using the auth
+// tokens of the accounts that make them. It's safe for concurrent use.
+type Validator struct {
+	tokens    TokenProvider
+	forwarded bool
+	base      *url.URL
+	onMatch   func(*http.Request, Match)
+}
+
+// NewValidator returns a *Validator for requests signed with the auth token.
+func NewValidator(authToken string, opts ...ValidatorOption) *Validator {
+	return NewTokenValidator(StaticTokens(authToken), opts...)
+}
+
+// NewTokenValidator returns a *Validator for requests signed with any of the
+// auth tokens the TokenProvider returns for the account making the request.
+func NewTokenValidator(tokens TokenProvider, opts ...ValidatorOption) *Validator {
+	v := &Validator{tokens: tokens}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// URL returns the URL Twilio made the request to, as used in its signature.
+func (v *Validator) URL(r *http.Request) string {
+	scheme, host := "http", r.Host
+
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if v.forwarded {
+		if proto := firstHeaderValue(r, "X-Forwarded-Proto"); proto != "" {
+			scheme = strings.ToLower(proto)
+		}
+
+		if h := firstHeaderValue(r, "X-Forwarded-Host"); h != "" {
+			host = h
+		}
+	}
+
+	// the raw request target is the exact path and query Twilio requested,
+	// unless it's in absolute form or the request wasn't received by a server
+	uri := r.RequestURI
+
+	if !strings.HasPrefix(uri, "/") {
+		uri = r.URL.RequestURI()
+	}
+
+	if v.base != nil {
+		scheme, host = v.base.Scheme, v.base.Host
+		uri = strings.TrimSuffix(v.base.EscapedPath(), "/") + uri
+	}
+
+	return scheme + "://" + host + uri
+}
+
+// firstHeaderValue returns the first of a comma-separated list of header
+// values, which is the one set by the proxy closest to the client.
+func firstHeaderValue(r *http.Request, name string) string {
+	s := r.Header.Get(name)
+
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		s = s[:i]
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// Validate returns an error if the request's X-Twilio-Signature isn't valid.
+// It's ValidateRequest without the Match.
+func (v *Validator) Validate(r *http.Request) error {
+	_, err := v.ValidateRequest(r)
+	return err
+}
+
+// ValidateRequest returns which auth token the request's X-Twilio-Signature
+// was made with, or an error if it isn't valid. It parses the form of POST
+// requests, so the parameters are available in r.PostForm afterwards. For
+// requests with a bodySHA256 query parameter, it reads the body once the
+// signature of the URL is valid, up to MaxBodySize, replacing it so that it
+// can be read again. Because proxies may add or remove the default port of
+// the scheme, the URL is checked with and without it. Every candidate token
+// is checked, so the time taken doesn't depend on which one matched. This
+// function returns a wrapped error (see package documentation for more info).
+func (v *Validator) ValidateRequest(r *http.Request) (Match, error) {
+	sig := r.Header.Get(SignatureHeader)
+
+	if sig == "" {
+		return Match{}, errors.Wrap(ErrMissingSignature, "validating request failed")
+	}
+
+	want, err := base64.StdEncoding.DecodeString(sig)
+
+	if err != nil {
+		return Match{}, errors.Wrap(ErrInvalidSignature, "validating request failed: signature isn't base64")
+	}
+
+	// the signature of a request with a JSON body covers the URL, including
+	// the hash of the body, so the body is only read once it's valid
+	hash := r.URL.Query().Get(BodySHA256Param)
+
+	var params url.Values
+
+	if hash == "" {
+		if params, err = formParams(r); err != nil {
+			return Match{}, errors.Wrap(err, "validating request failed")
+		}
+	}
+
+	m := Match{AccountSid: accountSid(r, params), TokenIndex: -1}
+
+	tokens, err := v.tokens.AuthTokens(m.AccountSid)
+
+	if err != nil {
+		return Match{}, errors.Wrap(err, "validating request failed")
+	}
+
+	urls := urlVariants(v.URL(r))
+
+	for i, token := range tokens {
+		for _, u := range urls {
+			if hmac.Equal(signatureMAC(token, u, params), want) && m.TokenIndex < 0 {
+				m.TokenIndex = i
+			}
+		}
+	}
+
+	if m.TokenIndex < 0 {
+		return Match{}, errors.Wrap(ErrInvalidSignature, "validating request failed")
+	}
+
+	if hash != "" {
+		if err := checkBody(r, hash); err != nil {
+			return Match{}, errors.Wrap(err, "validating request failed")
+		}
+
+		if m.AccountSid == "" {
+			m.AccountSid = bodyAccountSid(r)
+		}
+	}
+
+	return m, nil
+}
+
+// bodyAccountSid returns the AccountSid in the JSON body of the request, if
+// it has one, leaving the body to be read again.
+func bodyAccountSid(r *http.Request) string {
+	buf, ok := r.Body.(interface{ Bytes() []byte })
+
+	if !ok {
+		return ""
+	}
+
+	var body struct {
+		AccountSid string
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &body); err != nil {
+		return ""
+	}
+
+	return body.AccountSid
+}
+
+// accountSid returns the AccountSid parameter of the request.
+func accountSid(r *http.Request, params url.Values) string {
+	if sid := params.Get("AccountSid"); sid != "" {
+		return sid
+	}
+
+	return r.URL.Query().Get("AccountSid")
+}
+
+// formParams returns the POST parameters of the request, which are included
+// in its signature.
+func formParams(r *http.Request) (url.Values, error) {
+	if r.Method != http.MethodPost {
+		return nil, nil
+	}
+
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/x-www-form-urlencoded" {
+		return nil, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, errors.Wrap(err, "parsing form failed")
+	}
+
+	return r.PostForm, nil
+}
+
+// checkBody checks the body of the request against its hash, hashing it as
+// it's read, and replaces it so that it can be read again.
+func checkBody(r *http.Request, hash string) error {
+	var body bytes.Buffer
+
+	h := sha256.New()
+
+	n, err := io.Copy(io.MultiWriter(&body, h), io.LimitReader(r.Body, MaxBodySize+1))
+
+	if err != nil {
+		return errors.Wrap(err, "reading body failed")
+	}
+
+	if n > MaxBodySize {
+		return errors.Wrapf(ErrBodyTooLarge, "body is larger than %d bytes", MaxBodySize)
+	}
+
+	r.Body = bodyBuffer{&body}
+
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(h.Sum(nil))), []byte(strings.ToLower(hash))) != 1 {
+		return errors.Wrap(ErrInvalidSignature, "body doesn't match "+BodySHA256Param)
+	}
+
+	return nil
+}
+
+// bodyBuffer is the body of a request that has been read, so it can be read
+// again.
+type bodyBuffer struct {
+	*bytes.Buffer
+}
+
+// Close implements the io.Closer interface.
+func (bodyBuffer) Close() error {
+	return nil
+}
+
+// urlVariants returns the URL, and the URL with the default port of its
+// scheme added or removed.
+func urlVariants(u string) []string {
+	i := strings.Index(u, "://")
+
+	if i < 0 {
+		return []string{u}
+	}
+
+	scheme, rest := u[:i], u[i+3:]
+	hostEnd := strings.IndexAny(rest, "/?")
+
+	if hostEnd < 0 {
+		hostEnd = len(rest)
+	}
+
+	host, uri := rest[:hostEnd], rest[hostEnd:]
+
+	port := "80"
+
+	if scheme == "https" {
+		port = "443"
+	}
+
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		if p != port {
+			return []string{u}
+		}
+
+		// keep the brackets of IPv6 addresses
+		if strings.Contains(h, ":") {
+			h = "[" + h + "]"
+		}
+
+		return []string{u, scheme + "://" + h + uri}
+	}
+
+	return []string{u, scheme + "://" + net.JoinHostPort(strings.Trim(host, "[]"), port) + uri}
+}
+
+// Handler returns an http.Handler that validates the signature of requests
+// and passes the valid ones to next. It responds with 403 Forbidden to
+// requests whose signature is missing or isn't valid, or that are from an
+// unknown account, 413 Request Entity Too Large to requests whose JSON body is
+// larger than MaxBodySize, and 500 Internal Server Error when the request
+// can't be validated for another reason, such as the TokenProvider failing.
+func (v *Validator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m, err := v.ValidateRequest(r)
+
+		if err != nil {
+			code := http.StatusInternalServerError
+
+			switch errors.Cause(err) {
+			case ErrMissingSignature, ErrInvalidSignature, ErrUnknownAccount:
+				code = http.StatusForbidden
+			case ErrBodyTooLarge:
+				code = http.StatusRequestEntityTooLarge
+			}
+
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+
+		if v.onMatch != nil {
+			v.onMatch(r, m)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
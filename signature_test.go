@@ -0,0 +1,302 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+const testAuthToken = "12345"
+
+func TestSignature(t *testing.T) {
+	params := url.Values{
+		"AccountSid":    {"AC9a9f9392lad99kla0sklakjs90j092j3"},
+		"ApiVersion":    {"2010-04-01"},
+		"CallSid":       {"CAd800bb12c0426a7ea4230e492fef2a4f"},
+		"CallStatus":    {"ringing"},
+		"Called":        {"+15306384866"},
+		"CalledCity":    {"OAKLAND"},
+		"CalledCountry": {"US"},
+		"CalledState":   {"CA"},
+		"CalledZip":     {"94612"},
+		"Caller":        {"+15306666666"},
+		"CallerCity":    {"SOUTH LAKE TAHOE"},
+		"CallerCountry": {"US"},
+		"CallerName":    {"CA Wireless Call"},
+		"CallerState":   {"CA"},
+		"CallerZip":     {"89449"},
+		"Direction":     {"inbound"},
+		"From":          {"+15306666666"},
+		"FromCity":      {"SOUTH LAKE TAHOE"},
+		"FromCountry":   {"US"},
+		"FromState":     {"CA"},
+		"FromZip":       {"89449"},
+		"To":            {"+15306384866"},
+		"ToCity":        {"OAKLAND"},
+		"ToCountry":     {"US"},
+		"ToState":       {"CA"},
+		"ToZip":         {"94612"},
+	}
+
+	sig := Signature("1c892n40nd03kdnc0112slzkl3091j20", "http://www.postbin.org/1ed898x", params)
+
+	if want := "fF+xx6dTinOaCdZ0aIeNkHr/ZAA="; sig != want {
+		t.Errorf("Signature() = %q, want %q", sig, want)
+	}
+
+	a := Signature(testAuthToken, "https://example.com/", url.Values{"a": {"2", "1"}})
+	b := Signature(testAuthToken, "https://example.com/", url.Values{"a": {"1", "2"}})
+
+	if a != b {
+		t.Errorf("Signature() should sort repeated values, got %q and %q", a, b)
+	}
+}
+
+// signedRequest returns a request to the URL, signed for signedURL.
+func signedRequest(method, target, signedURL string, params url.Values, body string) *http.Request {
+	var r *http.Request
+
+	if params != nil {
+		r = httptest.NewRequest(method, target, strings.NewReader(params.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		r = httptest.NewRequest(method, target, strings.NewReader(body))
+	}
+
+	r.Header.Set(SignatureHeader, Signature(testAuthToken, signedURL, params))
+
+	return r
+}
+
+func bodyHash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestValidator_Validate(t *testing.T) {
+	params := url.Values{"CallSid": {"CA123"}, "From": {"+15555550100"}}
+	body := `{"hello":"world"}`
+	jsonURL := "https://example.com/hook?" + BodySHA256Param + "=" + bodyHash(body)
+
+	tls := func(r *http.Request) *http.Request {
+		r.TLS = &tls.ConnectionState{}
+		return r
+	}
+
+	header := func(r *http.Request, kv ...string) *http.Request {
+		for i := 0; i < len(kv); i += 2 {
+			r.Header.Set(kv[i], kv[i+1])
+		}
+
+		return r
+	}
+
+	tests := []struct {
+		desc  string
+		v     *Validator
+		r     *http.Request
+		cause error
+	}{
+		{
+			"form POST should be valid",
+			NewValidator(testAuthToken),
+			signedRequest("POST", "http://example.com/hook?a=1", "http://example.com/hook?a=1", params, ""),
+			nil,
+		},
+		{
+			"GET should be valid",
+			NewValidator(testAuthToken),
+			signedRequest("GET", "http://example.com/hook?CallSid=CA123", "http://example.com/hook?CallSid=CA123", nil, ""),
+			nil,
+		},
+		{
+			"TLS request should use https",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", "https://example.com/hook", "https://example.com/hook", params, "")),
+			nil,
+		},
+		{
+			"JSON body should be valid",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", jsonURL, jsonURL, nil, body)),
+			nil,
+		},
+		{
+			"JSON body that doesn't match its hash should be invalid",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", jsonURL, jsonURL, nil, `{"hello":"mallory"}`)),
+			ErrInvalidSignature,
+		},
+		{
+			"signature with the default port should be valid without it",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", "https://example.com/hook", "https://example.com:443/hook", params, "")),
+			nil,
+		},
+		{
+			"signature without the default port should be valid with it",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", "https://example.com:443/hook", "https://example.com/hook", params, "")),
+			nil,
+		},
+		{
+			"signature with another port should be invalid",
+			NewValidator(testAuthToken),
+			tls(signedRequest("POST", "https://example.com/hook", "https://example.com:8443/hook", params, "")),
+			ErrInvalidSignature,
+		},
+		{
+			"forwarded headers should be used when trusted",
+			NewValidator(testAuthToken, WithForwardedHeaders()),
+			header(signedRequest("POST", "http://10.0.0.5:8080/hook", "https://example.com/hook", params, ""),
+				"X-Forwarded-Proto", "https", "X-Forwarded-Host", "example.com, 10.0.0.1"),
+			nil,
+		},
+		{
+			"forwarded headers should be ignored when not trusted",
+			NewValidator(testAuthToken),
+			header(signedRequest("POST", "http://10.0.0.5:8080/hook", "https://example.com/hook", params, ""),
+				"X-Forwarded-Proto", "https", "X-Forwarded-Host", "example.com"),
+			ErrInvalidSignature,
+		},
+		{
+			"base URL should replace the scheme and host, and prefix the path",
+			NewValidator(testAuthToken, WithBaseURL(&url.URL{Scheme: "https", Host: "example.com", Path: "/twilio/"})),
+			signedRequest("POST", "http://10.0.0.5:8080/hook", "https://example.com/twilio/hook", params, ""),
+			nil,
+		},
+		{
+			"changed parameter should be invalid",
+			NewValidator(testAuthToken),
+			func() *http.Request {
+				r := signedRequest("POST", "http://example.com/hook", "http://example.com/hook", params, "")
+				forged := httptest.NewRequest("POST", "http://example.com/hook", strings.NewReader("CallSid=CA123&From=%2B15555550199"))
+				forged.Header = r.Header
+				return forged
+			}(),
+			ErrInvalidSignature,
+		},
+		{
+			"wrong token should be invalid",
+			NewValidator("54321"),
+			signedRequest("POST", "http://example.com/hook", "http://example.com/hook", params, ""),
+			ErrInvalidSignature,
+		},
+		{
+			"signature that isn't base64 should be invalid",
+			NewValidator(testAuthToken),
+			header(httptest.NewRequest("GET", "http://example.com/hook", nil), SignatureHeader, "!!!"),
+			ErrInvalidSignature,
+		},
+		{
+			"missing signature should be invalid",
+			NewValidator(testAuthToken),
+			httptest.NewRequest("GET", "http://example.com/hook", nil),
+			ErrMissingSignature,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.v.Validate(test.r)
+
+		if cause := errors.Cause(err); cause != test.cause {
+			t.Errorf("\nDescription: %s\nValidate() error = %v, want cause %v", test.desc, err, test.cause)
+		}
+	}
+}
+
+func TestValidator_Validate_body(t *testing.T) {
+	body := `{"hello":"world"}`
+	target := "http://example.com/hook?" + BodySHA256Param + "=" + bodyHash(body)
+
+	r := signedRequest("POST", target, target, nil, body)
+
+	if err := NewValidator(testAuthToken).Validate(r); err != nil {
+		t.Fatalf("Validate() unexpected error: %s", err)
+	}
+
+	got, err := ioutil.ReadAll(r.Body)
+
+	if err != nil || string(got) != body {
+		t.Errorf("body after Validate() = %q, %v, want %q", got, err, body)
+	}
+}
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestValidator_Validate_bodyLimits(t *testing.T) {
+	body := `{"hello":"world"}`
+	target := "http://example.com/hook?" + BodySHA256Param + "=" + bodyHash(body)
+
+	// the body of a request whose URL isn't signed shouldn't be read
+	r := signedRequest("POST", target, "http://example.com/other", nil, "")
+	cr := &countingReader{r: strings.NewReader(body)}
+	r.Body = ioutil.NopCloser(cr)
+
+	if err := NewValidator(testAuthToken).Validate(r); errors.Cause(err) != ErrInvalidSignature {
+		t.Errorf("Validate() of a forged URL error = %v, want cause %v", err, ErrInvalidSignature)
+	}
+
+	if cr.n != 0 {
+		t.Errorf("Validate() of a forged URL read %d bytes of the body, want 0", cr.n)
+	}
+
+	big := strings.Repeat("x", MaxBodySize+1)
+	target = "http://example.com/hook?" + BodySHA256Param + "=" + bodyHash(big)
+
+	if err := NewValidator(testAuthToken).Validate(signedRequest("POST", target, target, nil, big)); errors.Cause(err) != ErrBodyTooLarge {
+		t.Errorf("Validate() of a large body error = %v, want cause %v", err, ErrBodyTooLarge)
+	}
+}
+
+func TestValidator_Handler(t *testing.T) {
+	var called bool
+
+	h := NewValidator(testAuthToken).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+
+		if sid := r.PostForm.Get("CallSid"); sid != "CA123" {
+			t.Errorf("PostForm CallSid = %q, want %q", sid, "CA123")
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("POST", "http://example.com/hook", "http://example.com/hook", url.Values{"CallSid": {"CA123"}}, ""))
+
+	if !called || w.Code != http.StatusOK {
+		t.Errorf("valid request: called = %t, status = %d, want true and %d", called, w.Code, http.StatusOK)
+	}
+
+	called = false
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "http://example.com/hook", nil))
+
+	if called || w.Code != http.StatusForbidden {
+		t.Errorf("forged request: called = %t, status = %d, want false and %d", called, w.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,71 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import "github.com/pkg/errors"
+
+// ErrUnknownAccount is the cause of the error returned by a TokenProvider for
+// accounts it has no auth tokens for.
+var ErrUnknownAccount = errors.New("unknown account")
+
+// TokenProvider returns the auth tokens a request from the account may be
+// signed with, such as the primary and secondary tokens while an auth token
+// is being rotated, with the primary token first. The AccountSid is the one
+// in the request, which hasn't been validated yet. It's empty if the request
+// doesn't have one, which includes requests with a JSON body that don't have
+// an AccountSid in their URL, as the body is only read once the signature of
+// the URL is valid.
+type TokenProvider interface {
+	AuthTokens(accountSid string) ([]string, error)
+}
+
+// TokenProviderFunc is a function that's a TokenProvider.
+type TokenProviderFunc func(accountSid string) ([]string, error)
+
+// AuthTokens calls fn.
+func (fn TokenProviderFunc) AuthTokens(accountSid string) ([]string, error) {
+	return fn(accountSid)
+}
+
+// StaticTokens returns a TokenProvider with the same auth tokens for every
+// account, for services used by a single account.
+func StaticTokens(tokens ...string) TokenProvider {
+	return TokenProviderFunc(func(string) ([]string, error) {
+		return tokens, nil
+	})
+}
+
+// AccountTokens is a TokenProvider of the auth tokens of each account, keyed
+// by AccountSid, for services used by several subaccounts. The tokens keyed by
+// the empty string, if any, are used for requests without an AccountSid, such
+// as those with a JSON body.
+type AccountTokens map[string][]string
+
+// AuthTokens returns the tokens of the account, or an error with the cause
+// ErrUnknownAccount if there are none. This function returns a wrapped error
+// (see package documentation for more info).
+func (a AccountTokens) AuthTokens(accountSid string) ([]string, error) {
+	tokens := a[accountSid]
+
+	if len(tokens) == 0 {
+		return nil, errors.Wrapf(ErrUnknownAccount, "getting auth tokens of %q failed", accountSid)
+	}
+
+	return tokens, nil
+}
+
+// Match is the auth token a request's signature was made with.
+type Match struct {
+	// AccountSid is the AccountSid in the request's parameters or, for
+	// requests with a JSON body, in its body.
+	AccountSid string
+
+	// TokenIndex is the index of the auth token in those returned by the
+	// TokenProvider, so anything other than zero means the request was
+	// signed with a token other than the primary one.
+	TokenIndex int
+}
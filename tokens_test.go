@@ -0,0 +1,185 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// tokenRequest returns a form POST from the account, signed with the token.
+func tokenRequest(accountSid, token string) *http.Request {
+	params := url.Values{"AccountSid": {accountSid}, "CallSid": {"CA123"}}
+
+	r := httptest.NewRequest("POST", "http://example.com/hook", strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set(SignatureHeader, Signature(token, "http://example.com/hook", params))
+
+	return r
+}
+
+// jsonTokenRequest returns a POST with the JSON body, signed with the token.
+func jsonTokenRequest(body, token string) *http.Request {
+	target := "http://example.com/hook?" + BodySHA256Param + "=" + bodyHash(body)
+
+	r := httptest.NewRequest("POST", target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(SignatureHeader, Signature(token, target, nil))
+
+	return r
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	tokens := AccountTokens{
+		"AC1": {"primary1", "secondary1"},
+		"AC2": {"primary2"},
+	}
+
+	tests := []struct {
+		desc  string
+		v     *Validator
+		r     *http.Request
+		match Match
+		cause error
+	}{
+		{
+			"primary token should be index zero",
+			NewTokenValidator(tokens),
+			tokenRequest("AC1", "primary1"),
+			Match{AccountSid: "AC1", TokenIndex: 0},
+			nil,
+		},
+		{
+			"secondary token should be index one",
+			NewTokenValidator(tokens),
+			tokenRequest("AC1", "secondary1"),
+			Match{AccountSid: "AC1", TokenIndex: 1},
+			nil,
+		},
+		{
+			"subaccount should use its own tokens",
+			NewTokenValidator(tokens),
+			tokenRequest("AC2", "primary2"),
+			Match{AccountSid: "AC2", TokenIndex: 0},
+			nil,
+		},
+		{
+			"token of another account should be invalid",
+			NewTokenValidator(tokens),
+			tokenRequest("AC2", "primary1"),
+			Match{},
+			ErrInvalidSignature,
+		},
+		{
+			"unknown account should fail",
+			NewTokenValidator(tokens),
+			tokenRequest("AC3", "primary1"),
+			Match{},
+			ErrUnknownAccount,
+		},
+		{
+			"static tokens should be used for every account",
+			NewTokenValidator(StaticTokens("new", "old")),
+			tokenRequest("AC9", "old"),
+			Match{AccountSid: "AC9", TokenIndex: 1},
+			nil,
+		},
+		{
+			"AccountSid should be read from the query of GET requests",
+			NewTokenValidator(tokens),
+			func() *http.Request {
+				r := httptest.NewRequest("GET", "http://example.com/hook?AccountSid=AC2", nil)
+				r.Header.Set(SignatureHeader, Signature("primary2", "http://example.com/hook?AccountSid=AC2", nil))
+				return r
+			}(),
+			Match{AccountSid: "AC2", TokenIndex: 0},
+			nil,
+		},
+		{
+			"JSON body without an AccountSid in the URL should use the default tokens",
+			NewTokenValidator(AccountTokens{"": {"primary2"}, "AC2": {"primary2"}}),
+			jsonTokenRequest(`{"AccountSid":"AC2","CallSid":"CA123"}`, "primary2"),
+			Match{AccountSid: "AC2", TokenIndex: 0},
+			nil,
+		},
+		{
+			"JSON body without default tokens should be an unknown account",
+			NewTokenValidator(tokens),
+			jsonTokenRequest(`{"AccountSid":"AC2"}`, "primary2"),
+			Match{},
+			ErrUnknownAccount,
+		},
+	}
+
+	for _, test := range tests {
+		m, err := test.v.ValidateRequest(test.r)
+
+		if cause := errors.Cause(err); cause != test.cause {
+			t.Errorf("\nDescription: %s\nValidateRequest() error = %v, want cause %v", test.desc, err, test.cause)
+			continue
+		}
+
+		if m != test.match {
+			t.Errorf("\nDescription: %s\nValidateRequest() = %+v, want %+v", test.desc, m, test.match)
+		}
+	}
+}
+
+func TestWithMatchHook(t *testing.T) {
+	var matches []Match
+
+	v := NewTokenValidator(StaticTokens("new", "old"), WithMatchHook(func(_ *http.Request, m Match) {
+		matches = append(matches, m)
+	}))
+
+	h := v.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	h.ServeHTTP(httptest.NewRecorder(), tokenRequest("AC1", "old"))
+	h.ServeHTTP(httptest.NewRecorder(), tokenRequest("AC1", "forged"))
+	h.ServeHTTP(httptest.NewRecorder(), tokenRequest("AC1", "new"))
+
+	want := []Match{{AccountSid: "AC1", TokenIndex: 1}, {AccountSid: "AC1", TokenIndex: 0}}
+
+	if len(matches) != len(want) || matches[0] != want[0] || matches[1] != want[1] {
+		t.Errorf("matches = %+v, want %+v", matches, want)
+	}
+}
+
+func TestValidator_Handler_status(t *testing.T) {
+	failing := TokenProviderFunc(func(string) ([]string, error) {
+		return nil, errors.New("secrets store is down")
+	})
+
+	big := strings.Repeat("x", MaxBodySize+1)
+
+	tests := []struct {
+		desc string
+		v    *Validator
+		r    *http.Request
+		code int
+	}{
+		{"valid request", NewTokenValidator(AccountTokens{"AC1": {"t"}}), tokenRequest("AC1", "t"), http.StatusOK},
+		{"invalid signature", NewTokenValidator(AccountTokens{"AC1": {"t"}}), tokenRequest("AC1", "forged"), http.StatusForbidden},
+		{"unknown account", NewTokenValidator(AccountTokens{"AC1": {"t"}}), tokenRequest("AC2", "t"), http.StatusForbidden},
+		{"failing TokenProvider", NewTokenValidator(failing), tokenRequest("AC1", "t"), http.StatusInternalServerError},
+		{"large body", NewTokenValidator(StaticTokens("t")), jsonTokenRequest(big, "t"), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		test.v.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, test.r)
+
+		if w.Code != test.code {
+			t.Errorf("\nDescription: %s\nstatus = %d, want %d", test.desc, w.Code, test.code)
+		}
+	}
+}
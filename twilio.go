@@ -1 +1,10 @@
+// Package twilio is a set of helpers for building services that Twilio makes
+// webhook requests to, such as validating the X-Twilio-Signature of those
+// requests. The TwiML that's returned to Twilio is built with the twiml
+// package.
+//
+// Error handling in this package are wrapped errors, using the
+// github.com/pkg/errors package by Dave Cheney. More information about that
+// package and how to unwrap errors can be found here:
+// https://godoc.org/github.com/pkg/errors
 package twilio
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // BargeIn allows you to specify if Twilio should stop playing media from nested
 // or verbs once Twilio receives speech or DTMF. Defaults to true.
@@ -30,6 +35,11 @@ func (b BargeIn) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (b *BargeIn) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.Set(attr.Value)
+}
+
 // Bool returns the boolean representation of the BargeIn value. If the value is
 // not explicitly false, it's assumed true (to match Twilio's default).
 func (b BargeIn) Bool() bool {
@@ -50,3 +60,52 @@ func (b BargeIn) String() string {
 		return ""
 	}
 }
+
+// BargeInValues returns all of the BargeIn constants that render a value, for
+// listing the valid choices in configuration or help text.
+func BargeInValues() []BargeIn {
+	return []BargeIn{
+		BargeInTrue,
+		BargeInFalse,
+	}
+}
+
+// ParseBargeIn returns the BargeIn whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseBargeIn(s string) (BargeIn, error) {
+	if s == "" {
+		return BargeIn(0), nil
+	}
+
+	for _, v := range BargeInValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return BargeIn(0), unknownValue("BargeIn", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (b BargeIn) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (b *BargeIn) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (b *BargeIn) Set(value string) error {
+	parsed, err := ParseBargeIn(value)
+
+	if err != nil {
+		return err
+	}
+
+	*b = parsed
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package callstatus consumes the call status callbacks requested with the
+// StatusCallbackEvent of a Number, Client, or Sip noun of a Dial. Its Handler
+// parses the callbacks, puts each call's events in order, and calls the
+// function handling each kind of event:
+//
+//	h := callstatus.New()
+//
+//	h.Handle(twiml.StatusCallbackAnswered, func(ctx context.Context, e *twilio.CallStatusEvent) error {
+//		return markAnswered(ctx, e.ParentCallSid, e.Timestamp)
+//	})
+//
+//	h.Handle(twiml.StatusCallbackCompleted, func(ctx context.Context, e *twilio.CallStatusEvent) error {
+//		return recordOutcome(ctx, e.CallSid, e.CallStatus, e.CallDuration)
+//	})
+//
+//	http.Handle("/voice/status", h)
+//
+// Twilio makes the callbacks of a call concurrently, so they can arrive out
+// of order. The Handler holds back an event that arrives before the ones with
+// lower SequenceNumbers until they have been handled, or until the reorder
+// window passes, and drops events that arrive after a later one was handled
+// as well as duplicate deliveries.
+package callstatus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+	"github.com/theckman/twilio/twiml"
+)
+
+// DefaultReorderWindow is how long a Handler holds back an event waiting for
+// earlier events of the call, unless set with WithReorderWindow.
+const DefaultReorderWindow = 2 * time.Second
+
+// EventFunc is a function that handles a call status event.
+type EventFunc func(ctx context.Context, e *twilio.CallStatusEvent) error
+
+// Event returns the StatusCallbackEvent a call status is sent for, which is
+// zero for the queued status.
+func Event(s twilio.CallStatus) twiml.StatusCallbackEvent {
+	switch {
+	case s == twilio.CallStatusInitiated:
+		return twiml.StatusCallbackInitiated
+	case s == twilio.CallStatusRinging:
+		return twiml.StatusCallbackRinging
+	case s == twilio.CallStatusInProgress:
+		return twiml.StatusCallbackAnswered
+	case s.Final():
+		return twiml.StatusCallbackCompleted
+	default:
+		return twiml.StatusCallbackEvent(0)
+	}
+}
+
+// Option configures optional behavior of a Handler.
+type Option func(*Handler)
+
+// WithReorderWindow sets how long the Handler holds back an event waiting for
+// earlier events of the call, instead of DefaultReorderWindow. Once it has
+// passed, the event is handled and the missing ones are dropped if they
+// arrive later. It should be well under the 15 second timeout of Twilio's
+// requests.
+func WithReorderWindow(d time.Duration) Option {
+	return func(h *Handler) {
+		h.window = d
+	}
+}
+
+// WithErrorHook sets a function that's called with the error whenever a
+// callback can't be parsed or its EventFunc fails, such as to log it.
+func WithErrorHook(fn func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// WithDropHook sets a function that's called with the events the Handler
+// drops, because they arrived after a later event of the call was handled or
+// were delivered more than once.
+func WithDropHook(fn func(r *http.Request, e *twilio.CallStatusEvent)) Option {
+	return func(h *Handler) {
+		h.onDrop = fn
+	}
+}
+
+// Handler is an http.Handler for call status callbacks. It responds with
+// 204 No Content once the event has been handled or dropped, 400 Bad Request
+// to callbacks that can't be parsed, and 500 Internal Server Error when an
+// EventFunc fails. Events without an EventFunc are still put in order.
+type Handler struct {
+	window  time.Duration
+	onError func(*http.Request, error)
+	onDrop  func(*http.Request, *twilio.CallStatusEvent)
+
+	mu       sync.RWMutex
+	handlers map[twiml.StatusCallbackEvent]EventFunc
+
+	seq *sequencer
+}
+
+// New returns a new *Handler.
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		window:   DefaultReorderWindow,
+		handlers: make(map[twiml.StatusCallbackEvent]EventFunc),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.seq = newSequencer(h.window)
+
+	return h
+}
+
+// Handle sets the EventFunc for the events, which can be combined like
+// twiml.StatusCallbackAnswered|twiml.StatusCallbackCompleted, replacing any
+// EventFunc they had.
+func (h *Handler) Handle(events twiml.StatusCallbackEvent, fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, event := range twiml.StatusCallbackEventValues() {
+		if events&event == event {
+			h.handlers[event] = fn
+		}
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, err := twilio.ParseCallStatusEvent(r)
+
+	if err == nil && e.CallSid == "" {
+		err = errors.New("missing CallSid")
+	}
+
+	if err != nil {
+		h.fail(w, r, errors.Wrap(err, "handling call status event failed"), http.StatusBadRequest)
+		return
+	}
+
+	if !h.seq.acquire(r.Context(), e.CallSid, e.SequenceNumber) {
+		if h.onDrop != nil && r.Context().Err() == nil {
+			h.onDrop(r, e)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = h.dispatch(r.Context(), e)
+
+	h.seq.release(e.CallSid, e.SequenceNumber, e.CallStatus.Final())
+
+	if err != nil {
+		h.fail(w, r, errors.Wrapf(err, "handling %s event of %s failed", e.CallStatus, e.CallSid), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dispatch calls the EventFunc for the event, if there is one.
+func (h *Handler) dispatch(ctx context.Context, e *twilio.CallStatusEvent) error {
+	h.mu.RLock()
+	fn, ok := h.handlers[Event(e.CallStatus)]
+	h.mu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	return fn(ctx, e)
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error, code int) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+
+	http.Error(w, http.StatusText(code), code)
+}
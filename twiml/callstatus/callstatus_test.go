@@ -0,0 +1,220 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package callstatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+	"github.com/theckman/twilio/twiml"
+)
+
+const testCallSid = "CA0123456789abcdef0123456789abcdef"
+
+func statusRequest(status string, seq int) *http.Request {
+	body := fmt.Sprintf("CallSid=%s&CallStatus=%s&SequenceNumber=%d", testCallSid, status, seq)
+	r := httptest.NewRequest("POST", "http://example.com/voice/status", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+// recorder records the statuses of the events it handles.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (rec *recorder) handle(_ context.Context, e *twilio.CallStatusEvent) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.events = append(rec.events, e.CallStatus.String())
+
+	return nil
+}
+
+func (rec *recorder) get() []string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return append([]string(nil), rec.events...)
+}
+
+func TestEvent(t *testing.T) {
+	tests := []struct {
+		status twilio.CallStatus
+		event  twiml.StatusCallbackEvent
+	}{
+		{twilio.CallStatusQueued, twiml.StatusCallbackEvent(0)},
+		{twilio.CallStatusInitiated, twiml.StatusCallbackInitiated},
+		{twilio.CallStatusRinging, twiml.StatusCallbackRinging},
+		{twilio.CallStatusInProgress, twiml.StatusCallbackAnswered},
+		{twilio.CallStatusCompleted, twiml.StatusCallbackCompleted},
+		{twilio.CallStatusNoAnswer, twiml.StatusCallbackCompleted},
+		{twilio.CallStatusCanceled, twiml.StatusCallbackCompleted},
+	}
+
+	for _, test := range tests {
+		if event := Event(test.status); event != test.event {
+			t.Errorf("Event(%s) = %q, want %q", test.status, event, test.event)
+		}
+	}
+}
+
+func TestHandler_dispatch(t *testing.T) {
+	early, late := &recorder{}, &recorder{}
+
+	h := New()
+	h.Handle(twiml.StatusCallbackInitiated|twiml.StatusCallbackRinging, early.handle)
+	h.Handle(twiml.StatusCallbackCompleted, late.handle)
+
+	statuses := []string{"initiated", "ringing", "in-progress", "busy"}
+
+	for i, status := range statuses {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, statusRequest(status, i))
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s event status = %d, want %d", status, w.Code, http.StatusNoContent)
+		}
+	}
+
+	if got := early.get(); !reflect.DeepEqual(got, []string{"initiated", "ringing"}) {
+		t.Errorf("initiated and ringing EventFunc got %v", got)
+	}
+
+	if got := late.get(); !reflect.DeepEqual(got, []string{"busy"}) {
+		t.Errorf("completed EventFunc got %v", got)
+	}
+}
+
+func TestHandler_errors(t *testing.T) {
+	var hooked []string
+
+	h := New(WithErrorHook(func(_ *http.Request, err error) {
+		hooked = append(hooked, err.Error())
+	}))
+
+	h.Handle(twiml.StatusCallbackAll, func(context.Context, *twilio.CallStatusEvent) error {
+		return errors.New("database is down")
+	})
+
+	tests := []struct {
+		desc string
+		r    *http.Request
+		code int
+		err  string
+	}{
+		{
+			desc: "malformed parameters should be a bad request",
+			r:    statusRequest("on-hold", 0),
+			code: http.StatusBadRequest,
+			err:  `handling call status event failed: parsing call status event failed: parameter CallStatus "on-hold": unknown value`,
+		},
+		{
+			desc: "missing CallSid should be a bad request",
+			r:    httptest.NewRequest("GET", "http://example.com/voice/status?CallStatus=ringing", nil),
+			code: http.StatusBadRequest,
+			err:  "handling call status event failed: missing CallSid",
+		},
+		{
+			desc: "EventFunc error should be an internal server error",
+			r:    statusRequest("ringing", 0),
+			code: http.StatusInternalServerError,
+			err:  "handling ringing event of " + testCallSid + " failed: database is down",
+		},
+	}
+
+	for _, test := range tests {
+		hooked = nil
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.r)
+
+		if w.Code != test.code {
+			t.Errorf("\nDescription: %s\nstatus = %d, want %d", test.desc, w.Code, test.code)
+		}
+
+		if len(hooked) != 1 || hooked[0] != test.err {
+			t.Errorf("\nDescription: %s\nerror hook got %q, want %q", test.desc, hooked, test.err)
+		}
+	}
+}
+
+func TestHandler_order(t *testing.T) {
+	rec := &recorder{}
+
+	var mu sync.Mutex
+	var dropped []int
+
+	h := New(WithReorderWindow(time.Minute), WithDropHook(func(_ *http.Request, e *twilio.CallStatusEvent) {
+		mu.Lock()
+		dropped = append(dropped, e.SequenceNumber)
+		mu.Unlock()
+	}))
+
+	h.Handle(twiml.StatusCallbackAll, rec.handle)
+
+	var wg sync.WaitGroup
+
+	// the answered and completed events arrive first
+	for i, status := range []string{"in-progress", "completed"} {
+		wg.Add(1)
+
+		go func(status string, seq int) {
+			defer wg.Done()
+			h.ServeHTTP(httptest.NewRecorder(), statusRequest(status, seq))
+		}(status, i+2)
+	}
+
+	waitForCall(t, h.seq)
+
+	h.ServeHTTP(httptest.NewRecorder(), statusRequest("initiated", 0))
+	h.ServeHTTP(httptest.NewRecorder(), statusRequest("ringing", 1))
+
+	wg.Wait()
+
+	// duplicates and late events are dropped
+	h.ServeHTTP(httptest.NewRecorder(), statusRequest("ringing", 1))
+	h.ServeHTTP(httptest.NewRecorder(), statusRequest("completed", 3))
+
+	want := []string{"initiated", "ringing", "in-progress", "completed"}
+
+	if got := rec.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("events handled in order %v, want %v", got, want)
+	}
+
+	if !reflect.DeepEqual(dropped, []int{1, 3}) {
+		t.Errorf("dropped events %v, want [1 3]", dropped)
+	}
+}
+
+// waitForCall waits until the sequencer has seen the call.
+func waitForCall(t *testing.T, s *sequencer) {
+	for i := 0; i < 1000; i++ {
+		s.mu.Lock()
+		_, ok := s.calls[testCallSid]
+		s.mu.Unlock()
+
+		if ok {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("timed out waiting for the sequencer to see the call")
+}
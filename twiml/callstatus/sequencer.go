@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package callstatus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// finishedTTL is how long a sequencer remembers a call after its final event,
+// or after its last event if it never had one, to drop late deliveries.
+const finishedTTL = 10 * time.Minute
+
+// callSequence is the ordering state of a call's events.
+type callSequence struct {
+	next     int  // the SequenceNumber expected next
+	busy     bool // an event is being handled
+	finished bool // the final event was handled
+	seen     time.Time
+
+	// wake is closed, and replaced, whenever next or busy change
+	wake chan struct{}
+}
+
+// sequencer lets the events of each call be handled one at a time, in the
+// order of their SequenceNumbers.
+type sequencer struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	calls     map[string]*callSequence
+	lastSweep time.Time
+}
+
+func newSequencer(window time.Duration) *sequencer {
+	return &sequencer{window: window, now: time.Now, calls: make(map[string]*callSequence)}
+}
+
+// acquire waits until the event with the SequenceNumber n is the next one of
+// the call, or until the reorder window passes, and returns whether it should
+// be handled. If it returns true, release must be called once it has been.
+func (s *sequencer) acquire(ctx context.Context, callSid string, n int) bool {
+	var timer *time.Timer
+	var expired bool
+
+	s.mu.Lock()
+
+	now := s.now()
+	s.sweep(now)
+
+	c, ok := s.calls[callSid]
+
+	if !ok {
+		c = &callSequence{wake: make(chan struct{})}
+		s.calls[callSid] = c
+	}
+
+	c.seen = now
+
+	for {
+		switch {
+		case c.finished || n < c.next:
+			s.mu.Unlock()
+			return false
+		case !c.busy && (n == c.next || expired):
+			c.busy = true
+			s.mu.Unlock()
+			return true
+		}
+
+		// once the window has passed, only wait for the event that's
+		// being handled
+		var timeout <-chan time.Time
+
+		if !expired {
+			if timer == nil {
+				timer = time.NewTimer(s.window)
+				defer timer.Stop()
+			}
+
+			timeout = timer.C
+		}
+
+		wake := c.wake
+		s.mu.Unlock()
+
+		select {
+		case <-wake:
+		case <-timeout:
+			expired = true
+		case <-ctx.Done():
+			return false
+		}
+
+		s.mu.Lock()
+	}
+}
+
+// release marks the event with the SequenceNumber n as handled, letting the
+// next one be.
+func (s *sequencer) release(callSid string, n int, final bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.calls[callSid]
+
+	if n >= c.next {
+		c.next = n + 1
+	}
+
+	c.busy = false
+	c.finished = c.finished || final
+	c.seen = s.now()
+
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
+
+// sweep forgets calls that haven't had events for finishedTTL, at most once
+// per finishedTTL. It must be called with s.mu held.
+func (s *sequencer) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < finishedTTL {
+		return
+	}
+
+	s.lastSweep = now
+
+	for sid, c := range s.calls {
+		if !c.busy && now.Sub(c.seen) >= finishedTTL {
+			delete(s.calls, sid)
+		}
+	}
+}
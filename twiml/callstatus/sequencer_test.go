@@ -0,0 +1,78 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package callstatus
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSequencer_window(t *testing.T) {
+	s := newSequencer(10 * time.Millisecond)
+
+	// event 0 never arrives, so event 1 is handled once the window passes
+	start := time.Now()
+
+	if !s.acquire(context.Background(), testCallSid, 1) {
+		t.Fatal("acquire() after the window = false, want true")
+	}
+
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("acquire() returned after %s, want it to wait for the window", elapsed)
+	}
+
+	s.release(testCallSid, 1, false)
+
+	if s.acquire(context.Background(), testCallSid, 0) {
+		t.Error("acquire() of an event older than a handled one = true, want false")
+	}
+
+	if !s.acquire(context.Background(), testCallSid, 2) {
+		t.Fatal("acquire() of the next event = false, want true")
+	}
+
+	s.release(testCallSid, 2, true)
+
+	if s.acquire(context.Background(), testCallSid, 5) {
+		t.Error("acquire() after the final event = true, want false")
+	}
+}
+
+func TestSequencer_canceled(t *testing.T) {
+	s := newSequencer(time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if s.acquire(ctx, testCallSid, 1) {
+		t.Error("acquire() with a canceled context = true, want false")
+	}
+}
+
+func TestSequencer_sweep(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	s := newSequencer(time.Minute)
+	s.now = func() time.Time { return now }
+
+	if !s.acquire(context.Background(), testCallSid, 0) {
+		t.Fatal("acquire() of the first event = false, want true")
+	}
+
+	s.release(testCallSid, 0, true)
+
+	now = now.Add(finishedTTL)
+
+	if !s.acquire(context.Background(), "CAffffffffffffffffffffffffffffffff", 0) {
+		t.Fatal("acquire() of another call = false, want true")
+	}
+
+	if _, ok := s.calls[testCallSid]; ok {
+		t.Error("finished call should be forgotten after finishedTTL")
+	}
+}
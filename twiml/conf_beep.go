@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // ConfBeep allows you to specify if Twilio lets you specify whether a
 // notification beep is played to the conference when a participant joins or
@@ -31,8 +36,13 @@ func (b ConfBeep) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
-// Bool returns the boolean representation of the ConfBeep value. If the value is
-// not explicitly false, it's assumed true (to match Twilio's default).
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (b *ConfBeep) UnmarshalXMLAttr(attr xml.Attr) error {
+	return b.Set(attr.Value)
+}
+
+// Bool returns the boolean representation of the ConfBeep value. If the value
+// is not explicitly false, it's assumed true (to match Twilio's default).
 func (b ConfBeep) Bool() bool {
 	if b == ConfBeepFalse {
 		return false
@@ -51,3 +61,52 @@ func (b ConfBeep) String() string {
 		return ""
 	}
 }
+
+// ConfBeepValues returns all of the ConfBeep constants that render a value, for
+// listing the valid choices in configuration or help text.
+func ConfBeepValues() []ConfBeep {
+	return []ConfBeep{
+		ConfBeepTrue,
+		ConfBeepFalse,
+	}
+}
+
+// ParseConfBeep returns the ConfBeep whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseConfBeep(s string) (ConfBeep, error) {
+	if s == "" {
+		return ConfBeep(0), nil
+	}
+
+	for _, v := range ConfBeepValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return ConfBeep(0), unknownValue("ConfBeep", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (b ConfBeep) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (b *ConfBeep) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (b *ConfBeep) Set(value string) error {
+	parsed, err := ParseConfBeep(value)
+
+	if err != nil {
+		return err
+	}
+
+	*b = parsed
+
+	return nil
+}
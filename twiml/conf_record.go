@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // ConfRecord lets you record an entire conference.
 type ConfRecord uint8
@@ -30,6 +35,11 @@ func (r ConfRecord) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (r *ConfRecord) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.Set(attr.Value)
+}
+
 func (r ConfRecord) String() string {
 	switch r {
 	case ConfDoNotRecord:
@@ -40,3 +50,52 @@ func (r ConfRecord) String() string {
 		return ""
 	}
 }
+
+// ConfRecordValues returns all of the ConfRecord constants that render a value,
+// for listing the valid choices in configuration or help text.
+func ConfRecordValues() []ConfRecord {
+	return []ConfRecord{
+		ConfDoNotRecord,
+		ConfRecordFromStart,
+	}
+}
+
+// ParseConfRecord returns the ConfRecord whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseConfRecord(s string) (ConfRecord, error) {
+	if s == "" {
+		return ConfRecord(0), nil
+	}
+
+	for _, v := range ConfRecordValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return ConfRecord(0), unknownValue("ConfRecord", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r ConfRecord) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *ConfRecord) UnmarshalText(text []byte) error {
+	return r.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (r *ConfRecord) Set(value string) error {
+	parsed, err := ParseConfRecord(value)
+
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // ConfRegion specifies the region where Twilio should mix the conference.
 // Specifying a value for region overrides Twilio's automatic region selection
@@ -46,6 +51,11 @@ func (r ConfRegion) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (r *ConfRegion) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.Set(attr.Value)
+}
+
 func (r ConfRegion) String() string {
 	switch r {
 	case ConfRegionAustralia:
@@ -64,3 +74,56 @@ func (r ConfRegion) String() string {
 		return ""
 	}
 }
+
+// ConfRegionValues returns all of the ConfRegion constants that render a value,
+// for listing the valid choices in configuration or help text.
+func ConfRegionValues() []ConfRegion {
+	return []ConfRegion{
+		ConfRegionAustralia,
+		ConfRegionBrazil,
+		ConfRegionIreland,
+		ConfRegionJapan,
+		ConfRegionSingapore,
+		ConfRegionUS,
+	}
+}
+
+// ParseConfRegion returns the ConfRegion whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseConfRegion(s string) (ConfRegion, error) {
+	if s == "" {
+		return ConfRegion(0), nil
+	}
+
+	for _, v := range ConfRegionValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return ConfRegion(0), unknownValue("ConfRegion", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r ConfRegion) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *ConfRegion) UnmarshalText(text []byte) error {
+	return r.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (r *ConfRegion) Set(value string) error {
+	parsed, err := ParseConfRegion(value)
+
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
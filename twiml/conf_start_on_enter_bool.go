@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // ConfStartOnEnterBool tells a conference to start when this participant joins
 // the conference, if it is not already started. This is true by default. If
@@ -34,8 +39,14 @@ func (s ConfStartOnEnterBool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
-// Bool returns the boolean representation of the ConfStartOnEnterBool value. If the value is
-// not explicitly false, it's assumed true (to match Twilio's default).
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (s *ConfStartOnEnterBool) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.Set(attr.Value)
+}
+
+// Bool returns the boolean representation of the ConfStartOnEnterBool value. If
+// the value is not explicitly false, it's assumed true (to match Twilio's
+// default).
 func (s ConfStartOnEnterBool) Bool() bool {
 	if s == ConfStartOnEnterFalse {
 		return false
@@ -54,3 +65,53 @@ func (s ConfStartOnEnterBool) String() string {
 		return ""
 	}
 }
+
+// ConfStartOnEnterBoolValues returns all of the ConfStartOnEnterBool constants
+// that render a value, for listing the valid choices in configuration or help
+// text.
+func ConfStartOnEnterBoolValues() []ConfStartOnEnterBool {
+	return []ConfStartOnEnterBool{
+		ConfStartOnEnterTrue,
+		ConfStartOnEnterFalse,
+	}
+}
+
+// ParseConfStartOnEnterBool returns the ConfStartOnEnterBool whose TwiML value
+// is s, compared case-insensitively. This function returns a wrapped error (see
+// package documentation for more info).
+func ParseConfStartOnEnterBool(s string) (ConfStartOnEnterBool, error) {
+	if s == "" {
+		return ConfStartOnEnterBool(0), nil
+	}
+
+	for _, v := range ConfStartOnEnterBoolValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return ConfStartOnEnterBool(0), unknownValue("ConfStartOnEnterBool", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ConfStartOnEnterBool) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ConfStartOnEnterBool) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (s *ConfStartOnEnterBool) Set(value string) error {
+	parsed, err := ParseConfStartOnEnterBool(value)
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
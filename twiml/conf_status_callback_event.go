@@ -4,11 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
 import (
 	"bytes"
 	"encoding/xml"
+	"strings"
 )
 
 // ConfStatusCallbackEvent allows you to specify if Twilio lets you specify whether a
@@ -46,8 +49,9 @@ const (
 )
 
 // ConfStatusCallbackAll is a constant value that encompasses all ConfStatusCallbackEvent values.
-const ConfStatusCallbackAll = ConfStatusCallbackStart | ConfStatusCallbackEnd | ConfStatusCallbackJoin |
-	ConfStatusCallbackLeave | ConfStatusCallbackMute | ConfStatusCallbackHold | ConfStatusCallbackSpeaker
+const ConfStatusCallbackAll = ConfStatusCallbackStart | ConfStatusCallbackEnd |
+	ConfStatusCallbackJoin | ConfStatusCallbackLeave | ConfStatusCallbackMute |
+	ConfStatusCallbackHold | ConfStatusCallbackSpeaker
 
 // MarshalXMLAttr implements the xml.MarshalerAttr interface.
 func (s ConfStatusCallbackEvent) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
@@ -59,6 +63,11 @@ func (s ConfStatusCallbackEvent) MarshalXMLAttr(name xml.Name) (xml.Attr, error)
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (s *ConfStatusCallbackEvent) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.Set(attr.Value)
+}
+
 func (s ConfStatusCallbackEvent) String() string {
 	if s == ConfStatusCallbackEvent(0) {
 		return ""
@@ -120,3 +129,67 @@ func (s ConfStatusCallbackEvent) String() string {
 
 	return buf.String()
 }
+
+// ConfStatusCallbackEventValues returns each of the ConfStatusCallbackEvent
+// flags, for listing the valid choices in configuration or help text.
+func ConfStatusCallbackEventValues() []ConfStatusCallbackEvent {
+	return []ConfStatusCallbackEvent{
+		ConfStatusCallbackStart,
+		ConfStatusCallbackEnd,
+		ConfStatusCallbackJoin,
+		ConfStatusCallbackLeave,
+		ConfStatusCallbackMute,
+		ConfStatusCallbackHold,
+		ConfStatusCallbackSpeaker,
+	}
+}
+
+// ParseConfStatusCallbackEvent parses a space- or comma-separated list of
+// ConfStatusCallbackEvent flags (e.g., "start end"), compared
+// case-insensitively, in to a ConfStatusCallbackEvent. This function returns a
+// wrapped error (see package documentation for more info).
+func ParseConfStatusCallbackEvent(s string) (ConfStatusCallbackEvent, error) {
+	var parsed ConfStatusCallbackEvent
+
+	for _, word := range splitFlags(s) {
+		found := false
+
+		for _, v := range ConfStatusCallbackEventValues() {
+			if strings.EqualFold(v.String(), word) {
+				parsed |= v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return ConfStatusCallbackEvent(0), unknownValue("ConfStatusCallbackEvent", s)
+		}
+	}
+
+	return parsed, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s ConfStatusCallbackEvent) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *ConfStatusCallbackEvent) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
+
+// Set implements the flag.Value interface. The parsed value replaces the
+// current one, rather than being combined with it.
+func (s *ConfStatusCallbackEvent) Set(value string) error {
+	parsed, err := ParseConfStatusCallbackEvent(value)
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
@@ -0,0 +1,172 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package conference consumes the conference status callbacks requested with
+// the StatusCallbackEvent of the Conference noun of a Dial. Its Handler parses
+// the callbacks, keeps an optional Roster of each conference's participants
+// up to date, and calls the function handling each kind of event:
+//
+//	roster := conference.NewRoster()
+//	h := conference.New(conference.WithRoster(roster))
+//
+//	h.Handle(twiml.ConfStatusCallbackJoin|twiml.ConfStatusCallbackLeave, func(ctx context.Context, e *twilio.ConferenceEvent) error {
+//		c, _ := roster.Conference(e.ConferenceSid)
+//		return publishHeadcount(ctx, c.FriendlyName, len(c.Participants))
+//	})
+//
+//	http.Handle("/voice/conference", h)
+package conference
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+	"github.com/theckman/twilio/twiml"
+)
+
+// EventFunc is a function that handles a conference event.
+type EventFunc func(ctx context.Context, e *twilio.ConferenceEvent) error
+
+// Event returns the ConfStatusCallbackEvent that subscribes to the type of
+// event, which is zero for the types that can't be subscribed to this way.
+// The Handler passes events of those types to the EventFunc set by
+// HandleOther.
+func Event(t twilio.ConferenceEventType) twiml.ConfStatusCallbackEvent {
+	switch t {
+	case twilio.ConferenceStart:
+		return twiml.ConfStatusCallbackStart
+	case twilio.ConferenceEnd:
+		return twiml.ConfStatusCallbackEnd
+	case twilio.ParticipantJoin:
+		return twiml.ConfStatusCallbackJoin
+	case twilio.ParticipantLeave:
+		return twiml.ConfStatusCallbackLeave
+	case twilio.ParticipantMute, twilio.ParticipantUnmute:
+		return twiml.ConfStatusCallbackMute
+	case twilio.ParticipantHold, twilio.ParticipantUnhold:
+		return twiml.ConfStatusCallbackHold
+	case twilio.ParticipantSpeechStart, twilio.ParticipantSpeechStop:
+		return twiml.ConfStatusCallbackSpeaker
+	default:
+		return twiml.ConfStatusCallbackEvent(0)
+	}
+}
+
+// Option configures optional behavior of a Handler.
+type Option func(*Handler)
+
+// WithRoster sets a Roster that the Handler applies each event to before
+// calling its EventFunc, so the EventFunc sees the conference as of the
+// event.
+func WithRoster(r *Roster) Option {
+	return func(h *Handler) {
+		h.roster = r
+	}
+}
+
+// WithErrorHook sets a function that's called with the error whenever a
+// callback can't be parsed or its EventFunc fails, such as to log it.
+func WithErrorHook(fn func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// Handler is an http.Handler for conference status callbacks. It responds
+// with 204 No Content once the event has been handled, 400 Bad Request to
+// callbacks that can't be parsed, and 500 Internal Server Error when an
+// EventFunc fails.
+type Handler struct {
+	roster  *Roster
+	onError func(*http.Request, error)
+
+	mu       sync.RWMutex
+	handlers map[twiml.ConfStatusCallbackEvent]EventFunc
+	other    EventFunc
+}
+
+// New returns a new *Handler.
+func New(opts ...Option) *Handler {
+	h := &Handler{handlers: make(map[twiml.ConfStatusCallbackEvent]EventFunc)}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Handle sets the EventFunc for the events, which can be combined like
+// twiml.ConfStatusCallbackJoin|twiml.ConfStatusCallbackLeave, replacing any
+// EventFunc they had.
+func (h *Handler) Handle(events twiml.ConfStatusCallbackEvent, fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, event := range twiml.ConfStatusCallbackEventValues() {
+		if events&event == event {
+			h.handlers[event] = fn
+		}
+	}
+}
+
+// HandleOther sets the EventFunc for the events that have no EventFunc set by
+// Handle, replacing any it had. These include the events Event returns zero
+// for, such as participant-modify and the announcement events, which are
+// otherwise ignored.
+func (h *Handler) HandleOther(fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.other = fn
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, err := twilio.ParseConferenceEvent(r)
+
+	if err == nil && e.ConferenceSid == "" {
+		err = errors.New("missing ConferenceSid")
+	}
+
+	if err != nil {
+		h.fail(w, r, errors.Wrap(err, "handling conference event failed"), http.StatusBadRequest)
+		return
+	}
+
+	if h.roster != nil {
+		h.roster.Apply(e)
+	}
+
+	h.mu.RLock()
+	fn, ok := h.handlers[Event(e.StatusCallbackEvent)]
+
+	if !ok {
+		fn, ok = h.other, h.other != nil
+	}
+
+	h.mu.RUnlock()
+
+	if ok {
+		if err := fn(r.Context(), e); err != nil {
+			h.fail(w, r, errors.Wrapf(err, "handling %s event of %s failed", e.StatusCallbackEvent, e.ConferenceSid), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error, code int) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+
+	http.Error(w, http.StatusText(code), code)
+}
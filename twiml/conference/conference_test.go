@@ -0,0 +1,198 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package conference
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+	"github.com/theckman/twilio/twiml"
+)
+
+const (
+	testConferenceSid = "CF0123456789abcdef0123456789abcdef"
+	testCallSid       = "CA0123456789abcdef0123456789abcdef"
+	otherCallSid      = "CAffffffffffffffffffffffffffffffff"
+)
+
+func conferenceRequest(params url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "http://example.com/voice/conference", strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestEvent(t *testing.T) {
+	tests := []struct {
+		typ   twilio.ConferenceEventType
+		event twiml.ConfStatusCallbackEvent
+	}{
+		{twilio.ConferenceStart, twiml.ConfStatusCallbackStart},
+		{twilio.ConferenceEnd, twiml.ConfStatusCallbackEnd},
+		{twilio.ParticipantJoin, twiml.ConfStatusCallbackJoin},
+		{twilio.ParticipantLeave, twiml.ConfStatusCallbackLeave},
+		{twilio.ParticipantUnmute, twiml.ConfStatusCallbackMute},
+		{twilio.ParticipantUnhold, twiml.ConfStatusCallbackHold},
+		{twilio.ParticipantSpeechStart, twiml.ConfStatusCallbackSpeaker},
+		{twilio.ParticipantModify, twiml.ConfStatusCallbackEvent(0)},
+		{twilio.AnnouncementEnd, twiml.ConfStatusCallbackEvent(0)},
+	}
+
+	for _, test := range tests {
+		if event := Event(test.typ); event != test.event {
+			t.Errorf("Event(%s) = %q, want %q", test.typ, event, test.event)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	roster := NewRoster()
+
+	var handled []string
+	var counts []int
+
+	h := New(WithRoster(roster))
+
+	h.Handle(twiml.ConfStatusCallbackJoin|twiml.ConfStatusCallbackMute, func(_ context.Context, e *twilio.ConferenceEvent) error {
+		c, _ := roster.Conference(e.ConferenceSid)
+
+		handled = append(handled, e.StatusCallbackEvent.String())
+		counts = append(counts, len(c.Participants))
+
+		return nil
+	})
+
+	events := []url.Values{
+		{"StatusCallbackEvent": {"conference-start"}, "SequenceNumber": {"0"}},
+		{"StatusCallbackEvent": {"participant-join"}, "SequenceNumber": {"1"}, "CallSid": {testCallSid}},
+		{"StatusCallbackEvent": {"participant-join"}, "SequenceNumber": {"2"}, "CallSid": {otherCallSid}},
+		{"StatusCallbackEvent": {"participant-unmute"}, "SequenceNumber": {"3"}, "CallSid": {otherCallSid}},
+	}
+
+	for _, params := range events {
+		params.Set("ConferenceSid", testConferenceSid)
+		params.Set("FriendlyName", "support-42")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, conferenceRequest(params))
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s status = %d, want %d", params.Get("StatusCallbackEvent"), w.Code, http.StatusNoContent)
+		}
+	}
+
+	if want := []string{"participant-join", "participant-join", "participant-unmute"}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("handled %v, want %v", handled, want)
+	}
+
+	// the roster should be up to date when the EventFunc is called
+	if want := []int{1, 2, 2}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("EventFunc saw %v participants, want %v", counts, want)
+	}
+}
+
+func TestHandler_HandleOther(t *testing.T) {
+	var handled, other []string
+
+	h := New()
+
+	h.Handle(twiml.ConfStatusCallbackJoin, func(_ context.Context, e *twilio.ConferenceEvent) error {
+		handled = append(handled, e.StatusCallbackEvent.String())
+		return nil
+	})
+
+	events := []string{"participant-join", "participant-modify", "announcement-end", "participant-leave"}
+
+	for _, event := range events {
+		params := url.Values{"ConferenceSid": {testConferenceSid}, "StatusCallbackEvent": {event}}
+
+		// without HandleOther the other events are ignored
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, conferenceRequest(params))
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s status = %d, want %d", event, w.Code, http.StatusNoContent)
+		}
+	}
+
+	h.HandleOther(func(_ context.Context, e *twilio.ConferenceEvent) error {
+		other = append(other, e.StatusCallbackEvent.String())
+		return nil
+	})
+
+	for _, event := range events {
+		params := url.Values{"ConferenceSid": {testConferenceSid}, "StatusCallbackEvent": {event}}
+		h.ServeHTTP(httptest.NewRecorder(), conferenceRequest(params))
+	}
+
+	if want := []string{"participant-join", "participant-join"}; !reflect.DeepEqual(handled, want) {
+		t.Errorf("Handle() EventFunc got %v, want %v", handled, want)
+	}
+
+	if want := []string{"participant-modify", "announcement-end", "participant-leave"}; !reflect.DeepEqual(other, want) {
+		t.Errorf("HandleOther() EventFunc got %v, want %v", other, want)
+	}
+}
+
+func TestHandler_errors(t *testing.T) {
+	var hooked []string
+
+	h := New(WithErrorHook(func(_ *http.Request, err error) {
+		hooked = append(hooked, err.Error())
+	}))
+
+	h.Handle(twiml.ConfStatusCallbackAll, func(context.Context, *twilio.ConferenceEvent) error {
+		return errors.New("database is down")
+	})
+
+	tests := []struct {
+		desc   string
+		params url.Values
+		code   int
+		err    string
+	}{
+		{
+			desc:   "malformed parameters should be a bad request",
+			params: url.Values{"ConferenceSid": {testConferenceSid}, "StatusCallbackEvent": {"participant-dance"}},
+			code:   http.StatusBadRequest,
+			err:    `handling conference event failed: parsing conference event failed: parameter StatusCallbackEvent "participant-dance": unknown value`,
+		},
+		{
+			desc:   "missing ConferenceSid should be a bad request",
+			params: url.Values{"StatusCallbackEvent": {"conference-start"}},
+			code:   http.StatusBadRequest,
+			err:    "handling conference event failed: missing ConferenceSid",
+		},
+		{
+			desc:   "EventFunc error should be an internal server error",
+			params: url.Values{"ConferenceSid": {testConferenceSid}, "StatusCallbackEvent": {"conference-end"}},
+			code:   http.StatusInternalServerError,
+			err:    "handling conference-end event of " + testConferenceSid + " failed: database is down",
+		},
+	}
+
+	for _, test := range tests {
+		hooked = nil
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, conferenceRequest(test.params))
+
+		if w.Code != test.code {
+			t.Errorf("\nDescription: %s\nstatus = %d, want %d", test.desc, w.Code, test.code)
+		}
+
+		if len(hooked) != 1 || hooked[0] != test.err {
+			t.Errorf("\nDescription: %s\nerror hook got %q, want %q", test.desc, hooked, test.err)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package conference
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/theckman/twilio"
+)
+
+const (
+	// endedTTL is how long a Roster remembers a conference after it ends,
+	// to ignore late deliveries of its events.
+	endedTTL = 10 * time.Minute
+
+	// idleTTL is how long a Roster keeps a conference without events,
+	// in case its conference-end event was missed. Conferences can't last
+	// longer than a day.
+	idleTTL = 24 * time.Hour
+)
+
+// Participant is a participant of a conference, as of the last event about
+// it.
+type Participant struct {
+	CallSid string
+	Label   string
+
+	Muted          bool
+	Hold           bool
+	Speaking       bool
+	Coaching       bool
+	CallSidToCoach string
+
+	StartConferenceOnEnter bool
+	EndConferenceOnExit    bool
+
+	// Joined is when the participant joined, or when the Roster first
+	// heard of it if it missed the participant-join event.
+	Joined time.Time
+
+	seq int
+}
+
+// Conference is a conference that hasn't ended, with its participants.
+type Conference struct {
+	Sid          string
+	FriendlyName string
+
+	// Started is when the conference started mixing audio, which is the
+	// zero time until it does.
+	Started time.Time
+
+	// Participants are in the order they joined.
+	Participants []Participant
+}
+
+// Participant returns the participant on the call, if it's in the conference.
+func (c Conference) Participant(callSid string) (Participant, bool) {
+	for _, p := range c.Participants {
+		if p.CallSid == callSid {
+			return p, true
+		}
+	}
+
+	return Participant{}, false
+}
+
+// conferenceState is what a Roster knows about a conference.
+type conferenceState struct {
+	sid          string
+	friendlyName string
+	started      time.Time
+	participants map[string]*Participant
+
+	// left is the SequenceNumber of the participant-leave event of each
+	// participant that left, to ignore late events about it.
+	left map[string]int
+
+	ended bool
+	seen  time.Time
+}
+
+// Roster keeps track of the participants of conferences from their events,
+// which can be applied in any order. It's safe for concurrent use.
+type Roster struct {
+	now func() time.Time
+
+	mu          sync.Mutex
+	conferences map[string]*conferenceState
+	lastSweep   time.Time
+}
+
+// NewRoster returns a new, empty *Roster.
+func NewRoster() *Roster {
+	return &Roster{now: time.Now, conferences: make(map[string]*conferenceState)}
+}
+
+// Apply updates the Roster with the event. Events about a participant older
+// than the last one applied, and events of conferences that have ended, are
+// ignored.
+func (r *Roster) Apply(e *twilio.ConferenceEvent) {
+	if e.ConferenceSid == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	c, ok := r.conferences[e.ConferenceSid]
+
+	if !ok {
+		c = &conferenceState{
+			sid:          e.ConferenceSid,
+			participants: make(map[string]*Participant),
+			left:         make(map[string]int),
+		}
+
+		r.conferences[e.ConferenceSid] = c
+	}
+
+	if c.ended {
+		return
+	}
+
+	c.seen = now
+
+	if e.FriendlyName != "" {
+		c.friendlyName = e.FriendlyName
+	}
+
+	switch {
+	case e.StatusCallbackEvent == twilio.ConferenceStart:
+		if c.started.IsZero() {
+			c.started = e.Timestamp
+		}
+	case e.StatusCallbackEvent == twilio.ConferenceEnd:
+		c.ended = true
+	case e.StatusCallbackEvent.Participant() && e.CallSid != "":
+		c.applyParticipant(e)
+	}
+}
+
+func (c *conferenceState) applyParticipant(e *twilio.ConferenceEvent) {
+	p, ok := c.participants[e.CallSid]
+
+	if ok && e.SequenceNumber < p.seq {
+		// a late participant-join still tells when it joined
+		if e.StatusCallbackEvent == twilio.ParticipantJoin && e.Timestamp.Before(p.Joined) {
+			p.Joined = e.Timestamp
+		}
+
+		return
+	}
+
+	if left, ok := c.left[e.CallSid]; ok {
+		// a call can join again after leaving, but nothing else
+		// happens to it until it does
+		if e.SequenceNumber < left || e.StatusCallbackEvent != twilio.ParticipantJoin {
+			return
+		}
+
+		delete(c.left, e.CallSid)
+	}
+
+	if e.StatusCallbackEvent == twilio.ParticipantLeave {
+		delete(c.participants, e.CallSid)
+		c.left[e.CallSid] = e.SequenceNumber
+		return
+	}
+
+	if !ok {
+		p = &Participant{CallSid: e.CallSid, Joined: e.Timestamp}
+		c.participants[e.CallSid] = p
+	}
+
+	p.seq = e.SequenceNumber
+
+	// not every event has every parameter, so only the ones it has change
+	// the participant
+	has := func(name string) bool {
+		_, ok := e.Form[name]
+		return ok || e.Form == nil
+	}
+
+	if e.ParticipantLabel != "" {
+		p.Label = e.ParticipantLabel
+	}
+
+	if has("Muted") {
+		p.Muted = e.Muted
+	}
+
+	if has("Hold") {
+		p.Hold = e.Hold
+	}
+
+	if has("Coaching") {
+		p.Coaching = e.Coaching
+		p.CallSidToCoach = e.CallSidToCoach
+	}
+
+	if has("StartConferenceOnEnter") {
+		p.StartConferenceOnEnter = e.StartConferenceOnEnter
+	}
+
+	if has("EndConferenceOnExit") {
+		p.EndConferenceOnExit = e.EndConferenceOnExit
+	}
+
+	switch e.StatusCallbackEvent {
+	case twilio.ParticipantMute:
+		p.Muted = true
+	case twilio.ParticipantUnmute:
+		p.Muted = false
+	case twilio.ParticipantHold:
+		p.Hold = true
+	case twilio.ParticipantUnhold:
+		p.Hold = false
+	case twilio.ParticipantSpeechStart:
+		p.Speaking = true
+	case twilio.ParticipantSpeechStop:
+		p.Speaking = false
+	}
+}
+
+// snapshot returns a copy of the conference.
+func (c *conferenceState) snapshot() Conference {
+	out := Conference{Sid: c.sid, FriendlyName: c.friendlyName, Started: c.started}
+
+	for _, p := range c.participants {
+		out.Participants = append(out.Participants, *p)
+	}
+
+	sort.Slice(out.Participants, func(i, j int) bool {
+		a, b := out.Participants[i], out.Participants[j]
+
+		if !a.Joined.Equal(b.Joined) {
+			return a.Joined.Before(b.Joined)
+		}
+
+		return a.CallSid < b.CallSid
+	})
+
+	return out
+}
+
+// Conference returns the conference with the SID, if it hasn't ended.
+func (r *Roster) Conference(sid string) (Conference, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.conferences[sid]
+
+	if !ok || c.ended {
+		return Conference{}, false
+	}
+
+	return c.snapshot(), true
+}
+
+// Lookup returns the conference with the friendly name, which is the name
+// given to the Conference noun, if it hasn't ended.
+func (r *Roster) Lookup(friendlyName string) (Conference, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range r.conferences {
+		if !c.ended && c.friendlyName == friendlyName {
+			return c.snapshot(), true
+		}
+	}
+
+	return Conference{}, false
+}
+
+// Conferences returns the conferences that haven't ended, ordered by their
+// friendly names.
+func (r *Roster) Conferences() []Conference {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []Conference
+
+	for _, c := range r.conferences {
+		if !c.ended {
+			out = append(out, c.snapshot())
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].FriendlyName != out[j].FriendlyName {
+			return out[i].FriendlyName < out[j].FriendlyName
+		}
+
+		return out[i].Sid < out[j].Sid
+	})
+
+	return out
+}
+
+// sweep forgets conferences that ended endedTTL ago, or haven't had events
+// for idleTTL, at most once per endedTTL. It must be called with r.mu held.
+func (r *Roster) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < endedTTL {
+		return
+	}
+
+	r.lastSweep = now
+
+	for sid, c := range r.conferences {
+		idle := now.Sub(c.seen)
+
+		if c.ended && idle >= endedTTL || idle >= idleTTL {
+			delete(r.conferences, sid)
+		}
+	}
+}
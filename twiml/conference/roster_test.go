@@ -0,0 +1,178 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package conference
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio"
+)
+
+var testStart = time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+// event returns a conference event with the SequenceNumber seq, which
+// happened seq seconds after testStart. Only the parameters in params are in
+// its Form.
+func event(typ twilio.ConferenceEventType, seq int, callSid string, params url.Values) *twilio.ConferenceEvent {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	e := &twilio.ConferenceEvent{
+		ConferenceSid:       testConferenceSid,
+		FriendlyName:        "support-42",
+		StatusCallbackEvent: typ,
+		SequenceNumber:      seq,
+		Timestamp:           testStart.Add(time.Duration(seq) * time.Second),
+		CallSid:             callSid,
+		Muted:               params.Get("Muted") == "true",
+		Hold:                params.Get("Hold") == "true",
+		Form:                params,
+	}
+
+	return e
+}
+
+// callSids returns the CallSids of the participants of the conference.
+func callSids(t *testing.T, r *Roster) []string {
+	c, ok := r.Conference(testConferenceSid)
+
+	if !ok {
+		t.Fatal("Conference() = false, want true")
+	}
+
+	var sids []string
+
+	for _, p := range c.Participants {
+		sids = append(sids, p.CallSid)
+	}
+
+	return sids
+}
+
+func TestRoster(t *testing.T) {
+	r := NewRoster()
+
+	r.Apply(event(twilio.ParticipantJoin, 0, testCallSid, url.Values{"Muted": {"false"}, "Hold": {"false"}}))
+	r.Apply(event(twilio.ConferenceStart, 1, "", nil))
+	r.Apply(event(twilio.ParticipantJoin, 2, otherCallSid, url.Values{"Muted": {"true"}, "Hold": {"false"}}))
+	r.Apply(event(twilio.ParticipantHold, 3, testCallSid, url.Values{"Muted": {"false"}, "Hold": {"true"}}))
+	r.Apply(event(twilio.ParticipantSpeechStart, 4, otherCallSid, nil))
+
+	c, ok := r.Lookup("support-42")
+
+	if !ok {
+		t.Fatal("Lookup() = false, want true")
+	}
+
+	want := Conference{
+		Sid:          testConferenceSid,
+		FriendlyName: "support-42",
+		Started:      testStart.Add(time.Second),
+		Participants: []Participant{
+			{CallSid: testCallSid, Hold: true, Joined: testStart, seq: 3},
+			{CallSid: otherCallSid, Muted: true, Speaking: true, Joined: testStart.Add(2 * time.Second), seq: 4},
+		},
+	}
+
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("Lookup() = %+v, want %+v", c, want)
+	}
+
+	if p, ok := c.Participant(otherCallSid); !ok || !p.Speaking {
+		t.Errorf("Participant() = %+v, %t, want the speaking participant", p, ok)
+	}
+
+	r.Apply(event(twilio.ParticipantLeave, 5, testCallSid, nil))
+
+	if sids := callSids(t, r); !reflect.DeepEqual(sids, []string{otherCallSid}) {
+		t.Errorf("participants after leave = %v, want [%s]", sids, otherCallSid)
+	}
+
+	r.Apply(event(twilio.ConferenceEnd, 6, "", nil))
+
+	if _, ok := r.Conference(testConferenceSid); ok {
+		t.Error("Conference() after conference-end = true, want false")
+	}
+
+	if len(r.Conferences()) != 0 {
+		t.Errorf("Conferences() after conference-end = %v, want none", r.Conferences())
+	}
+
+	// late events of an ended conference are ignored
+	r.Apply(event(twilio.ParticipantJoin, 3, testCallSid, nil))
+
+	if _, ok := r.Conference(testConferenceSid); ok {
+		t.Error("Conference() after a late event = true, want false")
+	}
+}
+
+func TestRoster_outOfOrder(t *testing.T) {
+	r := NewRoster()
+
+	r.Apply(event(twilio.ParticipantMute, 2, testCallSid, nil))
+	r.Apply(event(twilio.ParticipantJoin, 0, testCallSid, url.Values{"Muted": {"false"}}))
+	r.Apply(event(twilio.ParticipantUnmute, 1, testCallSid, nil))
+
+	c, _ := r.Conference(testConferenceSid)
+	p, _ := c.Participant(testCallSid)
+
+	if !p.Muted || !p.Joined.Equal(testStart) {
+		t.Errorf("participant = %+v, want muted and joined at %s", p, testStart)
+	}
+
+	// a late event about a participant that left doesn't bring it back,
+	// but joining again does
+	r.Apply(event(twilio.ParticipantLeave, 4, testCallSid, nil))
+	r.Apply(event(twilio.ParticipantHold, 3, testCallSid, nil))
+
+	if sids := callSids(t, r); len(sids) != 0 {
+		t.Errorf("participants after a late event = %v, want none", sids)
+	}
+
+	r.Apply(event(twilio.ParticipantJoin, 5, testCallSid, nil))
+
+	if sids := callSids(t, r); !reflect.DeepEqual(sids, []string{testCallSid}) {
+		t.Errorf("participants after joining again = %v, want [%s]", sids, testCallSid)
+	}
+}
+
+func TestRoster_sweep(t *testing.T) {
+	now := testStart
+
+	r := NewRoster()
+	r.now = func() time.Time { return now }
+
+	r.Apply(event(twilio.ConferenceStart, 0, "", nil))
+	r.Apply(event(twilio.ConferenceEnd, 1, "", nil))
+
+	other := event(twilio.ConferenceStart, 0, "", nil)
+	other.ConferenceSid = "CFffffffffffffffffffffffffffffffff"
+	other.FriendlyName = "sales"
+	r.Apply(other)
+
+	now = now.Add(endedTTL)
+	r.Apply(other)
+
+	if _, ok := r.conferences[testConferenceSid]; ok {
+		t.Error("ended conference should be forgotten after endedTTL")
+	}
+
+	now = now.Add(idleTTL)
+	r.Apply(event(twilio.ConferenceStart, 0, "", nil))
+
+	if _, ok := r.Lookup("sales"); ok {
+		t.Error("idle conference should be forgotten after idleTTL")
+	}
+
+	if c := r.Conferences(); len(c) != 1 || c[0].Sid != testConferenceSid {
+		t.Errorf("Conferences() = %+v, want only %s", c, testConferenceSid)
+	}
+}
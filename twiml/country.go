@@ -0,0 +1,154 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import "strings"
+
+// The functions in this file take a country as an ISO 3166-1 alpha-2 code,
+// case-insensitively. "UK" is accepted as an alias for "GB".
+
+// normalizeCountry upper-cases the country code and resolves aliases.
+func normalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+
+	if alias, ok := regionAliases[country]; ok {
+		return alias
+	}
+
+	return country
+}
+
+// RingToneForCountry returns the RingTone used in the country, such as
+// RingToneGermany for "de". If Twilio doesn't provide a ringback tone for the
+// country RingToneAutomatic is returned.
+func RingToneForCountry(country string) RingTone {
+	country = normalizeCountry(country)
+
+	for _, r := range RingToneValues() {
+		if r == RingToneAutomatic || r == RingToneUSOld {
+			continue
+		}
+
+		if normalizeCountry(r.String()) == country {
+			return r
+		}
+	}
+
+	return RingToneAutomatic
+}
+
+// RingToneForNumber returns the RingTone used in the country the phone number
+// belongs to, so the caller hears the ringback tone they would expect when
+// calling that number directly. If the country can't be determined, or Twilio
+// doesn't provide a ringback tone for it, RingToneAutomatic is returned.
+func RingToneForNumber(number PhoneNumber) RingTone {
+	region := number.Region()
+
+	if region == "" {
+		return RingToneAutomatic
+	}
+
+	return RingToneForCountry(region)
+}
+
+// confRegions maps countries to the ConfRegion closest to them.
+var confRegions = map[string]ConfRegion{
+	// North and Central America, and the Caribbean
+	"US": ConfRegionUS, "CA": ConfRegionUS, "MX": ConfRegionUS,
+	"GT": ConfRegionUS, "BZ": ConfRegionUS, "SV": ConfRegionUS,
+	"HN": ConfRegionUS, "NI": ConfRegionUS, "CR": ConfRegionUS,
+	"PA": ConfRegionUS, "CU": ConfRegionUS, "DO": ConfRegionUS,
+	"HT": ConfRegionUS, "JM": ConfRegionUS, "PR": ConfRegionUS,
+	"BS": ConfRegionUS, "TT": ConfRegionUS,
+
+	// South America
+	"BR": ConfRegionBrazil, "AR": ConfRegionBrazil, "CL": ConfRegionBrazil,
+	"CO": ConfRegionBrazil, "PE": ConfRegionBrazil, "VE": ConfRegionBrazil,
+	"EC": ConfRegionBrazil, "BO": ConfRegionBrazil, "PY": ConfRegionBrazil,
+	"UY": ConfRegionBrazil,
+
+	// Europe, Africa, and the Middle East
+	"IE": ConfRegionIreland, "GB": ConfRegionIreland, "FR": ConfRegionIreland,
+	"DE": ConfRegionIreland, "NL": ConfRegionIreland, "BE": ConfRegionIreland,
+	"LU": ConfRegionIreland, "CH": ConfRegionIreland, "AT": ConfRegionIreland,
+	"IT": ConfRegionIreland, "ES": ConfRegionIreland, "PT": ConfRegionIreland,
+	"DK": ConfRegionIreland, "NO": ConfRegionIreland, "SE": ConfRegionIreland,
+	"FI": ConfRegionIreland, "IS": ConfRegionIreland, "EE": ConfRegionIreland,
+	"LV": ConfRegionIreland, "LT": ConfRegionIreland, "PL": ConfRegionIreland,
+	"CZ": ConfRegionIreland, "SK": ConfRegionIreland, "HU": ConfRegionIreland,
+	"SI": ConfRegionIreland, "HR": ConfRegionIreland, "RO": ConfRegionIreland,
+	"BG": ConfRegionIreland, "GR": ConfRegionIreland, "CY": ConfRegionIreland,
+	"MT": ConfRegionIreland, "RS": ConfRegionIreland, "UA": ConfRegionIreland,
+	"RU": ConfRegionIreland, "TR": ConfRegionIreland, "IL": ConfRegionIreland,
+	"AE": ConfRegionIreland, "SA": ConfRegionIreland, "QA": ConfRegionIreland,
+	"EG": ConfRegionIreland, "MA": ConfRegionIreland, "NG": ConfRegionIreland,
+	"KE": ConfRegionIreland, "GH": ConfRegionIreland, "ZA": ConfRegionIreland,
+
+	// East Asia
+	"JP": ConfRegionJapan, "KR": ConfRegionJapan, "CN": ConfRegionJapan,
+	"TW": ConfRegionJapan, "HK": ConfRegionJapan, "MO": ConfRegionJapan,
+
+	// South and Southeast Asia
+	"SG": ConfRegionSingapore, "MY": ConfRegionSingapore, "TH": ConfRegionSingapore,
+	"ID": ConfRegionSingapore, "PH": ConfRegionSingapore, "VN": ConfRegionSingapore,
+	"IN": ConfRegionSingapore, "PK": ConfRegionSingapore, "BD": ConfRegionSingapore,
+	"LK": ConfRegionSingapore,
+
+	// Oceania
+	"AU": ConfRegionAustralia, "NZ": ConfRegionAustralia, "FJ": ConfRegionAustralia,
+	"PG": ConfRegionAustralia,
+}
+
+// NearestConfRegion returns the ConfRegion geographically closest to the
+// country. If the country is unknown the zero value is returned, which is not
+// rendered and leaves the choice to Twilio.
+func NearestConfRegion(country string) ConfRegion {
+	return confRegions[normalizeCountry(country)]
+}
+
+// defaultLanguages maps countries to the Language most commonly spoken there.
+var defaultLanguages = map[string]Language{
+	"US": LangEnglishUS,
+	"CA": LangEnglishCanada,
+	"GB": LangEnglishUK,
+	"IE": LangEnglishUK,
+	"AU": LangEnglishAustralia,
+	"NZ": LangEnglishAustralia,
+	"DK": LangDanishDenmark,
+	"NL": LangDutchNetherlands,
+	"FI": LangFinnishFinland,
+	"FR": LangFrenchFrance,
+	"DE": LangGermanGermany,
+	"AT": LangGermanGermany,
+	"IT": LangItalianItaly,
+	"JP": LangJapaneseJapan,
+	"KR": LangKoreanKorea,
+	"NO": LangNorwegianNorway,
+	"PL": LangPolishPoland,
+	"BR": LangPortugeseBrazil,
+	"PT": LangPortugesePortugal,
+	"RU": LangRussianRussia,
+	"ES": LangSpanishSpain,
+	"MX": LangSpanishMexico,
+	"AR": LangSpanishMexico,
+	"CL": LangSpanishMexico,
+	"CO": LangSpanishMexico,
+	"PE": LangSpanishMexico,
+	"VE": LangSpanishMexico,
+	"SE": LangSwedishSweden,
+	"CN": LangChineseMandarin,
+	"HK": LangChineseCantonese,
+	"TW": LangChineseTaiwaneseMandarin,
+}
+
+// DefaultLanguage returns the Language most commonly spoken in the country.
+// Spanish-speaking countries in Latin America use LangSpanishMexico, as the
+// closest available variant. If there is no suitable Language, LangDefault is
+// returned.
+func DefaultLanguage(country string) Language {
+	return defaultLanguages[normalizeCountry(country)]
+}
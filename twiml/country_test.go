@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRingToneForCountry(t *testing.T) {
+	// every country's RingTone should be found by its own country code
+	for _, r := range RingToneValues() {
+		if r == RingToneAutomatic || r == RingToneUSOld {
+			continue
+		}
+
+		for _, in := range []string{r.String(), strings.ToUpper(r.String())} {
+			if out := RingToneForCountry(in); out != r {
+				t.Errorf("RingToneForCountry(%q) = %q; want %q", in, out, r)
+			}
+		}
+	}
+
+	tests := []struct {
+		desc string
+		in   string
+		out  RingTone
+	}{
+		{"ISO code for the UK should be RingToneUK", "gb", RingToneUK},
+		{"Surrounding whitespace should be ignored", " de ", RingToneGermany},
+		{"us-old is not a country", "us-old", RingToneAutomatic},
+		{"Unknown country should be automatic", "zz", RingToneAutomatic},
+		{"Empty string should be automatic", "", RingToneAutomatic},
+	}
+
+	for _, test := range tests {
+		if out := RingToneForCountry(test.in); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nRingToneForCountry(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestRingToneForNumber(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   PhoneNumber
+		out  RingTone
+	}{
+		{"German number should be RingToneGermany", "+4930123456", RingToneGermany},
+		{"UK number should be RingToneUK", "+442079460000", RingToneUK},
+		{"NANP number should be RingToneUS", "+14155555555", RingToneUS},
+		{"Number from a country without a tone should be automatic", "+353123456789", RingToneAutomatic},
+		{"Non-E.164 number should be automatic", "415-555-5555", RingToneAutomatic},
+	}
+
+	for _, test := range tests {
+		if out := RingToneForNumber(test.in); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nRingToneForNumber(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestNearestConfRegion(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		out  ConfRegion
+	}{
+		{"US should be us1", "us", ConfRegionUS},
+		{"Argentina should be br1", "AR", ConfRegionBrazil},
+		{"Germany should be ie1", "de", ConfRegionIreland},
+		{"UK alias should be ie1", "uk", ConfRegionIreland},
+		{"South Korea should be jp1", "kr", ConfRegionJapan},
+		{"India should be sg1", "in", ConfRegionSingapore},
+		{"New Zealand should be au1", "nz", ConfRegionAustralia},
+		{"Unknown country should be unset", "zz", ConfRegion(0)},
+	}
+
+	for _, test := range tests {
+		if out := NearestConfRegion(test.in); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nNearestConfRegion(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestDefaultLanguage(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		out  Language
+	}{
+		{"US should be en-US", "us", LangEnglishUS},
+		{"UK alias should be en-GB", "uk", LangEnglishUK},
+		{"Austria should be de-DE", "AT", LangGermanGermany},
+		{"Chile should be es-MX", "cl", LangSpanishMexico},
+		{"Taiwan should be zh-TW", "tw", LangChineseTaiwaneseMandarin},
+		{"Unknown country should be the default", "zz", LangDefault},
+	}
+
+	for _, test := range tests {
+		if out := DefaultLanguage(test.in); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nDefaultLanguage(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
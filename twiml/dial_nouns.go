@@ -4,6 +4,8 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
 import "encoding/xml"
@@ -58,8 +60,8 @@ type DialConference struct {
 // behaviors that Twilio should apply when dialing the number.
 type DialNumber struct {
 	XMLName              xml.Name            `xml:"Number"`
-	Number               string              `xml:",chardata"`
-	SendDigits           string              `xml:"sendDigits,attr,omitempty"`
+	Number               PhoneNumber         `xml:",chardata"`
+	SendDigits           DTMF                `xml:"sendDigits,attr,omitempty"`
 	URL                  string              `xml:"url,attr,omitempty"`
 	Method               string              `xml:"method,attr,omitempty"`
 	StatusCallbackEvent  StatusCallbackEvent `xml:"statusCallbackEvent,attr,omitempty"`
@@ -98,8 +100,8 @@ type DialSIP struct {
 	Username string   `xml:"username,attr,omitempty"`
 	Password string   `xml:"password,attr,omitempty"`
 
-	// URL is the call screening URL for the SIP call
-	// With Method being the HTTP method used for hitting the URL
+	// URL is the call screening URL for the SIP call, with Method being the
+	// HTTP method used for hitting the URL.
 	URL    string `xml:"url,attr,omitempty"`
 	Method string `xml:"method,attr,omitempty"`
 
@@ -107,11 +109,7 @@ type DialSIP struct {
 	StatusCallback       string              `xml:"statusCallback,attr,omitempty"`
 	StatusCallbackMethod string              `xml:"statusCallbackMethod,attr,omitempty"`
 
-	//
-	// Attributes shared from Dial verb
-	//
-	// Action                        string     `xml:"action,attr,omitempty"`
-	// Method                        string     `xml:"method,attr,omitempty"`
+	// The remaining attributes are shared with the Dial verb.
 	Timeout                       uint       `xml:"timeout,attr,omitempty"`
 	HangupOnStar                  bool       `xml:"hangupOnStar,attr"`
 	TimeLimit                     uint       `xml:"timeLimit,attr,omitempty"`
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // DialRecord lets you record both legs of a call within the associated Dial verb. Recordings are available in two options: mono-channel or dual-channel.
 type DialRecord uint8
@@ -42,6 +47,11 @@ func (d DialRecord) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (d *DialRecord) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.Set(attr.Value)
+}
+
 func (d DialRecord) String() string {
 	switch d {
 	case DialDoNotRecord:
@@ -58,3 +68,55 @@ func (d DialRecord) String() string {
 		return ""
 	}
 }
+
+// DialRecordValues returns all of the DialRecord constants that render a value,
+// for listing the valid choices in configuration or help text.
+func DialRecordValues() []DialRecord {
+	return []DialRecord{
+		DialDoNotRecord,
+		DialRecordFromAnswerMono,
+		DialRecordFromRingingMono,
+		DialRecordFromAnswerDual,
+		DialRecordFromRingingDual,
+	}
+}
+
+// ParseDialRecord returns the DialRecord whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseDialRecord(s string) (DialRecord, error) {
+	if s == "" {
+		return DialRecord(0), nil
+	}
+
+	for _, v := range DialRecordValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return DialRecord(0), unknownValue("DialRecord", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d DialRecord) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *DialRecord) UnmarshalText(text []byte) error {
+	return d.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (d *DialRecord) Set(value string) error {
+	parsed, err := ParseDialRecord(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
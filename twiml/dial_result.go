@@ -0,0 +1,49 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"time"
+
+	"github.com/theckman/twilio"
+)
+
+// DialResult is the outcome of a Dial, as sent to its Action URL once the
+// dialed call ends.
+type DialResult struct {
+	DialCallStatus twilio.DialCallStatus
+
+	// DialCallSid is the SID of the call to the called party.
+	DialCallSid string
+
+	// DialCallDuration is how long the call to the called party lasted.
+	DialCallDuration time.Duration
+
+	// DialBridged is whether the caller was connected to the called party.
+	DialBridged bool
+
+	// RecordingURL is the recording of the call, if the Dial's Record
+	// was set.
+	RecordingURL string
+}
+
+// NewDialResult returns the outcome of a Dial from the request made to its
+// Action URL, whose parameters were checked by twilio.ParseVoiceRequest.
+func NewDialResult(req *twilio.VoiceRequest) *DialResult {
+	return &DialResult{
+		DialCallStatus:   req.DialCallStatus,
+		DialCallSid:      req.DialCallSid,
+		DialCallDuration: req.DialCallDuration,
+		DialBridged:      req.DialBridged,
+		RecordingURL:     req.RecordingURL,
+	}
+}
+
+// Answered returns whether the called party answered.
+func (d *DialResult) Answered() bool {
+	return d.DialCallStatus.Answered()
+}
@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio"
+)
+
+func TestNewDialResult(t *testing.T) {
+	req := parseVoiceRequest(t, "DialCallStatus=completed&DialCallSid="+handlerCallSid+
+		"&DialCallDuration=65&DialBridged=true&RecordingUrl=https%3A%2F%2Fapi.twilio.com%2Frec")
+
+	got := NewDialResult(req)
+
+	want := &DialResult{
+		DialCallStatus:   twilio.DialCallStatusCompleted,
+		DialCallSid:      handlerCallSid,
+		DialCallDuration: 65 * time.Second,
+		DialBridged:      true,
+		RecordingURL:     "https://api.twilio.com/rec",
+	}
+
+	if *got != *want {
+		t.Errorf("NewDialResult() = %+v, want %+v", got, want)
+	}
+
+	if !got.Answered() {
+		t.Error("Answered() = false, want true")
+	}
+
+	// the parameters of the Dial shouldn't be custom parameters
+	if len(req.Params) != 0 {
+		t.Errorf("VoiceRequest.Params = %v, want none", req.Params)
+	}
+}
+
+func TestDialResult_Answered(t *testing.T) {
+	tests := []struct {
+		status   twilio.DialCallStatus
+		answered bool
+	}{
+		{twilio.DialCallStatusCompleted, true},
+		{twilio.DialCallStatusAnswered, true},
+		{twilio.DialCallStatusBusy, false},
+		{twilio.DialCallStatusNoAnswer, false},
+		{twilio.DialCallStatusFailed, false},
+		{twilio.DialCallStatusCanceled, false},
+		{0, false},
+	}
+
+	for _, test := range tests {
+		if got := (&DialResult{DialCallStatus: test.status}).Answered(); got != test.answered {
+			t.Errorf("Answered() with %q = %t, want %t", test.status, got, test.answered)
+		}
+	}
+}
@@ -20,11 +20,23 @@
 // where we translate the value to the string representation in TwiML (e.g.,
 // DoNotTrim becomes "do-not-trim").
 //
-// It's worth noting that this package does not do deep validation of TwiML
-// documents you are attempting to render. In other words if you try to render
-// an invalid TwiML document, by trying to place a Redirect verb within a Gather
-// verb for example, this package will happily render the document. However,
-// Twilio will fail to parse this document as it is invalid per the spec.
+// It's worth noting that this package does not validate TwiML documents when
+// rendering them. In other words if you try to render an invalid TwiML
+// document, by trying to place a Redirect verb within a Gather verb for
+// example, this package will happily render the document. However, Twilio will
+// fail to parse this document as it is invalid per the spec. The Validate()
+// function can be used to check where verbs and nouns are placed before
+// rendering. For a stricter check, ValidateDocument() checks a rendered
+// document against the package's own XML Schema of TwiML, which is written
+// from Twilio's documentation rather than published by Twilio, reporting each
+// invalid element or attribute with its line number. The
+// WithSchemaValidation() option does the same when encoding.
+//
+// The verbs, nouns, and most of the enum types in this package are generated
+// from the TwiML schema in schema.json, by running go generate. To add a new
+// verb, attribute, or value, edit the schema instead of the generated files.
+// The bundled XSDs live in the xsd directory, and are also bundled in to the
+// package by go generate.
 //
 // There are a few functions available to you for rendering out TwiML, with the
 // main being EncodeResponse(). All of the other functions end up calling
@@ -0,0 +1,197 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidDTMF is the cause of errors returned when a DTMF sequence contains
+// characters other than 0-9, *, #, w, and W.
+var ErrInvalidDTMF = errors.New("invalid DTMF sequence")
+
+const (
+	// DTMFShortPause is the length of the pause represented by a 'w' in a
+	// DTMF sequence.
+	DTMFShortPause = 500 * time.Millisecond
+
+	// DTMFLongPause is the length of the pause represented by a 'W' in a
+	// DTMF sequence.
+	DTMFLongPause = time.Second
+
+	// DTMFDigitDuration is the estimated time it takes to play a single
+	// digit of a DTMF sequence, including the gap before the next digit.
+	// Twilio doesn't document the tone length, so this is used only by
+	// DTMF.Duration().
+	DTMFDigitDuration = 200 * time.Millisecond
+)
+
+// DTMF is a sequence of dual tone multi frequency digits to play on a call. It
+// may only contain the digits 0-9, *, #, and the pause characters 'w' (half a
+// second) and 'W' (one second). It renders to XML as a plain string, so
+// existing string constants can still be used for any field of this type.
+// Use ParseDTMF or a DTMFBuilder to make sure the sequence is valid.
+type DTMF string
+
+// ParseDTMF returns the DTMF sequence s after validating that it only contains
+// characters Twilio accepts. This function returns a wrapped error (see
+// package documentation for more info).
+func ParseDTMF(s string) (DTMF, error) {
+	d := DTMF(s)
+
+	if err := d.Validate(); err != nil {
+		return "", err
+	}
+
+	return d, nil
+}
+
+// Validate returns an error if the DTMF sequence contains characters other
+// than 0-9, *, #, w, and W. This function returns a wrapped error (see package
+// documentation for more info).
+func (d DTMF) Validate() error {
+	for i, r := range string(d) {
+		if !isDTMFDigit(r) && r != 'w' && r != 'W' {
+			return errors.Wrapf(ErrInvalidDTMF, "unexpected character %q at offset %d", r, i)
+		}
+	}
+
+	return nil
+}
+
+// Duration returns how long the DTMF sequence takes to play, using
+// DTMFDigitDuration for each digit and the pause lengths for each 'w' and 'W'.
+func (d DTMF) Duration() time.Duration {
+	var total time.Duration
+
+	for _, r := range string(d) {
+		switch {
+		case r == 'w':
+			total += DTMFShortPause
+		case r == 'W':
+			total += DTMFLongPause
+		case isDTMFDigit(r):
+			total += DTMFDigitDuration
+		}
+	}
+
+	return total
+}
+
+// Digits returns the DTMF sequence with all pauses removed.
+func (d DTMF) Digits() string {
+	return strings.Map(func(r rune) rune {
+		if r == 'w' || r == 'W' {
+			return -1
+		}
+
+		return r
+	}, string(d))
+}
+
+func (d DTMF) String() string {
+	return string(d)
+}
+
+func isDTMFDigit(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '*' || r == '#'
+}
+
+// DTMFBuilder builds a DTMF sequence, converting pauses to 'w' and 'W'
+// characters. The first invalid input is remembered and returned from Build(),
+// so that calls can be chained:
+//
+//	digits, err := (&twiml.DTMFBuilder{}).
+//		Wait(2 * time.Second).
+//		Digits("1234").
+//		Pause().
+//		Digits("#").
+//		Build()
+//
+// The zero value is ready to use.
+type DTMFBuilder struct {
+	buf bytes.Buffer
+	err error
+}
+
+// Digits appends the digits in s to the sequence. s may only contain 0-9, *,
+// and #.
+func (b *DTMFBuilder) Digits(s string) *DTMFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	for i, r := range s {
+		if !isDTMFDigit(r) {
+			b.err = errors.Wrapf(ErrInvalidDTMF, "unexpected digit %q at offset %d of %q", r, i, s)
+			return b
+		}
+	}
+
+	b.buf.WriteString(s)
+
+	return b
+}
+
+// Pause appends a short, half-second, pause ('w') to the sequence.
+func (b *DTMFBuilder) Pause() *DTMFBuilder {
+	if b.err == nil {
+		b.buf.WriteByte('w')
+	}
+
+	return b
+}
+
+// LongPause appends a one second pause ('W') to the sequence.
+func (b *DTMFBuilder) LongPause() *DTMFBuilder {
+	if b.err == nil {
+		b.buf.WriteByte('W')
+	}
+
+	return b
+}
+
+// Wait appends a pause of duration d to the sequence, as a run of 'W'
+// characters followed by a 'w' for any remaining half second. Durations that
+// aren't a multiple of half a second are rounded up.
+func (b *DTMFBuilder) Wait(d time.Duration) *DTMFBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if d < 0 {
+		b.err = errors.Wrapf(ErrInvalidDTMF, "negative pause duration %s", d)
+		return b
+	}
+
+	halves := int64((d + DTMFShortPause - 1) / DTMFShortPause)
+
+	for ; halves >= 2; halves -= 2 {
+		b.buf.WriteByte('W')
+	}
+
+	if halves == 1 {
+		b.buf.WriteByte('w')
+	}
+
+	return b
+}
+
+// Build returns the DTMF sequence, or the first error encountered while
+// building it. This function returns a wrapped error (see package
+// documentation for more info).
+func (b *DTMFBuilder) Build() (DTMF, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	return DTMF(b.buf.String()), nil
+}
@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseDTMF(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		out  DTMF
+		err  error
+	}{
+		{"Empty sequence should be valid", "", "", nil},
+		{"Digits and pauses should be valid", "0w42*#W", "0w42*#W", nil},
+		{"Spaces should be rejected", "1 2", "", ErrInvalidDTMF},
+		{"Letters should be rejected", "1a", "", ErrInvalidDTMF},
+		{"Commas should be rejected", "1,,2", "", ErrInvalidDTMF},
+	}
+
+	for _, test := range tests {
+		out, err := ParseDTMF(test.in)
+
+		if cause := errors.Cause(err); cause != test.err {
+			t.Errorf(
+				"\nDescription: %s\nParseDTMF(%q) error = %v; want cause %v",
+				test.desc, test.in, err, test.err,
+			)
+			continue
+		}
+
+		if out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nParseDTMF(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestDTMF_Duration(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   DTMF
+		out  time.Duration
+	}{
+		{"Empty sequence should take no time", "", 0},
+		{"Single digit should take DTMFDigitDuration", "1", DTMFDigitDuration},
+		{"w should be half a second", "w", 500 * time.Millisecond},
+		{"W should be one second", "W", time.Second},
+		{"Mixed sequence should be summed", "Ww12#", 1500*time.Millisecond + 3*DTMFDigitDuration},
+	}
+
+	for _, test := range tests {
+		if out := test.in.Duration(); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nDTMF(%q).Duration() = %s; want %s",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestDTMF_Digits(t *testing.T) {
+	if out := DTMF("ww1234W#").Digits(); out != "1234#" {
+		t.Errorf("DTMF(%q).Digits() = %q; want %q", "ww1234W#", out, "1234#")
+	}
+}
+
+func TestDTMFBuilder(t *testing.T) {
+	tests := []struct {
+		desc  string
+		build func(b *DTMFBuilder) *DTMFBuilder
+		out   DTMF
+		err   error
+	}{
+		{
+			"Digits and pauses should be appended in order",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Digits("12").Pause().Digits("*").LongPause().Digits("#") },
+			"12w*W#", nil,
+		},
+		{
+			"Wait of whole seconds should be W runs",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Wait(3 * time.Second).Digits("1") },
+			"WWW1", nil,
+		},
+		{
+			"Wait with a half second should end in w",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Wait(2500 * time.Millisecond) },
+			"WWw", nil,
+		},
+		{
+			"Wait should round up to the next half second",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Wait(1100 * time.Millisecond) },
+			"Ww", nil,
+		},
+		{
+			"Zero Wait should append nothing",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Digits("1").Wait(0).Digits("2") },
+			"12", nil,
+		},
+		{
+			"Invalid digits should fail the build",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Digits("12").Digits("w").Digits("3") },
+			"", ErrInvalidDTMF,
+		},
+		{
+			"Negative Wait should fail the build",
+			func(b *DTMFBuilder) *DTMFBuilder { return b.Wait(-time.Second) },
+			"", ErrInvalidDTMF,
+		},
+	}
+
+	for _, test := range tests {
+		out, err := test.build(&DTMFBuilder{}).Build()
+
+		if cause := errors.Cause(err); cause != test.err {
+			t.Errorf(
+				"\nDescription: %s\nDTMFBuilder.Build() error = %v; want cause %v",
+				test.desc, err, test.err,
+			)
+			continue
+		}
+
+		if out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nDTMFBuilder.Build() = %q; want %q",
+				test.desc, out, test.out,
+			)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownValue is the cause of errors returned when parsing a string that
+// isn't the TwiML value of any constant of the type being parsed.
+//
+// All of the enum types in this package can be parsed from the same string
+// they render to XML as, using their ParseX function, the
+// encoding.TextUnmarshaler interface, or the xml.UnmarshalerAttr interface, and
+// can be used as a flag.Value. The empty string parses to the zero value, which
+// is not rendered.
+var ErrUnknownValue = errors.New("unknown value")
+
+func unknownValue(typ, s string) error {
+	return errors.Wrapf(ErrUnknownValue, "parsing %s %q", typ, s)
+}
+
+// splitFlags splits the string form of a bit-flag type in to its words, which
+// may be separated by spaces or commas.
+func splitFlags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\t'
+	})
+}
@@ -0,0 +1,224 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// textEnum is the set of interfaces every enum type in this package implements
+// through its pointer.
+type textEnum interface {
+	fmt.Stringer
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+	flag.Value
+}
+
+// enumValues returns a pointer to a copy of every listed value of every enum
+// type, along with a function returning a fresh zero value of the same type.
+func enumValues() map[string][]func() (textEnum, textEnum) {
+	out := make(map[string][]func() (textEnum, textEnum))
+
+	add := func(name string, v, zero textEnum) {
+		out[name] = append(out[name], func() (textEnum, textEnum) { return v, zero })
+	}
+
+	for _, v := range TrimValues() {
+		v := v
+		add("Trim", &v, new(Trim))
+	}
+	for _, v := range DialRecordValues() {
+		v := v
+		add("DialRecord", &v, new(DialRecord))
+	}
+	for _, v := range ConfRecordValues() {
+		v := v
+		add("ConfRecord", &v, new(ConfRecord))
+	}
+	for _, v := range RejectReasonValues() {
+		v := v
+		add("RejectReason", &v, new(RejectReason))
+	}
+	for _, v := range GatherInputValues() {
+		v := v
+		add("GatherInput", &v, new(GatherInput))
+	}
+	for _, v := range BargeInValues() {
+		v := v
+		add("BargeIn", &v, new(BargeIn))
+	}
+	for _, v := range ConfBeepValues() {
+		v := v
+		add("ConfBeep", &v, new(ConfBeep))
+	}
+	for _, v := range ConfStartOnEnterBoolValues() {
+		v := v
+		add("ConfStartOnEnterBool", &v, new(ConfStartOnEnterBool))
+	}
+	for _, v := range VoiceValues() {
+		v := v
+		add("Voice", &v, new(Voice))
+	}
+	for _, v := range RingToneValues() {
+		v := v
+		add("RingTone", &v, new(RingTone))
+	}
+	for _, v := range ConfRegionValues() {
+		v := v
+		add("ConfRegion", &v, new(ConfRegion))
+	}
+	for _, v := range LanguageValues() {
+		v := v
+		add("Language", &v, new(Language))
+	}
+	for _, v := range FinishOnKeyValues() {
+		v := v
+		add("FinishOnKey", &v, new(FinishOnKey))
+	}
+	for _, v := range StatusCallbackEventValues() {
+		v := v
+		add("StatusCallbackEvent", &v, new(StatusCallbackEvent))
+	}
+	for _, v := range ConfStatusCallbackEventValues() {
+		v := v
+		add("ConfStatusCallbackEvent", &v, new(ConfStatusCallbackEvent))
+	}
+
+	return out
+}
+
+func TestEnums_RoundTrip(t *testing.T) {
+	for name, values := range enumValues() {
+		seen := make(map[string]bool)
+
+		for _, get := range values {
+			v, zero := get()
+
+			text, err := v.MarshalText()
+
+			if err != nil {
+				t.Errorf("%s(%s).MarshalText() Unexpected Error: %s", name, v, err)
+				continue
+			}
+
+			if len(text) == 0 {
+				t.Errorf("%s(%s).MarshalText() should not be empty for a listed value", name, v)
+			}
+
+			if seen[string(text)] {
+				t.Errorf("%s has more than one value that marshals to %q", name, text)
+			}
+
+			seen[string(text)] = true
+
+			if err := zero.UnmarshalText(text); err != nil {
+				t.Errorf("%s.UnmarshalText(%q) Unexpected Error: %s", name, text, err)
+				continue
+			}
+
+			if zero.String() != v.String() {
+				t.Errorf("%s.UnmarshalText(%q) = %s; want %s", name, text, zero, v)
+			}
+
+			upper := strings.ToUpper(string(text))
+
+			if err := zero.Set(upper); err != nil || zero.String() != v.String() {
+				t.Errorf("%s.Set(%q) = %s, %v; want %s", name, upper, zero, err, v)
+			}
+		}
+	}
+}
+
+func TestEnums_Unknown(t *testing.T) {
+	for name, values := range enumValues() {
+		_, zero := values[0]()
+
+		err := zero.Set("bacon")
+
+		if errors.Cause(err) != ErrUnknownValue {
+			t.Errorf("%s.Set(%q) error = %v; want cause ErrUnknownValue", name, "bacon", err)
+		}
+
+		if err := zero.Set(""); err != nil {
+			t.Errorf("%s.Set(%q) Unexpected Error: %s", name, "", err)
+		}
+	}
+}
+
+func TestEnums_JSON(t *testing.T) {
+	type config struct {
+		Voice    Voice               `json:"voice"`
+		Language Language            `json:"language"`
+		Events   StatusCallbackEvent `json:"events"`
+		Finish   FinishOnKey         `json:"finish"`
+		RingTone RingTone            `json:"ring_tone"`
+	}
+
+	in := `{"voice":"alice","language":"en-GB","events":"ringing answered","finish":"*#","ring_tone":"de"}`
+
+	var c config
+
+	if err := json.Unmarshal([]byte(in), &c); err != nil {
+		t.Fatalf("json.Unmarshal() Unexpected Error: %s", err)
+	}
+
+	want := config{
+		Voice:    VoiceAlice,
+		Language: LangEnglishUK,
+		Events:   StatusCallbackRinging | StatusCallbackAnswered,
+		Finish:   FinishKeyStar | FinishKeyPound,
+		RingTone: RingToneGermany,
+	}
+
+	if c != want {
+		t.Errorf("json.Unmarshal() = %#v; want %#v", c, want)
+	}
+
+	out, err := json.Marshal(c)
+
+	if err != nil {
+		t.Fatalf("json.Marshal() Unexpected Error: %s", err)
+	}
+
+	if string(out) != in {
+		t.Errorf("json.Marshal() = %s; want %s", out, in)
+	}
+
+	if _, err := json.Marshal(config{}); err != nil {
+		t.Errorf("json.Marshal() of zero values Unexpected Error: %s", err)
+	}
+}
+
+func TestEnums_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	voice := VoiceAlice
+	events := StatusCallbackAll
+
+	fs.Var(&voice, "voice", "voice to use")
+	fs.Var(&events, "events", "status callback events")
+
+	if err := fs.Parse([]string{"-voice", "woman", "-events", "initiated,completed"}); err != nil {
+		t.Fatalf("FlagSet.Parse() Unexpected Error: %s", err)
+	}
+
+	if voice != VoiceWoman {
+		t.Errorf("voice = %s; want %s", voice, VoiceWoman)
+	}
+
+	if events != StatusCallbackInitiated|StatusCallbackCompleted {
+		t.Errorf("events = %s; want %s", events, StatusCallbackInitiated|StatusCallbackCompleted)
+	}
+}
@@ -9,6 +9,7 @@ package twiml
 import (
 	"bytes"
 	"encoding/xml"
+	"strings"
 )
 
 // FinishOnKey is a type for defining which digits will end a recording when
@@ -86,6 +87,18 @@ func (f FinishOnKey) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface. The attribute
+// is only rendered for non-zero values, so an empty attribute is parsed as
+// FinishKeyNone.
+func (f *FinishOnKey) UnmarshalXMLAttr(attr xml.Attr) error {
+	if attr.Value == "" {
+		*f = FinishKeyNone
+		return nil
+	}
+
+	return f.Set(attr.Value)
+}
+
 func (f FinishOnKey) String() string {
 	if f == FinishKeyNone {
 		return ""
@@ -146,3 +159,74 @@ func (f FinishOnKey) String() string {
 
 	return buf.String()
 }
+
+// FinishOnKeyValues returns each of the FinishOnKey flags for the keys on a
+// keypad, for listing the valid choices in configuration or help text.
+// FinishKeyNone is not included.
+func FinishOnKeyValues() []FinishOnKey {
+	return []FinishOnKey{
+		FinishKeyNumber1, FinishKeyNumber2, FinishKeyNumber3,
+		FinishKeyNumber4, FinishKeyNumber5, FinishKeyNumber6,
+		FinishKeyNumber7, FinishKeyNumber8, FinishKeyNumber9,
+		FinishKeyNumber0, FinishKeyStar, FinishKeyPound,
+	}
+}
+
+// ParseFinishOnKey parses a string of keys, such as "*#" or "0", in to a
+// FinishOnKey. Spaces and commas between keys are ignored. As FinishKeyNone
+// renders an empty string, just like the zero value, it's parsed from the
+// string "none" instead. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseFinishOnKey(s string) (FinishOnKey, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "none") {
+		return FinishKeyNone, nil
+	}
+
+	var parsed FinishOnKey
+
+	for _, r := range s {
+		switch {
+		case r == ' ', r == ',':
+			continue
+		case r >= '0' && r <= '9':
+			parsed |= FinishKeyNumber0 << uint(r-'0')
+		case r == '*':
+			parsed |= FinishKeyStar
+		case r == '#':
+			parsed |= FinishKeyPound
+		default:
+			return FinishOnKey(0), unknownValue("FinishOnKey", s)
+		}
+	}
+
+	return parsed, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. FinishKeyNone
+// is marshaled as "none", so that it can be told apart from the zero value.
+func (f FinishOnKey) MarshalText() ([]byte, error) {
+	if f == FinishKeyNone {
+		return []byte("none"), nil
+	}
+
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (f *FinishOnKey) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+// Set implements the flag.Value interface. The parsed value replaces the
+// current one, rather than being combined with it.
+func (f *FinishOnKey) Set(value string) error {
+	parsed, err := ParseFinishOnKey(value)
+
+	if err != nil {
+		return err
+	}
+
+	*f = parsed
+
+	return nil
+}
@@ -9,6 +9,8 @@ package twiml
 import (
 	"encoding/xml"
 	"testing"
+
+	"github.com/pkg/errors"
 )
 
 func TestFinishOnKey_String(t *testing.T) {
@@ -89,3 +91,42 @@ func TestFinishOnKey_MarshalXMLAttr(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFinishOnKey(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		out  FinishOnKey
+		err  error
+	}{
+		{"Empty string should be the zero value", "", FinishOnKey(0), nil},
+		{"none should be FinishKeyNone", "none", FinishKeyNone, nil},
+		{"Single key should be parsed", "0", FinishKeyNumber0, nil},
+		{"Multiple keys should be combined", "*#", FinishKeyStar | FinishKeyPound, nil},
+		{"Separators should be ignored", "1, 2 3", FinishKeyNumber1 | FinishKeyNumber2 | FinishKeyNumber3, nil},
+		{"All keys should be FinishKeyAll", "1234567890*#", FinishKeyAll, nil},
+		{"Letters should be rejected", "a", FinishOnKey(0), ErrUnknownValue},
+	}
+
+	for _, test := range tests {
+		out, err := ParseFinishOnKey(test.in)
+
+		if errors.Cause(err) != test.err {
+			t.Errorf(
+				"\nDescription: %s\nParseFinishOnKey(%q) error = %v; want cause %v",
+				test.desc, test.in, err, test.err,
+			)
+		}
+
+		if out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nParseFinishOnKey(%q) = %d; want %d",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+
+	if text, _ := FinishKeyNone.MarshalText(); string(text) != "none" {
+		t.Errorf("FinishKeyNone.MarshalText() = %q; want %q", text, "none")
+	}
+}
@@ -0,0 +1,356 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// maxPromptLength is the number of characters a prompt is truncated to when
+// shown on a CallFlow node.
+const maxPromptLength = 60
+
+// CallFlow is the graph of TwiML documents a call moves between, built by
+// following the URLs Twilio requests next: Gather, Record, Dial, Pay, and
+// Connect actions, Redirect targets, and Enqueue waitUrls and actions. It can
+// be rendered as a Graphviz DOT or Mermaid diagram to show what an IVR does.
+type CallFlow struct {
+	// Nodes are the documents of the flow, starting with the first
+	// response, in the order they were found.
+	Nodes []*FlowNode
+
+	// Edges are the transitions between documents, in the order they were
+	// found.
+	Edges []FlowEdge
+}
+
+// FlowNode is a single TwiML document within a CallFlow.
+type FlowNode struct {
+	// ID identifies the node within the rendered graph, such as "n0".
+	ID string
+
+	// URL is the URL the document is served from. It's empty for the
+	// starting response if it wasn't provided with a URL.
+	URL string
+
+	// Prompts are what the caller hears, in order: the message of each Say
+	// verb and the URL of each Play verb, truncated to keep the graph
+	// readable.
+	Prompts []string
+
+	// Missing is true if the URL was the target of an edge, but wasn't one
+	// of the responses the CallFlow was built from.
+	Missing bool
+}
+
+// FlowEdge is a transition from one FlowNode to another, labelled by what
+// causes it, such as "Gather: 1 digit" or "Dial +15555550100".
+type FlowEdge struct {
+	From  string
+	To    string
+	Label string
+}
+
+// NewCallFlow builds the CallFlow starting at the *Response r, following any
+// URLs to the responses keyed by URL. Relative URLs are resolved against the
+// URL of the document they're found in, and start is the URL of r if it's
+// known (it may be empty). To graph a set of responses, pass the first one as
+// r and all of them as responses; to graph a single response, responses may be
+// nil and the URLs it refers to are shown as missing nodes.
+//
+// Gather, Record, and Pay verbs without an action post back to the URL of the
+// document they're in, which is shown as an edge to the node itself.
+func NewCallFlow(start string, r *Response, responses map[string]*Response) *CallFlow {
+	b := &flowBuilder{
+		flow:      &CallFlow{},
+		responses: responses,
+		nodes:     make(map[string]*FlowNode),
+	}
+
+	first := b.node(start)
+	b.visit(first, r)
+
+	// responses are visited in the order they're reached, so the output is
+	// stable regardless of map iteration order
+	for i := 1; i < len(b.flow.Nodes); i++ {
+		n := b.flow.Nodes[i]
+
+		if resp, ok := responses[n.URL]; ok {
+			b.visit(n, resp)
+		} else {
+			n.Missing = true
+		}
+	}
+
+	return b.flow
+}
+
+type flowBuilder struct {
+	flow      *CallFlow
+	responses map[string]*Response
+	nodes     map[string]*FlowNode
+}
+
+// node returns the FlowNode for the URL, adding it if it's new.
+func (b *flowBuilder) node(u string) *FlowNode {
+	if n, ok := b.nodes[u]; ok {
+		return n
+	}
+
+	n := &FlowNode{ID: fmt.Sprintf("n%d", len(b.flow.Nodes)), URL: u}
+
+	b.nodes[u] = n
+	b.flow.Nodes = append(b.flow.Nodes, n)
+
+	return n
+}
+
+func (b *flowBuilder) edge(from *FlowNode, target, label string) {
+	to := b.node(resolveURL(from.URL, target))
+	b.flow.Edges = append(b.flow.Edges, FlowEdge{From: from.ID, To: to.ID, Label: label})
+}
+
+// visit adds the prompts of r to n, and an edge for each URL r refers to.
+func (b *flowBuilder) visit(n *FlowNode, r *Response) {
+	if r == nil {
+		return
+	}
+
+	_ = Walk(r, func(_ Path, node interface{}) error {
+		switch v := derefNode(node).(type) {
+		case Say:
+			n.Prompts = append(n.Prompts, truncatePrompt(v.Message))
+		case Play:
+			n.Prompts = append(n.Prompts, truncatePrompt("♪ "+v.URL))
+		case Gather:
+			b.edge(n, orSelf(v.Action, n.URL), gatherLabel(v))
+		case Record:
+			b.edge(n, orSelf(v.Action, n.URL), "Record")
+		case Pay:
+			b.edge(n, orSelf(v.Action, n.URL), "Pay")
+		case Dial:
+			if v.Action != "" {
+				b.edge(n, v.Action, dialLabel(v))
+			}
+		case Connect:
+			if v.Action != "" {
+				b.edge(n, v.Action, "Connect ended")
+			}
+		case Enqueue:
+			if v.WaitURL != "" {
+				b.edge(n, v.WaitURL, "waiting in "+v.QueueName)
+			}
+
+			if v.Action != "" {
+				b.edge(n, v.Action, "left "+v.QueueName)
+			}
+		case Redirect:
+			b.edge(n, v.URL, "Redirect")
+		}
+
+		return nil
+	})
+}
+
+// derefNode returns the value a non-nil pointer to a struct refers to, so a
+// verb can be handled the same whether or not it's a pointer.
+func derefNode(node interface{}) interface{} {
+	v := reflect.ValueOf(node)
+
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		return v.Elem().Interface()
+	}
+
+	return node
+}
+
+func orSelf(action, self string) string {
+	if action == "" {
+		return self
+	}
+
+	return action
+}
+
+// resolveURL resolves ref relative to base, leaving it unchanged if either
+// can't be parsed or base is empty.
+func resolveURL(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+
+	b, err := url.Parse(base)
+
+	if err != nil {
+		return ref
+	}
+
+	r, err := url.Parse(ref)
+
+	if err != nil {
+		return ref
+	}
+
+	return b.ResolveReference(r).String()
+}
+
+func gatherLabel(g Gather) string {
+	label := "Gather: " + gatherInput(g)
+
+	// speech is always the last input described, so the hints follow it
+	if g.Hints != "" && g.Input&GatherInputSpeech != 0 {
+		label += " (" + g.Hints + ")"
+	}
+
+	return label
+}
+
+func dialLabel(d Dial) string {
+	var targets []string
+
+	if d.Number != "" {
+		targets = append(targets, string(d.Number))
+	}
+
+	for _, noun := range d.Nouns {
+		switch v := derefNode(noun).(type) {
+		case DialNumber:
+			targets = append(targets, string(v.Number))
+		case DialClient:
+			targets = append(targets, "client:"+v.ClientName)
+		case DialConference:
+			targets = append(targets, "conference:"+v.Name)
+		case DialQueue:
+			targets = append(targets, "queue:"+v.QueueName)
+		case DialSIP:
+			targets = append(targets, v.URI)
+		case DialSIM:
+			targets = append(targets, "sim:"+v.SIM)
+		}
+	}
+
+	if len(targets) == 0 {
+		return "Dial"
+	}
+
+	return "Dial " + strings.Join(targets, ", ")
+}
+
+func truncatePrompt(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	if utf8.RuneCountInString(s) <= maxPromptLength {
+		return s
+	}
+
+	return string([]rune(s)[:maxPromptLength-1]) + "…"
+}
+
+// title is the first line of the label of n.
+func (n *FlowNode) title() string {
+	if n.URL == "" {
+		return "start"
+	}
+
+	return n.URL
+}
+
+// WriteDOT renders the CallFlow as a Graphviz DOT digraph, writing it to w.
+// Missing nodes are drawn with a dashed outline. This function returns a
+// wrapped error (see package documentation for more info).
+func (f *CallFlow) WriteDOT(w io.Writer) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	buf.WriteString("digraph callflow {\n")
+	buf.WriteString("  node [shape=box];\n")
+
+	for _, n := range f.Nodes {
+		label := append([]string{n.title()}, n.Prompts...)
+
+		fmt.Fprintf(buf, "  %s [label=%s", n.ID, dotQuote(strings.Join(label, "\n")))
+
+		if n.Missing {
+			buf.WriteString(", style=dashed")
+		}
+
+		buf.WriteString("];\n")
+	}
+
+	for _, e := range f.Edges {
+		fmt.Fprintf(buf, "  %s -> %s [label=%s];\n", e.From, e.To, dotQuote(e.Label))
+	}
+
+	buf.WriteString("}\n")
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "writing DOT graph failed")
+	}
+
+	return nil
+}
+
+// dotQuote renders s as a DOT quoted string, with newlines as line breaks.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// WriteMermaid renders the CallFlow as a Mermaid flowchart, writing it to w.
+// Missing nodes are drawn with rounded corners. This function returns a
+// wrapped error (see package documentation for more info).
+func (f *CallFlow) WriteMermaid(w io.Writer) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	buf.WriteString("flowchart TD\n")
+
+	for _, n := range f.Nodes {
+		label := []string{mermaidEscape(n.title())}
+
+		for _, p := range n.Prompts {
+			label = append(label, mermaidEscape(p))
+		}
+
+		start, end := "[", "]"
+
+		if n.Missing {
+			start, end = "(", ")"
+		}
+
+		fmt.Fprintf(buf, "  %s%s\"%s\"%s\n", n.ID, start, strings.Join(label, "<br/>"), end)
+	}
+
+	for _, e := range f.Edges {
+		fmt.Fprintf(buf, "  %s -->|\"%s\"| %s\n", e.From, mermaidEscape(e.Label), e.To)
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "writing Mermaid graph failed")
+	}
+
+	return nil
+}
+
+// mermaidEscape replaces the characters that would end a Mermaid label, or be
+// parsed as markup, with entity codes.
+func mermaidEscape(s string) string {
+	r := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "|", "#124;")
+	return r.Replace(s)
+}
@@ -0,0 +1,216 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func testIVR() (*Response, map[string]*Response) {
+	main := &Response{Verbs: []interface{}{
+		&Gather{
+			Action:    "/menu",
+			NumDigits: 1,
+			NestedVerbs: []interface{}{
+				&Say{Message: "Welcome! Press 1 for sales, or 2 for \"support\"."},
+			},
+		},
+		&Redirect{URL: "https://example.org/ivr/main"},
+	}}
+
+	responses := map[string]*Response{
+		"https://example.org/ivr/main": main,
+		"https://example.org/menu": {Verbs: []interface{}{
+			Dial{Action: "dial-done", Number: "+15555550100"},
+			&Enqueue{QueueName: "support", WaitURL: "/hold"},
+		}},
+		"https://example.org/dial-done": {Verbs: []interface{}{
+			&Say{Message: "Goodbye"},
+			&Hangup{},
+		}},
+	}
+
+	return main, responses
+}
+
+func TestNewCallFlow(t *testing.T) {
+	main, responses := testIVR()
+	flow := NewCallFlow("https://example.org/ivr/main", main, responses)
+
+	wantNodes := []FlowNode{
+		{ID: "n0", URL: "https://example.org/ivr/main", Prompts: []string{`Welcome! Press 1 for sales, or 2 for "support".`}},
+		{ID: "n1", URL: "https://example.org/menu"},
+		{ID: "n2", URL: "https://example.org/dial-done", Prompts: []string{"Goodbye"}},
+		{ID: "n3", URL: "https://example.org/hold", Missing: true},
+	}
+
+	if len(flow.Nodes) != len(wantNodes) {
+		t.Fatalf("NewCallFlow() nodes = %d, want %d", len(flow.Nodes), len(wantNodes))
+	}
+
+	for i, n := range flow.Nodes {
+		if !reflect.DeepEqual(*n, wantNodes[i]) {
+			t.Errorf("NewCallFlow() node %d = %#v, want %#v", i, *n, wantNodes[i])
+		}
+	}
+
+	wantEdges := []FlowEdge{
+		{From: "n0", To: "n1", Label: "Gather: 1 digit"},
+		{From: "n0", To: "n0", Label: "Redirect"},
+		{From: "n1", To: "n2", Label: "Dial +15555550100"},
+		{From: "n1", To: "n3", Label: "waiting in support"},
+	}
+
+	if !reflect.DeepEqual(flow.Edges, wantEdges) {
+		t.Errorf("NewCallFlow() edges = %#v, want %#v", flow.Edges, wantEdges)
+	}
+}
+
+func TestNewCallFlow_Single(t *testing.T) {
+	r := &Response{Verbs: []interface{}{
+		&Gather{Input: GatherInputDTMFSpeech, Hints: "sales, support"},
+		&Record{Action: "/voicemail"},
+	}}
+
+	flow := NewCallFlow("", r, nil)
+
+	wantEdges := []FlowEdge{
+		{From: "n0", To: "n0", Label: "Gather: digits or speech (sales, support)"},
+		{From: "n0", To: "n1", Label: "Record"},
+	}
+
+	if !reflect.DeepEqual(flow.Edges, wantEdges) {
+		t.Errorf("NewCallFlow() edges = %#v, want %#v", flow.Edges, wantEdges)
+	}
+
+	if len(flow.Nodes) != 2 || !flow.Nodes[1].Missing || flow.Nodes[1].URL != "/voicemail" {
+		t.Errorf("NewCallFlow() nodes = %#v, want a missing /voicemail node", flow.Nodes)
+	}
+}
+
+func TestCallFlow_WriteDOT(t *testing.T) {
+	main, responses := testIVR()
+	buf := &bytes.Buffer{}
+
+	if err := NewCallFlow("https://example.org/ivr/main", main, responses).WriteDOT(buf); err != nil {
+		t.Fatalf("WriteDOT() Unexpected Error: %s", err)
+	}
+
+	want := `digraph callflow {
+  node [shape=box];
+  n0 [label="https://example.org/ivr/main\nWelcome! Press 1 for sales, or 2 for \"support\"."];
+  n1 [label="https://example.org/menu"];
+  n2 [label="https://example.org/dial-done\nGoodbye"];
+  n3 [label="https://example.org/hold", style=dashed];
+  n0 -> n1 [label="Gather: 1 digit"];
+  n0 -> n0 [label="Redirect"];
+  n1 -> n2 [label="Dial +15555550100"];
+  n1 -> n3 [label="waiting in support"];
+}
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("WriteDOT() = %s\nwant %s", got, want)
+	}
+}
+
+func TestCallFlow_WriteMermaid(t *testing.T) {
+	main, responses := testIVR()
+	buf := &bytes.Buffer{}
+
+	if err := NewCallFlow("https://example.org/ivr/main", main, responses).WriteMermaid(buf); err != nil {
+		t.Fatalf("WriteMermaid() Unexpected Error: %s", err)
+	}
+
+	want := `flowchart TD
+  n0["https://example.org/ivr/main<br/>Welcome! Press 1 for sales, or 2 for #quot;support#quot;."]
+  n1["https://example.org/menu"]
+  n2["https://example.org/dial-done<br/>Goodbye"]
+  n3("https://example.org/hold")
+  n0 -->|"Gather: 1 digit"| n1
+  n0 -->|"Redirect"| n0
+  n1 -->|"Dial +15555550100"| n2
+  n1 -->|"waiting in support"| n3
+`
+
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMermaid() = %s\nwant %s", got, want)
+	}
+}
+
+func TestTruncatePrompt(t *testing.T) {
+	long := "This message is long enough that it has to be truncated when shown on the graph."
+
+	if got := truncatePrompt(long); len([]rune(got)) != maxPromptLength || got[len(got)-len("…"):] != "…" {
+		t.Errorf("truncatePrompt() = %q, want %d characters ending in an ellipsis", got, maxPromptLength)
+	}
+
+	if got := truncatePrompt("  short\n prompt "); got != "short prompt" {
+		t.Errorf("truncatePrompt() = %q, want %q", got, "short prompt")
+	}
+}
+
+func TestDialLabel(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   Dial
+		out  string
+	}{
+		{
+			desc: "no targets",
+			in:   Dial{Action: "/done"},
+			out:  "Dial",
+		},
+		{
+			desc: "number attribute",
+			in:   Dial{Action: "/done", Number: "+15555550100"},
+			out:  "Dial +15555550100",
+		},
+		{
+			desc: "nouns",
+			in: Dial{Action: "/done", Nouns: []interface{}{
+				&DialNumber{Number: "+15555550101"},
+				DialClient{ClientName: "alice"},
+				&DialQueue{QueueName: "support"},
+			}},
+			out: "Dial +15555550101, client:alice, queue:support",
+		},
+	}
+
+	for _, test := range tests {
+		if got := dialLabel(test.in); got != test.out {
+			t.Errorf("\nDescription: %s\ndialLabel() = %q, want %q", test.desc, got, test.out)
+		}
+	}
+}
+
+func TestGatherLabel(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   Gather
+		out  string
+	}{
+		{desc: "default input", in: Gather{}, out: "Gather: digits"},
+		{desc: "one digit", in: Gather{NumDigits: 1}, out: "Gather: 1 digit"},
+		{desc: "several digits", in: Gather{NumDigits: 4}, out: "Gather: 4 digits"},
+		{desc: "speech", in: Gather{Input: GatherInputSpeech}, out: "Gather: speech"},
+		{desc: "hints without speech", in: Gather{Hints: "sales"}, out: "Gather: digits"},
+		{
+			desc: "digits and speech with hints",
+			in:   Gather{Input: GatherInputDTMFSpeech, NumDigits: 1, Hints: "sales, support"},
+			out:  "Gather: 1 digit or speech (sales, support)",
+		},
+	}
+
+	for _, test := range tests {
+		if got := gatherLabel(test.in); got != test.out {
+			t.Errorf("\nDescription: %s\ngatherLabel() = %q, want %q", test.desc, got, test.out)
+		}
+	}
+}
@@ -4,9 +4,15 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
 
 // GatherInput allows you to define the type of input to gather from a caller.
 // The constant values can be bitwise-OR'ed together to support `dtmf speech`
@@ -36,15 +42,92 @@ func (g GatherInput) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (g *GatherInput) UnmarshalXMLAttr(attr xml.Attr) error {
+	return g.Set(attr.Value)
+}
+
 func (g GatherInput) String() string {
-	switch {
-	case g == GatherInputDTMFSpeech:
-		return "dtmf speech"
-	case g&GatherInputDTMF == GatherInputDTMF:
-		return "dtmf"
-	case g&GatherInputSpeech == GatherInputSpeech:
-		return "speech"
-	default:
+	if g == GatherInput(0) {
 		return ""
 	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	if g&GatherInputDTMF == GatherInputDTMF {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("dtmf")
+	}
+
+	if g&GatherInputSpeech == GatherInputSpeech {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("speech")
+	}
+
+	return buf.String()
+}
+
+// GatherInputValues returns each of the GatherInput flags, for listing the
+// valid choices in configuration or help text.
+func GatherInputValues() []GatherInput {
+	return []GatherInput{
+		GatherInputDTMF,
+		GatherInputSpeech,
+	}
+}
+
+// ParseGatherInput parses a space- or comma-separated list of GatherInput flags
+// (e.g., "dtmf speech"), compared case-insensitively, in to a GatherInput. This
+// function returns a wrapped error (see package documentation for more info).
+func ParseGatherInput(s string) (GatherInput, error) {
+	var parsed GatherInput
+
+	for _, word := range splitFlags(s) {
+		found := false
+
+		for _, v := range GatherInputValues() {
+			if strings.EqualFold(v.String(), word) {
+				parsed |= v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return GatherInput(0), unknownValue("GatherInput", s)
+		}
+	}
+
+	return parsed, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (g GatherInput) MarshalText() ([]byte, error) {
+	return []byte(g.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (g *GatherInput) UnmarshalText(text []byte) error {
+	return g.Set(string(text))
+}
+
+// Set implements the flag.Value interface. The parsed value replaces the
+// current one, rather than being combined with it.
+func (g *GatherInput) Set(value string) error {
+	parsed, err := ParseGatherInput(value)
+
+	if err != nil {
+		return err
+	}
+
+	*g = parsed
+
+	return nil
 }
@@ -0,0 +1,85 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import "github.com/theckman/twilio"
+
+// GatherResult is the input collected by a Gather, as sent to its Action URL.
+type GatherResult struct {
+	// Digits are the keys the caller pressed, without the key that ended
+	// the input.
+	Digits string
+
+	// SpeechResult is the transcription of what the caller said, and
+	// Confidence is between 0 and 1.
+	SpeechResult string
+	Confidence   float64
+
+	// FinishedOnKey is the key in the Gather's FinishOnKey that ended the
+	// input, which is zero if it ended another way, such as by timing out
+	// or reaching NumDigits.
+	FinishedOnKey FinishOnKey
+}
+
+// NewGatherResult returns the result of a Gather from the request made to its
+// Action URL, whose parameters were checked by twilio.ParseVoiceRequest.
+func NewGatherResult(req *twilio.VoiceRequest) *GatherResult {
+	g := &GatherResult{
+		Digits:       req.Digits,
+		SpeechResult: req.SpeechResult,
+		Confidence:   req.Confidence,
+	}
+
+	if len(req.FinishedOnKey) == 1 {
+		g.FinishedOnKey, _ = keyOf(rune(req.FinishedOnKey[0]))
+	}
+
+	return g
+}
+
+// Empty returns whether the caller neither pressed keys nor said anything,
+// which is only sent to the Action URL when the Gather has
+// ActionOnEmptyResult set.
+func (g *GatherResult) Empty() bool {
+	return g.Digits == "" && g.SpeechResult == ""
+}
+
+// Matches returns whether the caller pressed at least one key, and only the
+// keys in keys. For a menu gathered with a NumDigits of 1,
+//
+//	res.Matches(FinishKeyNumber1 | FinishKeyNumber2)
+//
+// is whether the caller chose option 1 or 2.
+func (g *GatherResult) Matches(keys FinishOnKey) bool {
+	if g.Digits == "" {
+		return false
+	}
+
+	for _, r := range g.Digits {
+		key, ok := keyOf(r)
+
+		if !ok || keys&key != key {
+			return false
+		}
+	}
+
+	return true
+}
+
+// keyOf returns the FinishOnKey flag of a key on a keypad.
+func keyOf(r rune) (FinishOnKey, bool) {
+	switch {
+	case r >= '0' && r <= '9':
+		return FinishKeyNumber0 << uint(r-'0'), true
+	case r == '*':
+		return FinishKeyStar, true
+	case r == '#':
+		return FinishKeyPound, true
+	default:
+		return FinishOnKey(0), false
+	}
+}
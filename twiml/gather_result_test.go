@@ -0,0 +1,101 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"testing"
+
+	"github.com/theckman/twilio"
+)
+
+// parseVoiceRequest parses a voice request with the form-encoded body.
+func parseVoiceRequest(t *testing.T, body string) *twilio.VoiceRequest {
+	req, err := twilio.ParseVoiceRequest(voiceRequest(body))
+
+	if err != nil {
+		t.Fatalf("ParseVoiceRequest(%q) unexpected error: %s", body, err)
+	}
+
+	return req
+}
+
+func TestNewGatherResult(t *testing.T) {
+	tests := []struct {
+		desc string
+		body string
+		want GatherResult
+	}{
+		{
+			desc: "digits",
+			body: "Digits=12&FinishedOnKey=%23",
+			want: GatherResult{Digits: "12", FinishedOnKey: FinishKeyPound},
+		},
+		{
+			desc: "speech",
+			body: "SpeechResult=billing&Confidence=0.9",
+			want: GatherResult{SpeechResult: "billing", Confidence: 0.9},
+		},
+		{
+			desc: "empty FinishedOnKey",
+			body: "Digits=1234&FinishedOnKey=",
+			want: GatherResult{Digits: "1234"},
+		},
+		{
+			desc: "FinishedOnKey of a number",
+			body: "Digits=5&FinishedOnKey=0",
+			want: GatherResult{Digits: "5", FinishedOnKey: FinishKeyNumber0},
+		},
+	}
+
+	for _, test := range tests {
+		if got := NewGatherResult(parseVoiceRequest(t, test.body)); *got != test.want {
+			t.Errorf("\nDescription: %s\nNewGatherResult() = %+v, want %+v", test.desc, got, test.want)
+		}
+	}
+}
+
+func TestGatherResult_Empty(t *testing.T) {
+	if !(&GatherResult{}).Empty() {
+		t.Error("Empty() of no input = false, want true")
+	}
+
+	if (&GatherResult{SpeechResult: "yes"}).Empty() {
+		t.Error("Empty() of speech = true, want false")
+	}
+
+	if (&GatherResult{Digits: "1"}).Empty() {
+		t.Error("Empty() of digits = true, want false")
+	}
+}
+
+func TestGatherResult_Matches(t *testing.T) {
+	menu := FinishKeyNumber1 | FinishKeyNumber2 | FinishKeyStar
+
+	tests := []struct {
+		desc   string
+		digits string
+		keys   FinishOnKey
+		want   bool
+	}{
+		{"one of the keys", "2", menu, true},
+		{"only the keys", "1*2", menu, true},
+		{"another key", "3", menu, false},
+		{"one of the keys and another", "13", menu, false},
+		{"no digits", "", menu, false},
+		{"number 0", "0", FinishKeyNumber0, true},
+		{"pound", "#", FinishKeyPound, true},
+		{"hangup", "hangup", FinishKeyAll, false},
+	}
+
+	for _, test := range tests {
+		g := &GatherResult{Digits: test.digits}
+
+		if got := g.Matches(test.keys); got != test.want {
+			t.Errorf("\nDescription: %s\nMatches(%q) with Digits %q = %t, want %t", test.desc, test.keys.String(), test.digits, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+)
+
+// ContentType is the Content-Type of the TwiML documents written by Handler.
+const ContentType = "text/xml; charset=utf-8"
+
+// HandlerFunc is a function that returns the TwiML response to a voice webhook
+// request. A nil *Response with a nil error is an empty response, which ends
+// the call.
+type HandlerFunc func(ctx context.Context, req *twilio.VoiceRequest) (*Response, error)
+
+// ServeHTTP calls fn, with the same behavior as a Handler without options.
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	NewHandler(fn).ServeHTTP(w, r)
+}
+
+// DefaultFallback returns the response a Handler writes when it can't write
+// the one it was meant to, which apologizes and hangs up.
+func DefaultFallback() *Response {
+	return &Response{Verbs: []interface{}{
+		&Say{Message: "We're sorry, an application error has occurred. Goodbye."},
+		&Hangup{},
+	}}
+}
+
+// HandlerOption configures optional behavior of a Handler.
+type HandlerOption func(*Handler)
+
+// WithFallback sets the response the Handler writes when it can't write the
+// one it was meant to, instead of DefaultFallback().
+func WithFallback(r *Response) HandlerOption {
+	return func(h *Handler) {
+		h.fallback = r
+	}
+}
+
+// WithErrorHook sets a function that's called with the error whenever the
+// Handler writes its fallback response, such as to log it.
+func WithErrorHook(fn func(r *http.Request, err error)) HandlerOption {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// WithEncodeOptions sets the options the Handler encodes responses with, such
+// as WithLimits. A response that fails their checks is replaced by the
+// fallback response.
+func WithEncodeOptions(opts ...EncodeOption) HandlerOption {
+	return func(h *Handler) {
+		h.encodeOpts = opts
+	}
+}
+
+// Handler is an http.Handler that parses voice webhook requests, calls a
+// HandlerFunc, and writes the TwiML response it returns. If the request can't
+// be parsed, or the HandlerFunc returns an error or panics, or the response
+// can't be encoded, it writes a fallback response with a 200 status instead,
+// because Twilio plays a generic error message to the caller for any other
+// status.
+type Handler struct {
+	fn         HandlerFunc
+	fallback   *Response
+	onError    func(*http.Request, error)
+	encodeOpts []EncodeOption
+}
+
+// NewHandler returns a *Handler that calls fn.
+func NewHandler(fn HandlerFunc, opts ...HandlerOption) *Handler {
+	h := &Handler{fn: fn}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.fallback == nil {
+		h.fallback = DefaultFallback()
+	}
+
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.respond(r)
+
+	if err != nil {
+		if h.onError != nil {
+			h.onError(r, err)
+		}
+
+		if doc, err = MarshalResponse(h.fallback); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc)
+}
+
+// respond returns the encoded response of the HandlerFunc.
+func (h *Handler) respond(r *http.Request) (doc []byte, err error) {
+	req, err := twilio.ParseVoiceRequest(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "handling voice request failed")
+	}
+
+	resp, err := h.call(r.Context(), req)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "handling voice request failed")
+	}
+
+	if resp == nil {
+		resp = &Response{}
+	}
+
+	if doc, err = MarshalResponse(resp, h.encodeOpts...); err != nil {
+		return nil, errors.Wrap(err, "handling voice request failed")
+	}
+
+	return doc, nil
+}
+
+// call calls the HandlerFunc, turning a panic in to an error.
+func (h *Handler) call(ctx context.Context, req *twilio.VoiceRequest) (resp *Response, err error) {
+	defer func() {
+		p := recover()
+
+		if p == nil {
+			return
+		}
+
+		// the http package uses this panic to abort a response on purpose
+		if p == http.ErrAbortHandler {
+			panic(p)
+		}
+
+		buf := make([]byte, 64<<10)
+		buf = buf[:runtime.Stack(buf, false)]
+
+		resp, err = nil, errors.Errorf("panic: %v\n%s", p, buf)
+	}()
+
+	return h.fn(ctx, req)
+}
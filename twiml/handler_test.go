@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+)
+
+const handlerCallSid = "CA0123456789abcdef0123456789abcdef"
+
+func voiceRequest(body string) *http.Request {
+	r := httptest.NewRequest("POST", "http://example.com/voice", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func mustMarshal(t *testing.T, r *Response) string {
+	doc, err := MarshalResponse(r)
+
+	if err != nil {
+		t.Fatalf("MarshalResponse() unexpected error: %s", err)
+	}
+
+	return string(doc)
+}
+
+func TestHandler(t *testing.T) {
+	hello := &Response{Verbs: []interface{}{&Say{Message: "Hello"}}}
+	custom := &Response{Verbs: []interface{}{&Redirect{URL: "/voicemail"}}}
+
+	tests := []struct {
+		desc string
+		fn   HandlerFunc
+		opts []HandlerOption
+		body string
+		want *Response
+		err  string
+	}{
+		{
+			desc: "response should be written",
+			fn: func(_ context.Context, req *twilio.VoiceRequest) (*Response, error) {
+				if req.CallSid != handlerCallSid {
+					return nil, errors.Errorf("CallSid = %q", req.CallSid)
+				}
+
+				return hello, nil
+			},
+			body: "CallSid=" + handlerCallSid,
+			want: hello,
+		},
+		{
+			desc: "nil response should be empty",
+			fn:   func(context.Context, *twilio.VoiceRequest) (*Response, error) { return nil, nil },
+			want: &Response{},
+		},
+		{
+			desc: "error should write the fallback",
+			fn: func(context.Context, *twilio.VoiceRequest) (*Response, error) {
+				return nil, errors.New("database is down")
+			},
+			want: DefaultFallback(),
+			err:  "handling voice request failed: database is down",
+		},
+		{
+			desc: "panic should write the fallback",
+			fn:   func(context.Context, *twilio.VoiceRequest) (*Response, error) { panic("boom") },
+			want: DefaultFallback(),
+			err:  "handling voice request failed: panic: boom",
+		},
+		{
+			desc: "malformed request should write the fallback",
+			fn:   func(context.Context, *twilio.VoiceRequest) (*Response, error) { return hello, nil },
+			body: "CallStatus=nope",
+			want: DefaultFallback(),
+			err:  `handling voice request failed: parsing voice request failed: parameter CallStatus "nope"`,
+		},
+		{
+			desc: "custom fallback should be written",
+			fn: func(context.Context, *twilio.VoiceRequest) (*Response, error) {
+				return nil, errors.New("oops")
+			},
+			opts: []HandlerOption{WithFallback(custom)},
+			want: custom,
+			err:  "handling voice request failed: oops",
+		},
+		{
+			desc: "response failing encode options should write the fallback",
+			fn:   func(context.Context, *twilio.VoiceRequest) (*Response, error) { return hello, nil },
+			opts: []HandlerOption{WithEncodeOptions(WithLimits(Limits{MaxResponseSize: 10}))},
+			want: DefaultFallback(),
+			err:  "handling voice request failed: encoding response failed",
+		},
+	}
+
+	for _, test := range tests {
+		var hookErr error
+
+		opts := append(test.opts, WithErrorHook(func(_ *http.Request, err error) { hookErr = err }))
+
+		w := httptest.NewRecorder()
+		NewHandler(test.fn, opts...).ServeHTTP(w, voiceRequest(test.body))
+
+		if w.Code != http.StatusOK {
+			t.Errorf("\nDescription: %s\nstatus = %d, want %d", test.desc, w.Code, http.StatusOK)
+		}
+
+		if ct := w.Header().Get("Content-Type"); ct != ContentType {
+			t.Errorf("\nDescription: %s\nContent-Type = %q, want %q", test.desc, ct, ContentType)
+		}
+
+		if want := mustMarshal(t, test.want); w.Body.String() != want {
+			t.Errorf("\nDescription: %s\nbody =\n%s\nwant\n%s", test.desc, w.Body.String(), want)
+		}
+
+		switch {
+		case test.err == "" && hookErr != nil:
+			t.Errorf("\nDescription: %s\nunexpected error: %s", test.desc, hookErr)
+		case test.err != "" && (hookErr == nil || !strings.HasPrefix(hookErr.Error(), test.err)):
+			t.Errorf("\nDescription: %s\nerror = %v, want prefix %q", test.desc, hookErr, test.err)
+		}
+	}
+}
+
+func TestHandlerFunc_ServeHTTP(t *testing.T) {
+	type key struct{}
+
+	fn := HandlerFunc(func(ctx context.Context, _ *twilio.VoiceRequest) (*Response, error) {
+		return &Response{Verbs: []interface{}{&Say{Message: ctx.Value(key{}).(string)}}}, nil
+	})
+
+	r := voiceRequest("")
+	r = r.WithContext(context.WithValue(r.Context(), key{}, "from the context"))
+
+	w := httptest.NewRecorder()
+	fn.ServeHTTP(w, r)
+
+	if want := mustMarshal(t, &Response{Verbs: []interface{}{&Say{Message: "from the context"}}}); w.Body.String() != want {
+		t.Errorf("body =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+}
@@ -0,0 +1,273 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Command twimlgen generates the verb, noun, and enum types of the twiml
+// package from the checked-in schema.json. It's run by go generate from the
+// twiml package directory:
+//
+//	go generate github.com/theckman/twilio/twiml
+//
+// To support a new verb, noun, attribute, or enum value, edit schema.json and
+// regenerate. The schema is checked for consistency (e.g., that fields only
+// use known types, and that allowed children exist) before anything is
+// written.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	childrenFile = "children_gen.go"
+	validateFile = "validate_gen.go"
+	testFile     = "schema_gen_test.go"
+	xsdFile      = "xsd_gen.go"
+)
+
+var funcs = template.FuncMap{
+	"comment":   comment,
+	"wrap":      wrap,
+	"orJoin":    orJoin,
+	"quoteJoin": quoteJoin,
+	"example":   example,
+}
+
+func main() {
+	schemaPath := flag.String("schema", "schema.json", "path to the TwiML schema")
+	dir := flag.String("dir", ".", "directory to write the generated files to")
+	flag.Parse()
+
+	s, err := loadSchema(*schemaPath)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "twimlgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	files, err := generate(s)
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "twimlgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, name := range sortedNames(files) {
+		path := filepath.Join(*dir, name)
+
+		// leave unchanged files alone, so their modification time doesn't
+		// trigger needless rebuilds
+		if old, err := ioutil.ReadFile(path); err == nil && bytes.Equal(old, files[name]) {
+			continue
+		}
+
+		if err := ioutil.WriteFile(path, files[name], 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "twimlgen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate renders all of the files described by the schema, keyed by file
+// name.
+func generate(s *schema) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	render := func(name, text string, data interface{}) error {
+		if _, ok := files[name]; ok {
+			return fmt.Errorf("%s is generated more than once", name)
+		}
+
+		b, err := execute(name, text, data)
+
+		if err != nil {
+			return err
+		}
+
+		files[name] = b
+
+		return nil
+	}
+
+	for _, e := range s.Enums {
+		data := struct {
+			Package string
+			Enum    *enum
+		}{s.Package, e}
+
+		if err := render(e.File, enumTemplate, data); err != nil {
+			return nil, err
+		}
+	}
+
+	byFile := make(map[string][]*element)
+
+	for _, e := range s.Elements {
+		byFile[e.File] = append(byFile[e.File], e)
+	}
+
+	for file, elements := range byFile {
+		data := struct {
+			Package  string
+			Elements []*element
+		}{s.Package, elements}
+
+		if err := render(file, elementsTemplate, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := render(childrenFile, childrenTemplate, s); err != nil {
+		return nil, err
+	}
+
+	if err := render(validateFile, validateTemplate, s); err != nil {
+		return nil, err
+	}
+
+	if err := render(testFile, testTemplate, s); err != nil {
+		return nil, err
+	}
+
+	if len(s.XSD) > 0 {
+		if err := render(xsdFile, xsdTemplate, s); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+func execute(name, text string, data interface{}) ([]byte, error) {
+	t, err := template.New(name).Funcs(funcs).Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing template for %s: %v", name, err)
+	}
+
+	buf := bytes.NewBufferString(header)
+
+	if err := t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("rendering %s: %v", name, err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %v\n%s", name, err, buf.Bytes())
+	}
+
+	return src, nil
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// comment renders text from the schema as a comment, keeping its line breaks.
+func comment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	for i, line := range lines {
+		if line = strings.TrimRight(line, " "); line == "" {
+			lines[i] = "//"
+		} else {
+			lines[i] = "// " + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// wrap renders a single paragraph as a comment, wrapped at 80 columns.
+func wrap(text string) string {
+	var lines []string
+	var line string
+
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > 77 {
+			lines = append(lines, "// "+line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+
+		line += word
+	}
+
+	if line != "" {
+		lines = append(lines, "// "+line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// orJoin renders the bitwise-OR of the values of a combination, wrapped at 80
+// columns like the hand-written constants were.
+func orJoin(c *combination) string {
+	var buf bytes.Buffer
+
+	width := len("const  = ") + len(c.Name)
+
+	for i, name := range c.Of {
+		if i > 0 {
+			if width+len(" | ")+len(name) > 80 {
+				buf.WriteString(" |\n\t")
+				width = 4
+			} else {
+				buf.WriteString(" | ")
+				width += 3
+			}
+		}
+
+		buf.WriteString(name)
+		width += len(name)
+	}
+
+	return buf.String()
+}
+
+func quoteJoin(s []string) string {
+	quoted := make([]string, len(s))
+
+	for i, v := range s {
+		quoted[i] = strconv.Quote(v)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// example returns an example value of a flags enum, for documentation.
+func example(e *enum) string {
+	var words []string
+
+	for _, v := range e.Listed() {
+		if words = append(words, v.XML); len(words) == 2 {
+			break
+		}
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,113 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pkgDir = "../.."
+
+func TestGenerate_UpToDate(t *testing.T) {
+	s, err := loadSchema(filepath.Join(pkgDir, "schema.json"))
+
+	if err != nil {
+		t.Fatalf("loadSchema() Unexpected Error: %s", err)
+	}
+
+	files, err := generate(s)
+
+	if err != nil {
+		t.Fatalf("generate() Unexpected Error: %s", err)
+	}
+
+	for _, name := range sortedNames(files) {
+		b, err := ioutil.ReadFile(filepath.Join(pkgDir, name))
+
+		if err != nil {
+			t.Errorf("reading %s failed: %s", name, err)
+			continue
+		}
+
+		if !bytes.Equal(b, files[name]) {
+			t.Errorf("%s is out of date with schema.json; run go generate", name)
+		}
+	}
+}
+
+func TestSchema_check(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *schema
+		err  string
+	}{
+		{
+			desc: "field with unknown type should fail",
+			in: &schema{Elements: []*element{
+				{Name: "Say", Element: "Say", Kind: "verb", Fields: []*field{{Name: "Voice", Type: "Voice"}}},
+			}},
+			err: "unknown type Voice",
+		},
+		{
+			desc: "unknown child element should fail",
+			in: &schema{Elements: []*element{
+				{Name: "Gather", Element: "Gather", Kind: "verb", Children: &children{Field: "NestedVerbs", Allowed: []string{"Say"}}},
+			}},
+			err: "unknown child element Say",
+		},
+		{
+			desc: "duplicate names should fail",
+			in: &schema{
+				Enums: []*enum{{Name: "Say", File: "say.go", Type: "uint8", Kind: "single", Receiver: "s"}},
+				Elements: []*element{
+					{Name: "Say", Element: "Say", Kind: "verb"},
+				},
+			},
+			err: "Say is defined more than once",
+		},
+		{
+			desc: "combination of unknown values should fail",
+			in: &schema{Enums: []*enum{{
+				Name: "Flags", File: "flags.go", Type: "uint8", Kind: "flags", Receiver: "f",
+				Values:       []*enumValue{{Name: "FlagA", XML: "a"}},
+				Combinations: []*combination{{Name: "FlagAll", Of: []string{"FlagA", "FlagB"}}},
+			}}},
+			err: "unknown value FlagB",
+		},
+		{
+			desc: "XSD without a file should fail",
+			in:   &schema{XSD: []*bundledXSD{{Name: "voiceXSD"}}},
+			err:  "xsd voiceXSD: missing file",
+		},
+	}
+
+	for _, test := range tests {
+		err := test.in.check()
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("\nDescription: %s\ncheck() error = %v; want error containing %q", test.desc, err, test.err)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	out := wrap(strings.Repeat("word ", 40))
+
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) > 80 {
+			t.Errorf("wrap() line is %d characters long: %q", len(line), line)
+		}
+
+		if !strings.HasPrefix(line, "// ") {
+			t.Errorf("wrap() line is missing comment prefix: %q", line)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// schema is the description of TwiML the twiml package is generated from.
+type schema struct {
+	// Package is the name of the generated package.
+	Package string `json:"package"`
+
+	// Types are the hand-written types, besides the enums, that fields may
+	// use.
+	Types []string `json:"types"`
+
+	Enums    []*enum    `json:"enums"`
+	Elements []*element `json:"elements"`
+
+	// XSD are the XML Schema documents bundled in to the package.
+	XSD []*bundledXSD `json:"xsd"`
+}
+
+// bundledXSD is an XML Schema document, bundled in to the package as a string
+// constant.
+type bundledXSD struct {
+	// Name is the name of the constant.
+	Name string `json:"name"`
+
+	// File is the path of the document, relative to schema.json.
+	File string `json:"file"`
+	Doc  string `json:"doc"`
+
+	// Content is the document itself, read by loadSchema.
+	Content string `json:"-"`
+}
+
+// enum is a uint type whose constants render to a fixed set of TwiML
+// attribute values.
+type enum struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Doc  string `json:"doc"`
+	Type string `json:"type"`
+
+	// Kind is either "single", for types holding one value, or "flags" for
+	// bit-flag types rendering a space separated list.
+	Kind string `json:"kind"`
+
+	// Receiver is the name of the method receiver.
+	Receiver string `json:"receiver"`
+
+	// Iota is whether the constants start at zero, instead of one, with the
+	// first constant usually rendering the empty string.
+	Iota bool `json:"iota,omitempty"`
+
+	// Unknown is the value rendered for values without a constant.
+	Unknown string `json:"unknown,omitempty"`
+
+	// Bool is whether the type is a TwiML boolean that defaults to true, and
+	// needs a Bool() method.
+	Bool bool `json:"bool,omitempty"`
+
+	Values       []*enumValue   `json:"values"`
+	Combinations []*combination `json:"combinations,omitempty"`
+}
+
+func (e *enum) Flags() bool {
+	return e.Kind == "flags"
+}
+
+// Listed returns the values that render to a non-empty string.
+func (e *enum) Listed() []*enumValue {
+	var out []*enumValue
+
+	for _, v := range e.Values {
+		if v.XML != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+type enumValue struct {
+	Name    string `json:"name"`
+	Doc     string `json:"doc"`
+	XML     string `json:"xml"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// combination is a constant that's a bitwise-OR of the values of a flags
+// enum.
+type combination struct {
+	Name string   `json:"name"`
+	Doc  string   `json:"doc"`
+	Of   []string `json:"of"`
+}
+
+// element is a verb or noun, rendered as a struct.
+type element struct {
+	Name    string `json:"name"`
+	Element string `json:"element"`
+	File    string `json:"file"`
+
+	// Kind is either "verb" or "noun". Verbs are allowed within a Response.
+	Kind string `json:"kind"`
+	Doc  string `json:"doc"`
+
+	// Children describes the slice field containing nested elements, if the
+	// element can contain any.
+	Children *children `json:"children,omitempty"`
+	Fields   []*field  `json:"fields"`
+}
+
+type field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	XML  string `json:"xml"`
+	Doc  string `json:"doc,omitempty"`
+
+	// Break is whether a blank line is rendered before the field.
+	Break bool `json:"break,omitempty"`
+}
+
+type children struct {
+	Field   string   `json:"field"`
+	Doc     string   `json:"doc"`
+	Allowed []string `json:"allowed"`
+}
+
+var builtinTypes = map[string]bool{
+	"bool":   true,
+	"string": true,
+	"uint":   true,
+	"uint8":  true,
+	"uint16": true,
+}
+
+func loadSchema(path string) (*schema, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var s schema
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("decoding %s: %v", path, err)
+	}
+
+	if err := s.check(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	for _, x := range s.XSD {
+		b, err := ioutil.ReadFile(filepath.Join(filepath.Dir(path), x.File))
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the document is rendered as a raw string literal
+		if strings.Contains(string(b), "`") {
+			return nil, fmt.Errorf("%s: XSD documents must not contain backquotes", x.File)
+		}
+
+		x.Content = string(b)
+	}
+
+	return &s, nil
+}
+
+// check makes sure the schema is consistent, so that mistakes are caught
+// before generating code that doesn't compile or validates incorrectly.
+func (s *schema) check() error {
+	types := make(map[string]bool)
+	elements := make(map[string]bool)
+	names := make(map[string]bool)
+
+	for _, t := range s.Types {
+		types[t] = true
+	}
+
+	unique := func(name string) error {
+		if names[name] {
+			return fmt.Errorf("%s is defined more than once", name)
+		}
+
+		names[name] = true
+
+		return nil
+	}
+
+	for _, e := range s.Enums {
+		if err := unique(e.Name); err != nil {
+			return err
+		}
+
+		if e.Kind != "single" && e.Kind != "flags" {
+			return fmt.Errorf("enum %s: unknown kind %q", e.Name, e.Kind)
+		}
+
+		if e.Receiver == "" || e.File == "" || !builtinTypes[e.Type] {
+			return fmt.Errorf("enum %s: missing receiver, file, or uint type", e.Name)
+		}
+
+		values := make(map[string]bool)
+
+		for _, v := range e.Values {
+			if err := unique(v.Name); err != nil {
+				return err
+			}
+
+			values[v.Name] = true
+		}
+
+		for _, c := range e.Combinations {
+			if err := unique(c.Name); err != nil {
+				return err
+			}
+
+			for _, of := range c.Of {
+				if !values[of] {
+					return fmt.Errorf("enum %s: combination %s refers to unknown value %s", e.Name, c.Name, of)
+				}
+			}
+		}
+
+		types[e.Name] = true
+	}
+
+	for _, e := range s.Elements {
+		if err := unique(e.Name); err != nil {
+			return err
+		}
+
+		if e.Kind != "verb" && e.Kind != "noun" {
+			return fmt.Errorf("element %s: unknown kind %q", e.Name, e.Kind)
+		}
+
+		elements[e.Element] = true
+	}
+
+	for _, x := range s.XSD {
+		if err := unique(x.Name); err != nil {
+			return err
+		}
+
+		if x.File == "" {
+			return fmt.Errorf("xsd %s: missing file", x.Name)
+		}
+	}
+
+	for _, e := range s.Elements {
+		for _, f := range e.Fields {
+			if !builtinTypes[f.Type] && !types[f.Type] {
+				return fmt.Errorf("element %s: field %s has unknown type %s", e.Name, f.Name, f.Type)
+			}
+		}
+
+		if e.Children == nil {
+			continue
+		}
+
+		for _, a := range e.Children.Allowed {
+			if !elements[a] {
+				return fmt.Errorf("element %s: unknown child element %s", e.Name, a)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Verbs returns the XML element names of the verbs, which are the elements
+// allowed within a Response.
+func (s *schema) Verbs() []string {
+	var out []string
+
+	for _, e := range s.Elements {
+		if e.Kind == "verb" {
+			out = append(out, e.Element)
+		}
+	}
+
+	return out
+}
+
+// Containers returns the elements that can contain other elements.
+func (s *schema) Containers() []*element {
+	var out []*element
+
+	for _, e := range s.Elements {
+		if e.Children != nil {
+			out = append(out, e)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,353 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package main
+
+const header = `// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
+`
+
+const enumTemplate = `package {{.Package}}
+
+import (
+{{- if .Enum.Flags}}
+	"bytes"
+{{- end}}
+	"encoding/xml"
+	"strings"
+)
+
+{{with .Enum -}}
+{{comment .Doc}}
+type {{.Name}} {{.Type}}
+
+const (
+{{- range $i, $v := .Values}}
+{{- if $i}}
+{{end}}
+{{comment $v.Doc}}
+	{{$v.Name}}{{if eq $i 0}} {{$.Enum.Name}} = {{if $.Enum.Iota}}iota{{else}}1 << iota{{end}}{{end}}{{with $v.Comment}} // {{.}}{{end}}
+{{- end}}
+)
+{{range .Combinations}}
+{{comment .Doc}}
+const {{.Name}} = {{orJoin .}}
+{{end}}
+// MarshalXMLAttr implements the xml.MarshalerAttr interface.
+func ({{.Receiver}} {{.Name}}) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	attr := xml.Attr{
+		Name:  name,
+		Value: {{.Receiver}}.String(),
+	}
+
+	return attr, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func ({{.Receiver}} *{{.Name}}) UnmarshalXMLAttr(attr xml.Attr) error {
+	return {{.Receiver}}.Set(attr.Value)
+}
+{{if .Bool}}
+{{wrap (printf "Bool returns the boolean representation of the %s value. If the value is not explicitly false, it's assumed true (to match Twilio's default)." .Name)}}
+func ({{.Receiver}} {{.Name}}) Bool() bool {
+	if {{.Receiver}} == {{(index .Values 1).Name}} {
+		return false
+	}
+
+	return true
+}
+{{end}}
+{{- if .Flags}}
+func ({{.Receiver}} {{.Name}}) String() string {
+	if {{.Receiver}} == {{.Name}}(0) {
+		return ""
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+{{range .Values}}
+	if {{$.Enum.Receiver}}&{{.Name}} == {{.Name}} {
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString({{printf "%q" .XML}})
+	}
+{{end}}
+	return buf.String()
+}
+
+{{wrap (printf "%sValues returns each of the %s flags, for listing the valid choices in configuration or help text." .Name .Name)}}
+{{- else}}
+func ({{.Receiver}} {{.Name}}) String() string {
+	switch {{.Receiver}} {
+{{- range .Values}}
+	case {{.Name}}:
+		return {{printf "%q" .XML}}
+{{- end}}
+	default:
+		return {{printf "%q" .Unknown}}
+	}
+}
+
+{{wrap (printf "%sValues returns all of the %s constants that render a value, for listing the valid choices in configuration or help text." .Name .Name)}}
+{{- end}}
+func {{.Name}}Values() []{{.Name}} {
+	return []{{.Name}}{
+{{- range .Listed}}
+		{{.Name}},
+{{- end}}
+	}
+}
+{{if .Flags}}
+{{wrap (printf "Parse%s parses a space- or comma-separated list of %s flags (e.g., %q), compared case-insensitively, in to a %s. This function returns a wrapped error (see package documentation for more info)." .Name .Name (example .) .Name)}}
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+	var parsed {{.Name}}
+
+	for _, word := range splitFlags(s) {
+		found := false
+
+		for _, v := range {{.Name}}Values() {
+			if strings.EqualFold(v.String(), word) {
+				parsed |= v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return {{.Name}}(0), unknownValue({{printf "%q" .Name}}, s)
+		}
+	}
+
+	return parsed, nil
+}
+{{- else}}
+{{wrap (printf "Parse%s returns the %s whose TwiML value is s, compared case-insensitively. This function returns a wrapped error (see package documentation for more info)." .Name .Name)}}
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+	if s == "" {
+		return {{.Name}}(0), nil
+	}
+
+	for _, v := range {{.Name}}Values() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return {{.Name}}(0), unknownValue({{printf "%q" .Name}}, s)
+}
+{{- end}}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func ({{.Receiver}} {{.Name}}) MarshalText() ([]byte, error) {
+	return []byte({{.Receiver}}.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func ({{.Receiver}} *{{.Name}}) UnmarshalText(text []byte) error {
+	return {{.Receiver}}.Set(string(text))
+}
+
+{{if .Flags -}}
+// Set implements the flag.Value interface. The parsed value replaces the
+// current one, rather than being combined with it.
+{{- else -}}
+// Set implements the flag.Value interface.
+{{- end}}
+func ({{.Receiver}} *{{.Name}}) Set(value string) error {
+	parsed, err := Parse{{.Name}}(value)
+
+	if err != nil {
+		return err
+	}
+
+	*{{.Receiver}} = parsed
+
+	return nil
+}
+{{- end}}
+`
+
+const elementsTemplate = `package {{.Package}}
+
+import "encoding/xml"
+{{range .Elements}}
+{{comment .Doc}}
+type {{.Name}} struct {
+	XMLName xml.Name ` + "`" + `xml:"{{.Element}}"` + "`" + `
+{{- range .Fields}}
+{{- if .Break}}
+{{end}}
+{{- with .Doc}}
+{{comment .}}
+{{- end}}
+	{{.Name}} {{.Type}} ` + "`" + `xml:"{{.XML}}"` + "`" + `
+{{- end}}
+{{- with .Children}}
+
+{{comment .Doc}}
+	{{.Field}} []interface{}
+{{- end}}
+}
+{{end}}`
+
+const childrenTemplate = `package {{.Package}}
+
+// children returns the nested verbs or nouns of node, if it has any.
+func children(node interface{}) []interface{} {
+	switch n := node.(type) {
+{{- range .Containers}}
+	case *{{.Name}}:
+		if n != nil {
+			return n.{{.Children.Field}}
+		}
+	case {{.Name}}:
+		return n.{{.Children.Field}}
+{{- end}}
+	}
+
+	return nil
+}
+
+// setChildren replaces the nested verbs or nouns of node, returning the
+// updated node. Pointers are updated in place.
+func setChildren(node interface{}, kids []interface{}) interface{} {
+	switch n := node.(type) {
+{{- range .Containers}}
+	case *{{.Name}}:
+		if n != nil {
+			n.{{.Children.Field}} = kids
+		}
+	case {{.Name}}:
+		n.{{.Children.Field}} = kids
+		return n
+{{- end}}
+	}
+
+	return node
+}
+
+// cloneContainer returns a deep copy of node if it's able to contain nested
+// verbs or nouns. The bool is false for all other values.
+func cloneContainer(node interface{}) (interface{}, bool) {
+	switch n := node.(type) {
+{{- range .Containers}}
+	case *{{.Name}}:
+		if n == nil {
+			return n, true
+		}
+
+		c := *n
+		c.{{.Children.Field}} = cloneSlice(n.{{.Children.Field}})
+		return &c, true
+	case {{.Name}}:
+		n.{{.Children.Field}} = cloneSlice(n.{{.Children.Field}})
+		return n, true
+{{- end}}
+	}
+
+	return node, false
+}
+`
+
+const validateTemplate = `package {{.Package}}
+
+// allowedChildren is the XML element names of the verbs and nouns each element
+// may contain, keyed by the XML element name of the parent. Elements that
+// can't contain any are not listed.
+var allowedChildren = map[string][]string{
+	"Response": { {{- quoteJoin .Verbs -}} },
+{{- range .Containers}}
+	{{printf "%q" .Element}}: { {{- quoteJoin .Children.Allowed -}} },
+{{- end}}
+}
+`
+
+const testTemplate = `package {{.Package}}
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+{{range .Enums}}
+func Test{{.Name}}_UnmarshalXMLAttr(t *testing.T) {
+	for _, v := range {{.Name}}Values() {
+		attr, err := v.MarshalXMLAttr(xml.Name{Local: "attr"})
+
+		if err != nil {
+			t.Fatalf("{{.Name}}(%d).MarshalXMLAttr() Unexpected Error: %s", v, err)
+		}
+
+		var got {{.Name}}
+
+		if err := got.UnmarshalXMLAttr(attr); err != nil {
+			t.Fatalf("UnmarshalXMLAttr(%q) Unexpected Error: %s", attr.Value, err)
+		}
+
+		if got != v {
+			t.Errorf("UnmarshalXMLAttr(%q) = %d; want %d", attr.Value, got, v)
+		}
+	}
+
+	var got {{.Name}}
+
+	if err := got.UnmarshalXMLAttr(xml.Attr{Value: "not-a-{{.Name}}"}); err == nil {
+		t.Error("UnmarshalXMLAttr() of an unknown value should fail")
+	}
+}
+{{end}}
+func TestElements_XMLName(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   interface{}
+		out  string
+	}{
+{{- range .Elements}}
+		{ {{- printf "%q" (printf "%s should render as %s" .Name .Element)}}, &{{.Name}}{}, {{printf "%q" .Element -}} },
+{{- end}}
+	}
+
+	for _, test := range tests {
+		b, err := xml.Marshal(test.in)
+
+		if err != nil {
+			t.Fatalf("\nDescription: %s\nxml.Marshal() Unexpected Error: %s", test.desc, err)
+		}
+
+		if !strings.HasPrefix(string(b), "<"+test.out) {
+			t.Errorf(
+				"\nDescription: %s\nxml.Marshal(%T) = %s; want <%s> element",
+				test.desc, test.in, b, test.out,
+			)
+		}
+
+		if name := NodeName(test.in); name != test.out {
+			t.Errorf("\nDescription: %s\nNodeName(%T) = %q; want %q", test.desc, test.in, name, test.out)
+		}
+	}
+}
+`
+
+const xsdTemplate = `package {{.Package}}
+
+const (
+{{- range $i, $x := .XSD}}
+{{- if $i}}
+{{end}}
+{{comment $x.Doc}}
+	{{$x.Name}} = ` + "`{{$x.Content}}`" + `
+{{- end}}
+)
+`
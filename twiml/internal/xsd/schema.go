@@ -0,0 +1,628 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package xsd validates XML documents against an XML Schema (XSD), in pure Go.
+// Only the subset of XML Schema used by the TwiML schemas bundled with the
+// twiml package is supported: documents without namespaces; global and local
+// elements; sequence and choice groups with occurrence bounds; attributes;
+// simple and mixed content; and simple types restricted by enumeration,
+// pattern, length, or inclusive range, or built as lists and unions. Schemas
+// using anything else fail to compile, rather than validating incorrectly.
+package xsd
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// unbounded is the maxOccurs of a particle that may repeat any number of
+// times.
+const unbounded = -1
+
+// Schema is a compiled XML Schema.
+type Schema struct {
+	elements map[string]*element
+}
+
+// element is an element declaration. Exactly one of complex or simple is set.
+type element struct {
+	name    string
+	complex *complexType
+	simple  *simpleType
+}
+
+type complexType struct {
+	mixed    bool
+	attrs    []*attribute
+	anyAttr  bool
+	content  *particle   // nil for empty content
+	text     *simpleType // set for simple content
+	elements map[string]*element
+}
+
+func (ct *complexType) attribute(name string) *attribute {
+	for _, a := range ct.attrs {
+		if a.name == name {
+			return a
+		}
+	}
+
+	return nil
+}
+
+type attribute struct {
+	name     string
+	typ      *simpleType
+	required bool
+}
+
+type particleKind uint8
+
+const (
+	particleElement particleKind = iota
+	particleSequence
+	particleChoice
+)
+
+// particle is an element or group within the content model of a complex
+// type.
+type particle struct {
+	kind     particleKind
+	min, max int
+	elem     *element
+	items    []*particle
+}
+
+// compiler holds the state used while compiling a schema document. Named
+// types are compiled on first use, so they can be declared in any order.
+type compiler struct {
+	complexNodes map[string]*node
+	simpleNodes  map[string]*node
+	elementNodes map[string]*node
+
+	complexTypes map[string]*complexType
+	simpleTypes  map[string]*simpleType
+	elements     map[string]*element
+}
+
+// Compile parses and compiles an XML Schema document. This function returns a
+// wrapped error (see the twiml package documentation for more info).
+func Compile(doc []byte) (*Schema, error) {
+	root, err := parseTree(doc)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling schema failed")
+	}
+
+	if root.name != "schema" {
+		return nil, errors.Errorf("compiling schema failed: root element is %s, not schema", root.name)
+	}
+
+	c := &compiler{
+		complexNodes: make(map[string]*node),
+		simpleNodes:  make(map[string]*node),
+		elementNodes: make(map[string]*node),
+		complexTypes: make(map[string]*complexType),
+		simpleTypes:  make(map[string]*simpleType),
+		elements:     make(map[string]*element),
+	}
+
+	for _, n := range root.kids {
+		name, _ := n.attr("name")
+
+		switch n.name {
+		case "complexType":
+			c.complexNodes[name] = n
+		case "simpleType":
+			c.simpleNodes[name] = n
+		case "element":
+			c.elementNodes[name] = n
+		default:
+			return nil, unsupported(n)
+		}
+	}
+
+	s := &Schema{elements: make(map[string]*element)}
+
+	for name := range c.elementNodes {
+		e, err := c.globalElement(name)
+
+		if err != nil {
+			return nil, errors.Wrap(err, "compiling schema failed")
+		}
+
+		s.elements[name] = e
+	}
+
+	// compile the named types not used by any element, so mistakes in them
+	// are reported too
+	for name := range c.complexNodes {
+		if _, err := c.namedComplex(name); err != nil {
+			return nil, errors.Wrap(err, "compiling schema failed")
+		}
+	}
+
+	for name := range c.simpleNodes {
+		if _, err := c.namedSimple(name); err != nil {
+			return nil, errors.Wrap(err, "compiling schema failed")
+		}
+	}
+
+	return s, nil
+}
+
+func unsupported(n *node) error {
+	return errors.Errorf("line %d: unsupported schema construct %s", n.line, n.name)
+}
+
+// localName strips the namespace prefix from a QName, such as "xs:string".
+func localName(qname string) string {
+	if i := strings.IndexByte(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+
+	return qname
+}
+
+func (c *compiler) globalElement(name string) (*element, error) {
+	if e, ok := c.elements[name]; ok {
+		return e, nil
+	}
+
+	n, ok := c.elementNodes[name]
+
+	if !ok {
+		return nil, errors.Errorf("reference to undeclared element %s", name)
+	}
+
+	e := &element{name: name}
+
+	// registered before compiling, so recursive references resolve
+	c.elements[name] = e
+
+	if err := c.fillElement(e, n); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *compiler) localElement(n *node) (*element, error) {
+	name, ok := n.attr("name")
+
+	if !ok {
+		return nil, errors.Errorf("line %d: element without a name or ref", n.line)
+	}
+
+	e := &element{name: name}
+
+	if err := c.fillElement(e, n); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (c *compiler) fillElement(e *element, n *node) error {
+	if typ, ok := n.attr("type"); ok {
+		typ = localName(typ)
+
+		if _, ok := c.complexNodes[typ]; ok {
+			ct, err := c.namedComplex(typ)
+			e.complex = ct
+			return err
+		}
+
+		st, err := c.namedSimple(typ)
+		e.simple = st
+		return err
+	}
+
+	for _, k := range n.kids {
+		var err error
+
+		switch k.name {
+		case "complexType":
+			e.complex, err = c.complex(k)
+		case "simpleType":
+			e.simple, err = c.simple(k)
+		case "annotation":
+			continue
+		default:
+			return unsupported(k)
+		}
+
+		return err
+	}
+
+	// an element without a type is xs:anyType; only text is supported
+	e.simple = builtin("string")
+
+	return nil
+}
+
+func (c *compiler) namedComplex(name string) (*complexType, error) {
+	if ct, ok := c.complexTypes[name]; ok {
+		return ct, nil
+	}
+
+	n, ok := c.complexNodes[name]
+
+	if !ok {
+		return nil, errors.Errorf("reference to undeclared complex type %s", name)
+	}
+
+	ct := &complexType{}
+	c.complexTypes[name] = ct
+
+	return ct, c.fillComplex(ct, n)
+}
+
+func (c *compiler) complex(n *node) (*complexType, error) {
+	ct := &complexType{}
+	return ct, c.fillComplex(ct, n)
+}
+
+func (c *compiler) fillComplex(ct *complexType, n *node) error {
+	ct.mixed = n.attrBool("mixed")
+	ct.elements = make(map[string]*element)
+
+	for _, k := range n.kids {
+		switch k.name {
+		case "sequence", "choice":
+			if ct.content != nil {
+				return errors.Errorf("line %d: more than one content model", k.line)
+			}
+
+			p, err := c.particle(k, ct)
+
+			if err != nil {
+				return err
+			}
+
+			ct.content = p
+		case "attribute":
+			a, err := c.attribute(k)
+
+			if err != nil {
+				return err
+			}
+
+			ct.attrs = append(ct.attrs, a)
+		case "anyAttribute":
+			ct.anyAttr = true
+		case "simpleContent":
+			if err := c.simpleContent(ct, k); err != nil {
+				return err
+			}
+		case "annotation":
+			continue
+		default:
+			return unsupported(k)
+		}
+	}
+
+	return nil
+}
+
+func (c *compiler) simpleContent(ct *complexType, n *node) error {
+	if len(n.kids) != 1 || n.kids[0].name != "extension" {
+		return errors.Errorf("line %d: simpleContent must contain a single extension", n.line)
+	}
+
+	ext := n.kids[0]
+	base, _ := ext.attr("base")
+
+	st, err := c.namedSimple(localName(base))
+
+	if err != nil {
+		return err
+	}
+
+	ct.text = st
+
+	for _, k := range ext.kids {
+		switch k.name {
+		case "attribute":
+			a, err := c.attribute(k)
+
+			if err != nil {
+				return err
+			}
+
+			ct.attrs = append(ct.attrs, a)
+		case "anyAttribute":
+			ct.anyAttr = true
+		default:
+			return unsupported(k)
+		}
+	}
+
+	return nil
+}
+
+func (c *compiler) particle(n *node, ct *complexType) (*particle, error) {
+	p := &particle{min: 1, max: 1}
+
+	if v, ok := n.attr("minOccurs"); ok {
+		min, err := strconv.Atoi(v)
+
+		if err != nil || min < 0 {
+			return nil, errors.Errorf("line %d: invalid minOccurs %q", n.line, v)
+		}
+
+		p.min = min
+	}
+
+	if v, ok := n.attr("maxOccurs"); ok {
+		if v == "unbounded" {
+			p.max = unbounded
+		} else {
+			max, err := strconv.Atoi(v)
+
+			if err != nil || max < p.min {
+				return nil, errors.Errorf("line %d: invalid maxOccurs %q", n.line, v)
+			}
+
+			p.max = max
+		}
+	}
+
+	switch n.name {
+	case "element":
+		p.kind = particleElement
+
+		var err error
+
+		if ref, ok := n.attr("ref"); ok {
+			p.elem, err = c.globalElement(localName(ref))
+		} else {
+			p.elem, err = c.localElement(n)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if e, ok := ct.elements[p.elem.name]; ok && e != p.elem {
+			return nil, errors.Errorf("line %d: element %s is declared more than once", n.line, p.elem.name)
+		}
+
+		ct.elements[p.elem.name] = p.elem
+
+		return p, nil
+	case "sequence":
+		p.kind = particleSequence
+	case "choice":
+		p.kind = particleChoice
+	default:
+		return nil, unsupported(n)
+	}
+
+	for _, k := range n.kids {
+		if k.name == "annotation" {
+			continue
+		}
+
+		item, err := c.particle(k, ct)
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.items = append(p.items, item)
+	}
+
+	return p, nil
+}
+
+func (c *compiler) attribute(n *node) (*attribute, error) {
+	name, ok := n.attr("name")
+
+	if !ok {
+		return nil, errors.Errorf("line %d: attribute without a name", n.line)
+	}
+
+	a := &attribute{name: name}
+
+	if use, _ := n.attr("use"); use == "required" {
+		a.required = true
+	}
+
+	var err error
+
+	if typ, ok := n.attr("type"); ok {
+		a.typ, err = c.namedSimple(localName(typ))
+	} else if len(n.kids) == 1 && n.kids[0].name == "simpleType" {
+		a.typ, err = c.simple(n.kids[0])
+	} else {
+		a.typ = builtin("string")
+	}
+
+	return a, err
+}
+
+func builtin(name string) *simpleType {
+	t := newSimpleType(name)
+	t.builtin = builtinTypes[name]
+
+	return t
+}
+
+func (c *compiler) namedSimple(name string) (*simpleType, error) {
+	if st, ok := c.simpleTypes[name]; ok {
+		return st, nil
+	}
+
+	n, ok := c.simpleNodes[name]
+
+	if !ok {
+		if _, ok := builtinTypes[name]; ok {
+			return builtin(name), nil
+		}
+
+		return nil, errors.Errorf("reference to undeclared simple type %s", name)
+	}
+
+	st := newSimpleType(name)
+	c.simpleTypes[name] = st
+
+	return st, c.fillSimple(st, n)
+}
+
+func (c *compiler) simple(n *node) (*simpleType, error) {
+	st := newSimpleType("anonymous type")
+	return st, c.fillSimple(st, n)
+}
+
+// itemTypes returns the types named by attr, followed by any inline simple
+// types, as used by xs:list and xs:union.
+func (c *compiler) itemTypes(n *node, attr string) ([]*simpleType, error) {
+	var out []*simpleType
+
+	names, _ := n.attr(attr)
+
+	for _, name := range strings.Fields(names) {
+		st, err := c.namedSimple(localName(name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, st)
+	}
+
+	for _, k := range n.kids {
+		if k.name != "simpleType" {
+			return nil, unsupported(k)
+		}
+
+		st, err := c.simple(k)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, st)
+	}
+
+	return out, nil
+}
+
+func (c *compiler) fillSimple(st *simpleType, n *node) error {
+	if len(n.kids) != 1 {
+		return errors.Errorf("line %d: simpleType must contain one of restriction, list, or union", n.line)
+	}
+
+	k := n.kids[0]
+
+	switch k.name {
+	case "list":
+		items, err := c.itemTypes(k, "itemType")
+
+		if err != nil {
+			return err
+		}
+
+		if len(items) != 1 {
+			return errors.Errorf("line %d: list must have a single item type", k.line)
+		}
+
+		st.list = items[0]
+
+		return nil
+	case "union":
+		members, err := c.itemTypes(k, "memberTypes")
+
+		if err != nil {
+			return err
+		}
+
+		st.union = members
+
+		return nil
+	case "restriction":
+		return c.restriction(st, k)
+	default:
+		return unsupported(k)
+	}
+}
+
+func (c *compiler) restriction(st *simpleType, n *node) error {
+	var err error
+
+	if base, ok := n.attr("base"); ok {
+		st.base, err = c.namedSimple(localName(base))
+	} else if len(n.kids) > 0 && n.kids[0].name == "simpleType" {
+		st.base, err = c.simple(n.kids[0])
+	} else {
+		err = errors.Errorf("line %d: restriction without a base type", n.line)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, f := range n.kids {
+		value, _ := f.attr("value")
+
+		switch f.name {
+		case "simpleType":
+			// the anonymous base type, handled above
+			continue
+		case "enumeration":
+			st.enums = append(st.enums, value)
+		case "pattern":
+			re, err := translatePattern(value)
+
+			if err != nil {
+				return errors.Wrapf(err, "line %d: invalid pattern", f.line)
+			}
+
+			st.patterns = append(st.patterns, re)
+		case "minLength", "maxLength", "length":
+			l, err := strconv.Atoi(value)
+
+			if err != nil || l < 0 {
+				return errors.Errorf("line %d: invalid %s %q", f.line, f.name, value)
+			}
+
+			if f.name != "maxLength" {
+				st.minLen = l
+			}
+
+			if f.name != "minLength" {
+				st.maxLen = l
+			}
+		case "minInclusive", "maxInclusive":
+			r, ok := new(big.Rat).SetString(value)
+
+			if !ok {
+				return errors.Errorf("line %d: invalid %s %q", f.line, f.name, value)
+			}
+
+			if f.name == "minInclusive" {
+				st.minInc = r
+			} else {
+				st.maxInc = r
+			}
+		case "annotation":
+			continue
+		default:
+			return unsupported(f)
+		}
+	}
+
+	return nil
+}
+
+func (n *node) attrBool(name string) bool {
+	v, _ := n.attr(name)
+	return v == "true" || v == "1"
+}
@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import (
+	"strings"
+	"testing"
+)
+
+func schemaDoc(body string) []byte {
+	return []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">` + body + `</xs:schema>`)
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   []byte
+		err  string
+	}{
+		{
+			desc: "valid schema should compile",
+			in: schemaDoc(`
+<xs:simpleType name="method"><xs:restriction base="xs:string"><xs:enumeration value="GET"/></xs:restriction></xs:simpleType>
+<xs:element name="Root"><xs:complexType><xs:sequence><xs:element ref="Leaf"/></xs:sequence><xs:attribute name="method" type="method"/></xs:complexType></xs:element>
+<xs:element name="Leaf" type="xs:string"/>`),
+		},
+		{
+			desc: "malformed XML should fail",
+			in:   []byte("<xs:schema>"),
+			err:  "compiling schema failed",
+		},
+		{
+			desc: "root other than schema should fail",
+			in:   []byte("<element/>"),
+			err:  "root element is element, not schema",
+		},
+		{
+			desc: "unsupported construct should fail",
+			in:   schemaDoc(`<xs:element name="Root"><xs:complexType><xs:all/></xs:complexType></xs:element>`),
+			err:  "unsupported schema construct all",
+		},
+		{
+			desc: "reference to undeclared element should fail",
+			in:   schemaDoc(`<xs:element name="Root"><xs:complexType><xs:sequence><xs:element ref="Missing"/></xs:sequence></xs:complexType></xs:element>`),
+			err:  "undeclared element Missing",
+		},
+		{
+			desc: "reference to undeclared type should fail",
+			in:   schemaDoc(`<xs:element name="Root" type="missing"/>`),
+			err:  "missing",
+		},
+		{
+			desc: "unknown built-in type should fail",
+			in:   schemaDoc(`<xs:element name="Root" type="xs:dateTime"/>`),
+			err:  "dateTime",
+		},
+		{
+			desc: "invalid maxOccurs should fail",
+			in:   schemaDoc(`<xs:element name="Root"><xs:complexType><xs:sequence maxOccurs="many"/></xs:complexType></xs:element>`),
+			err:  `invalid maxOccurs "many"`,
+		},
+		{
+			desc: "invalid pattern should fail",
+			in:   schemaDoc(`<xs:simpleType name="p"><xs:restriction base="xs:string"><xs:pattern value="("/></xs:restriction></xs:simpleType><xs:element name="Root" type="p"/>`),
+			err:  "invalid pattern",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Compile(test.in)
+
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("\nDescription: %s\nCompile() Unexpected Error: %s", test.desc, err)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("\nDescription: %s\nCompile() error = %v, want it to contain %q", test.desc, err, test.err)
+		}
+	}
+}
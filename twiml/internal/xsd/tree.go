@@ -0,0 +1,115 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// node is an element of a parsed XML document, along with the line it starts
+// on.
+type node struct {
+	name  string
+	attrs []xml.Attr
+	kids  []*node
+	text  bytes.Buffer
+	line  int
+}
+
+// attr returns the value of the named attribute, ignoring its namespace.
+func (n *node) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// lines converts byte offsets within a document to line numbers.
+type lines []int
+
+func newLines(doc []byte) lines {
+	var l lines
+
+	for i, b := range doc {
+		if b == '\n' {
+			l = append(l, i)
+		}
+	}
+
+	return l
+}
+
+func (l lines) line(offset int64) int {
+	return sort.SearchInts(l, int(offset)) + 1
+}
+
+// parseTree parses doc in to a tree of nodes. Namespaces are ignored, as
+// TwiML doesn't use them.
+func parseTree(doc []byte) (*node, error) {
+	l := newLines(doc)
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+
+	var root *node
+	var stack []*node
+
+	for {
+		// the offset before reading a token is where the token starts
+		offset := dec.InputOffset()
+
+		tok, err := dec.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing XML document failed")
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{
+				name:  t.Name.Local,
+				attrs: t.Attr,
+				line:  l.line(offset),
+			}
+
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, errors.Errorf("parsing XML document failed: line %d: more than one root element", n.line)
+				}
+
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.kids = append(parent.kids, n)
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("parsing XML document failed: no root element")
+	}
+
+	return root, nil
+}
@@ -0,0 +1,187 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// simpleType is an XML Schema simple type, used for attribute values and
+// text-only content. Exactly one of builtin, base, list, or union is set.
+type simpleType struct {
+	name string
+
+	// builtin checks the value of a built-in type, such as xs:boolean
+	builtin func(string) bool
+
+	// base is the type restricted by the facets below
+	base *simpleType
+
+	enums    []string
+	patterns []*regexp.Regexp
+	minLen   int // -1 if not set
+	maxLen   int // -1 if not set
+	minInc   *big.Rat
+	maxInc   *big.Rat
+
+	// list is the item type of a whitespace separated list
+	list *simpleType
+
+	// union is the member types, any of which may match
+	union []*simpleType
+}
+
+func newSimpleType(name string) *simpleType {
+	return &simpleType{name: name, minLen: -1, maxLen: -1}
+}
+
+// check returns a description of why value isn't valid for the type, or an
+// empty string if it is.
+func (t *simpleType) check(value string) string {
+	switch {
+	case t.builtin != nil:
+		if !t.builtin(collapse(t.name, value)) {
+			return fmt.Sprintf("%q is not a valid %s", value, t.name)
+		}
+
+		return ""
+	case t.list != nil:
+		for _, item := range strings.Fields(value) {
+			if msg := t.list.check(item); msg != "" {
+				return msg
+			}
+		}
+
+		return ""
+	case t.union != nil:
+		for _, m := range t.union {
+			if m.check(value) == "" {
+				return ""
+			}
+		}
+
+		return fmt.Sprintf("%q does not match any member of the union", value)
+	}
+
+	if msg := t.base.check(value); msg != "" {
+		return msg
+	}
+
+	return t.checkFacets(value)
+}
+
+func (t *simpleType) checkFacets(value string) string {
+	if len(t.enums) > 0 {
+		found := false
+
+		for _, e := range t.enums {
+			if e == value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Sprintf("%q is not one of %s", value, strings.Join(t.enums, ", "))
+		}
+	}
+
+	for _, p := range t.patterns {
+		if !p.MatchString(value) {
+			return fmt.Sprintf("%q does not match the pattern %s", value, strings.TrimSuffix(strings.TrimPrefix(p.String(), "^(?:"), ")$"))
+		}
+	}
+
+	n := utf8.RuneCountInString(value)
+
+	if t.base.list != nil {
+		n = len(strings.Fields(value))
+	}
+
+	if t.minLen >= 0 && n < t.minLen {
+		return fmt.Sprintf("%q is shorter than %d", value, t.minLen)
+	}
+
+	if t.maxLen >= 0 && n > t.maxLen {
+		return fmt.Sprintf("%q is longer than %d", value, t.maxLen)
+	}
+
+	if t.minInc != nil || t.maxInc != nil {
+		r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+
+		if !ok {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+
+		if t.minInc != nil && r.Cmp(t.minInc) < 0 {
+			return fmt.Sprintf("%q is less than %s", value, t.minInc.RatString())
+		}
+
+		if t.maxInc != nil && r.Cmp(t.maxInc) > 0 {
+			return fmt.Sprintf("%q is greater than %s", value, t.maxInc.RatString())
+		}
+	}
+
+	return ""
+}
+
+// collapse applies the whitespace processing of the built-in types: all of
+// them except xs:string have leading and trailing whitespace removed.
+func collapse(name, value string) string {
+	if name == "string" {
+		return value
+	}
+
+	return strings.Join(strings.Fields(value), " ")
+}
+
+var (
+	integerPattern = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+)
+
+func integerIn(min int64) func(string) bool {
+	return func(s string) bool {
+		if !integerPattern.MatchString(s) {
+			return false
+		}
+
+		n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "+"), 10)
+
+		return ok && n.Cmp(big.NewInt(min)) >= 0
+	}
+}
+
+func anyValue(string) bool { return true }
+
+// builtinTypes are the XML Schema built-in types supported by this package,
+// keyed by their local name.
+var builtinTypes = map[string]func(string) bool{
+	"string":           anyValue,
+	"normalizedString": anyValue,
+	"token":            anyValue,
+	"anyURI":           func(s string) bool { return !strings.ContainsAny(s, " \t\n") },
+	"boolean": func(s string) bool {
+		return s == "true" || s == "false" || s == "1" || s == "0"
+	},
+	"decimal":            decimalPattern.MatchString,
+	"integer":            integerPattern.MatchString,
+	"nonNegativeInteger": integerIn(0),
+	"positiveInteger":    integerIn(1),
+	"unsignedInt":        integerIn(0),
+}
+
+// translatePattern converts an XML Schema regular expression to one for the
+// regexp package. XML Schema patterns are implicitly anchored, and the subset
+// used by TwiML is otherwise compatible.
+func translatePattern(p string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + p + `)$`)
+}
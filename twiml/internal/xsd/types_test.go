@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import "testing"
+
+func TestSimpleType_check(t *testing.T) {
+	s, err := Compile(schemaDoc(`
+<xs:simpleType name="digits"><xs:restriction base="xs:string"><xs:pattern value="[0-9]*"/></xs:restriction></xs:simpleType>
+<xs:simpleType name="word"><xs:restriction base="xs:token"><xs:enumeration value="one"/><xs:enumeration value="two"/></xs:restriction></xs:simpleType>
+<xs:simpleType name="words"><xs:restriction><xs:simpleType><xs:list itemType="word"/></xs:simpleType><xs:maxLength value="2"/></xs:restriction></xs:simpleType>
+<xs:simpleType name="percent"><xs:restriction base="xs:integer"><xs:minInclusive value="0"/><xs:maxInclusive value="100"/></xs:restriction></xs:simpleType>
+<xs:simpleType name="auto"><xs:restriction base="xs:string"><xs:enumeration value="auto"/></xs:restriction></xs:simpleType>
+<xs:simpleType name="timeout"><xs:union memberTypes="xs:positiveInteger auto"/></xs:simpleType>
+<xs:element name="Root"><xs:complexType>
+  <xs:attribute name="digits" type="digits"/>
+  <xs:attribute name="words" type="words"/>
+  <xs:attribute name="percent" type="percent"/>
+  <xs:attribute name="timeout" type="timeout"/>
+  <xs:attribute name="flag" type="xs:boolean"/>
+</xs:complexType></xs:element>`))
+
+	if err != nil {
+		t.Fatalf("Compile() Unexpected Error: %s", err)
+	}
+
+	attrs := s.elements["Root"].complex
+
+	tests := []struct {
+		desc  string
+		attr  string
+		value string
+		valid bool
+	}{
+		{"digits should match the pattern", "digits", "0123", true},
+		{"letters should not match the pattern", "digits", "12a", false},
+		{"list of known words should pass", "words", "one two", true},
+		{"list with an unknown word should fail", "words", "one three", false},
+		{"list longer than maxLength should fail", "words", "one two one", false},
+		{"number within range should pass", "percent", "100", true},
+		{"number above range should fail", "percent", "101", false},
+		{"non-number should fail range check", "percent", "ten", false},
+		{"first union member should pass", "timeout", "5", true},
+		{"second union member should pass", "timeout", "auto", true},
+		{"value matching no union member should fail", "timeout", "0", false},
+		{"boolean should pass", "flag", "true", true},
+		{"invalid boolean should fail", "flag", "yes", false},
+	}
+
+	for _, test := range tests {
+		msg := attrs.attribute(test.attr).typ.check(test.value)
+
+		if test.valid != (msg == "") {
+			t.Errorf("\nDescription: %s\ncheck(%q) = %q, want valid = %t", test.desc, test.value, msg, test.valid)
+		}
+	}
+}
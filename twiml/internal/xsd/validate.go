@@ -0,0 +1,292 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a single way in which a document doesn't conform to a Schema.
+type Violation struct {
+	// Line is the line of the document the offending element starts on.
+	Line int
+
+	// Element is the name of the offending element.
+	Element string
+
+	// Attribute is the name of the offending attribute, if the violation is
+	// about an attribute.
+	Attribute string
+
+	// Message describes the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Attribute != "" {
+		return fmt.Sprintf("line %d: <%s %s>: %s", v.Line, v.Element, v.Attribute, v.Message)
+	}
+
+	return fmt.Sprintf("line %d: <%s>: %s", v.Line, v.Element, v.Message)
+}
+
+// Validate checks doc against the Schema, returning each violation found in
+// document order. An error is only returned if doc isn't well-formed XML.
+// This function returns a wrapped error (see the twiml package documentation
+// for more info).
+func (s *Schema) Validate(doc []byte) ([]Violation, error) {
+	root, err := parseTree(doc)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var v validator
+
+	e, ok := s.elements[root.name]
+
+	if !ok {
+		v.add(root, "", "is not a valid root element")
+		return v.violations, nil
+	}
+
+	v.element(root, e)
+
+	return v.violations, nil
+}
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) add(n *node, attr, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{
+		Line:      n.line,
+		Element:   n.name,
+		Attribute: attr,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) element(n *node, e *element) {
+	if e.simple != nil {
+		for _, a := range n.attrs {
+			if !ignoredAttr(a.Name.Space, a.Name.Local) {
+				v.add(n, a.Name.Local, "attribute is not allowed")
+			}
+		}
+
+		v.text(n, e.simple)
+
+		return
+	}
+
+	ct := e.complex
+
+	v.attributes(n, ct)
+
+	if ct.text != nil {
+		v.text(n, ct.text)
+		return
+	}
+
+	if !ct.mixed && strings.TrimSpace(n.text.String()) != "" {
+		v.add(n, "", "text content is not allowed")
+	}
+
+	v.content(n, ct)
+
+	for _, k := range n.kids {
+		if ke, ok := ct.elements[k.name]; ok {
+			v.element(k, ke)
+		}
+	}
+}
+
+func ignoredAttr(space, local string) bool {
+	return space == "xmlns" || local == "xmlns" || space == "xml" ||
+		space == "http://www.w3.org/XML/1998/namespace"
+}
+
+func (v *validator) attributes(n *node, ct *complexType) {
+	seen := make(map[string]bool)
+
+	for _, a := range n.attrs {
+		if ignoredAttr(a.Name.Space, a.Name.Local) {
+			continue
+		}
+
+		seen[a.Name.Local] = true
+
+		decl := ct.attribute(a.Name.Local)
+
+		if decl == nil {
+			if !ct.anyAttr {
+				v.add(n, a.Name.Local, "attribute is not allowed")
+			}
+
+			continue
+		}
+
+		if msg := decl.typ.check(a.Value); msg != "" {
+			v.add(n, a.Name.Local, "%s", msg)
+		}
+	}
+
+	for _, decl := range ct.attrs {
+		if decl.required && !seen[decl.name] {
+			v.add(n, decl.name, "required attribute is missing")
+		}
+	}
+}
+
+// text checks the text content of an element with simple content, which must
+// not contain child elements.
+func (v *validator) text(n *node, st *simpleType) {
+	for _, k := range n.kids {
+		v.add(k, "", "element is not allowed within <%s>", n.name)
+	}
+
+	if msg := st.check(n.text.String()); msg != "" {
+		v.add(n, "", "%s", msg)
+	}
+}
+
+// content checks the child elements of n against the content model of ct.
+func (v *validator) content(n *node, ct *complexType) {
+	names := make([]string, len(n.kids))
+	unknown := false
+
+	for i, k := range n.kids {
+		names[i] = k.name
+
+		if _, ok := ct.elements[k.name]; !ok {
+			v.add(k, "", "element is not allowed within <%s>", n.name)
+			unknown = true
+		}
+	}
+
+	if unknown {
+		return
+	}
+
+	if ct.content == nil {
+		if len(names) > 0 {
+			v.add(n, "", "child elements are not allowed")
+		}
+
+		return
+	}
+
+	if ct.content.match(names, 0, false)[len(names)] {
+		return
+	}
+
+	// report the first child that can't be part of a valid sequence, even
+	// if the missing required elements were added, otherwise something is
+	// missing
+	for i := range names {
+		if !ct.content.match(names[:i+1], 0, true)[i+1] {
+			v.add(n.kids[i], "", "element is not expected here within <%s>", n.name)
+			return
+		}
+	}
+
+	v.add(n, "", "required child elements are missing")
+}
+
+// match returns the set of positions in names where a match of p, starting
+// at pos, could end. If relaxed is true, minOccurs is ignored, so that the
+// missing elements of an incomplete sequence aren't an error.
+func (p *particle) match(names []string, pos int, relaxed bool) map[int]bool {
+	min := p.min
+
+	if relaxed {
+		min = 0
+	}
+
+	out := make(map[int]bool)
+
+	if min == 0 {
+		out[pos] = true
+	}
+
+	current := map[int]bool{pos: true}
+
+	for i := 1; p.max == unbounded || i <= p.max; i++ {
+		next := make(map[int]bool)
+
+		for start := range current {
+			for end := range p.matchOnce(names, start, relaxed) {
+				next[end] = true
+			}
+		}
+
+		progressed := false
+
+		for end := range next {
+			if !current[end] {
+				progressed = true
+			}
+
+			if i >= min {
+				out[end] = true
+			}
+		}
+
+		// repetitions that don't consume anything can't change the result
+		if len(next) == 0 || !progressed && i >= min {
+			break
+		}
+
+		current = next
+	}
+
+	return out
+}
+
+func (p *particle) matchOnce(names []string, pos int, relaxed bool) map[int]bool {
+	switch p.kind {
+	case particleElement:
+		if pos < len(names) && names[pos] == p.elem.name {
+			return map[int]bool{pos + 1: true}
+		}
+
+		return nil
+	case particleSequence:
+		current := map[int]bool{pos: true}
+
+		for _, item := range p.items {
+			next := make(map[int]bool)
+
+			for start := range current {
+				for end := range item.match(names, start, relaxed) {
+					next[end] = true
+				}
+			}
+
+			if len(next) == 0 {
+				return nil
+			}
+
+			current = next
+		}
+
+		return current
+	default:
+		out := make(map[int]bool)
+
+		for _, item := range p.items {
+			for end := range item.match(names, pos, relaxed) {
+				out[end] = true
+			}
+		}
+
+		return out
+	}
+}
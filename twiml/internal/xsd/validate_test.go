@@ -0,0 +1,119 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package xsd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	s, err := Compile(schemaDoc(`
+<xs:element name="Root"><xs:complexType><xs:choice minOccurs="0" maxOccurs="unbounded">
+  <xs:element ref="Seq"/>
+  <xs:element ref="Text"/>
+</xs:choice></xs:complexType></xs:element>
+<xs:element name="Seq"><xs:complexType><xs:sequence>
+  <xs:element name="A" type="xs:string"/>
+  <xs:element name="B" type="xs:string" minOccurs="0" maxOccurs="2"/>
+  <xs:element name="C" type="xs:string"/>
+</xs:sequence><xs:attribute name="id" type="xs:positiveInteger" use="required"/></xs:complexType></xs:element>
+<xs:element name="Text"><xs:complexType><xs:simpleContent><xs:extension base="xs:integer">
+  <xs:attribute name="unit" type="xs:string"/>
+</xs:extension></xs:simpleContent></xs:complexType></xs:element>`))
+
+	if err != nil {
+		t.Fatalf("Compile() Unexpected Error: %s", err)
+	}
+
+	tests := []struct {
+		desc string
+		in   string
+		out  []string
+	}{
+		{
+			desc: "valid document should have no violations",
+			in:   "<Root>\n<Seq id=\"1\"><A/><B/><B/><C/></Seq>\n<Text unit=\"s\">10</Text>\n</Root>",
+		},
+		{
+			desc: "optional elements may be left out",
+			in:   "<Root><Seq id=\"1\"><A/><C/></Seq></Root>",
+		},
+		{
+			desc: "too many repetitions should fail at the extra element",
+			in:   "<Root>\n<Seq id=\"1\">\n<A/>\n<B/>\n<B/>\n<B/>\n<C/>\n</Seq>\n</Root>",
+			out:  []string{"line 6: <B>: element is not expected here within <Seq>"},
+		},
+		{
+			desc: "out of order elements should fail at the first misplaced one",
+			in:   "<Root>\n<Seq id=\"1\">\n<C/>\n<A/>\n</Seq>\n</Root>",
+			out:  []string{"line 4: <A>: element is not expected here within <Seq>"},
+		},
+		{
+			desc: "missing required element should fail on the parent",
+			in:   "<Root>\n<Seq id=\"1\"><A/></Seq>\n</Root>",
+			out:  []string{"line 2: <Seq>: required child elements are missing"},
+		},
+		{
+			desc: "all violations should be reported in document order",
+			in:   "<Root>\n<Seq>\n<A/><D/>\n</Seq>\n<Text unit=\"s\">ten</Text>\n</Root>",
+			out: []string{
+				"line 2: <Seq id>: required attribute is missing",
+				"line 3: <D>: element is not allowed within <Seq>",
+				`line 5: <Text>: "ten" is not a valid integer`,
+			},
+		},
+		{
+			desc: "text within element-only content should fail",
+			in:   "<Root>hello</Root>",
+			out:  []string{"line 1: <Root>: text content is not allowed"},
+		},
+		{
+			desc: "child elements within simple content should fail",
+			in:   "<Root>\n<Text>\n<A/>1</Text>\n</Root>",
+			out:  []string{"line 3: <A>: element is not allowed within <Text>"},
+		},
+		{
+			desc: "unknown root element should fail",
+			in:   "\n\n<Other/>",
+			out:  []string{"line 3: <Other>: is not a valid root element"},
+		},
+	}
+
+	for _, test := range tests {
+		violations, err := s.Validate([]byte(test.in))
+
+		if err != nil {
+			t.Errorf("\nDescription: %s\nValidate() Unexpected Error: %s", test.desc, err)
+			continue
+		}
+
+		var out []string
+
+		for _, v := range violations {
+			out = append(out, v.String())
+		}
+
+		if !reflect.DeepEqual(out, test.out) {
+			t.Errorf("\nDescription: %s\nValidate() = %q, want %q", test.desc, out, test.out)
+		}
+	}
+}
+
+func TestSchema_Validate_Malformed(t *testing.T) {
+	s, err := Compile(schemaDoc(`<xs:element name="Root" type="xs:string"/>`))
+
+	if err != nil {
+		t.Fatalf("Compile() Unexpected Error: %s", err)
+	}
+
+	for _, in := range []string{"", "<Root>", "<Root/><Root/>"} {
+		if _, err := s.Validate([]byte(in)); err == nil {
+			t.Errorf("Validate(%q) expected an error", in)
+		}
+	}
+}
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // Language represents a language as understood by the TwiML. The language
 // selected depends on the voice used to speak. By default this package uses the
@@ -15,7 +20,7 @@ import "encoding/xml"
 type Language uint16
 
 const (
-	//LangDefault is the default value for language and causes the field to not be set.
+	// LangDefault is the default value for language and causes the field to not be set.
 	LangDefault Language = iota
 
 	// LangEnglishUS is the English language as spoken in the United States.
@@ -106,6 +111,11 @@ func (l Language) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (l *Language) UnmarshalXMLAttr(attr xml.Attr) error {
+	return l.Set(attr.Value)
+}
+
 func (l Language) String() string {
 	switch l {
 	case LangDefault:
@@ -164,3 +174,75 @@ func (l Language) String() string {
 		return "unknown"
 	}
 }
+
+// LanguageValues returns all of the Language constants that render a value, for
+// listing the valid choices in configuration or help text.
+func LanguageValues() []Language {
+	return []Language{
+		LangEnglishUS,
+		LangCatalanSpain,
+		LangChineseCantonese,
+		LangChineseMandarin,
+		LangChineseTaiwaneseMandarin,
+		LangDanishDenmark,
+		LangDutchNetherlands,
+		LangEnglishAustralia,
+		LangEnglishCanada,
+		LangEnglishUK,
+		LangFinnishFinland,
+		LangFrenchCanada,
+		LangFrenchFrance,
+		LangGermanGermany,
+		LangItalianItaly,
+		LangJapaneseJapan,
+		LangKoreanKorea,
+		LangNorwegianNorway,
+		LangPolishPoland,
+		LangPortugeseBrazil,
+		LangPortugesePortugal,
+		LangRussianRussia,
+		LangSpanishMexico,
+		LangSpanishSpain,
+		LangSwedishSweden,
+	}
+}
+
+// ParseLanguage returns the Language whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseLanguage(s string) (Language, error) {
+	if s == "" {
+		return Language(0), nil
+	}
+
+	for _, v := range LanguageValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return Language(0), unknownValue("Language", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (l Language) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (l *Language) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (l *Language) Set(value string) error {
+	parsed, err := ParseLanguage(value)
+
+	if err != nil {
+		return err
+	}
+
+	*l = parsed
+
+	return nil
+}
@@ -0,0 +1,224 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSayLength is the maximum number of characters Twilio accepts within
+	// a single Say verb.
+	MaxSayLength = 4096
+
+	// MaxResponseSize is the maximum size, in bytes, of a TwiML document
+	// Twilio accepts.
+	MaxResponseSize = 64 * 1024
+)
+
+// Limits are the size limits to enforce when encoding a *Response. A zero
+// value for a field disables that check.
+type Limits struct {
+	// MaxSayLength is the maximum number of characters (not bytes) in the
+	// Message of a Say verb, including Say verbs nested within a Gather.
+	MaxSayLength int
+
+	// MaxResponseSize is the maximum size of the rendered document in bytes,
+	// including the XML header.
+	MaxResponseSize int
+}
+
+// TwilioLimits are the Limits enforced by Twilio when parsing TwiML.
+var TwilioLimits = Limits{
+	MaxSayLength:    MaxSayLength,
+	MaxResponseSize: MaxResponseSize,
+}
+
+// LimitKind is the type of limit that was exceeded.
+type LimitKind uint8
+
+const (
+	// LimitSayLength is the limit on the number of characters in a Say verb.
+	LimitSayLength LimitKind = 1 << iota
+
+	// LimitResponseSize is the limit on the size of the rendered document.
+	LimitResponseSize
+)
+
+func (k LimitKind) String() string {
+	switch k {
+	case LimitSayLength:
+		return "Say length"
+	case LimitResponseSize:
+		return "response size"
+	default:
+		return ""
+	}
+}
+
+// LimitError is the error returned, wrapped, when a *Response exceeds its
+// Limits. Use errors.Cause() from github.com/pkg/errors to get to it.
+type LimitError struct {
+	Kind LimitKind
+
+	// Path is the location of the Say verb exceeding LimitSayLength. It's
+	// empty for LimitResponseSize.
+	Path Path
+
+	// Size is the length of the Say verb in characters, or the size of the
+	// document in bytes.
+	Size int
+
+	// Max is the limit that was exceeded.
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	if len(e.Path) > 0 {
+		return fmt.Sprintf("%s of %d exceeds the limit of %d at %s", e.Kind, e.Size, e.Max, e.Path)
+	}
+
+	return fmt.Sprintf("%s of %d exceeds the limit of %d", e.Kind, e.Size, e.Max)
+}
+
+// WithLimits makes the encoding fail with a *LimitError, instead of producing
+// a document Twilio would reject, if the *Response exceeds the limits. Nothing
+// is written to the io.Writer when a limit is exceeded.
+func WithLimits(l Limits) EncodeOption {
+	return func(c *encodeConfig) {
+		c.limits = &l
+	}
+}
+
+// Check returns a *LimitError if any Say verb within the *Response exceeds
+// MaxSayLength. MaxResponseSize can only be checked when rendering.
+func (l Limits) Check(r *Response) error {
+	if l.MaxSayLength <= 0 {
+		return nil
+	}
+
+	return Walk(r, func(path Path, node interface{}) error {
+		var msg string
+
+		switch n := node.(type) {
+		case *Say:
+			if n == nil {
+				return nil
+			}
+
+			msg = n.Message
+		case Say:
+			msg = n.Message
+		default:
+			return nil
+		}
+
+		if size := utf8.RuneCountInString(msg); size > l.MaxSayLength {
+			return &LimitError{Kind: LimitSayLength, Path: path, Size: size, Max: l.MaxSayLength}
+		}
+
+		return nil
+	})
+}
+
+// SplitSay splits the Message of the Say verb in to multiple Say verbs, each
+// at most max characters long, so that long dynamically generated messages
+// aren't rejected by Twilio. Messages are split at the end of a sentence where
+// possible, otherwise at a word boundary, and as a last resort mid-word. The
+// Language, Voice, and Loop of s are copied to each Say verb, which means each
+// part is looped individually. If max is 0 or less, MaxSayLength is used. A
+// nil Say produces no verbs.
+//
+// The returned slice can be appended to Response.Verbs or Gather.NestedVerbs.
+func SplitSay(s *Say, max int) []interface{} {
+	if s == nil {
+		return nil
+	}
+
+	if max <= 0 {
+		max = MaxSayLength
+	}
+
+	var verbs []interface{}
+
+	for _, part := range splitText(s.Message, max) {
+		verbs = append(verbs, &Say{
+			Message:  part,
+			Language: s.Language,
+			Loop:     s.Loop,
+			Voice:    s.Voice,
+		})
+	}
+
+	return verbs
+}
+
+// splitText splits text in to parts of at most max characters, preferring to
+// split at sentence boundaries and then at whitespace.
+func splitText(text string, max int) []string {
+	var parts []string
+
+	rest := []rune(strings.TrimSpace(text))
+
+	for len(rest) > max {
+		cut := lastSentenceEnd(rest[:max+1])
+
+		if cut <= 0 {
+			cut = lastSpace(rest[:max+1])
+		}
+
+		if cut <= 0 {
+			cut = max
+		}
+
+		if part := strings.TrimSpace(string(rest[:cut])); part != "" {
+			parts = append(parts, part)
+		}
+
+		rest = []rune(strings.TrimLeftFunc(string(rest[cut:]), unicode.IsSpace))
+	}
+
+	if len(rest) > 0 {
+		parts = append(parts, string(rest))
+	}
+
+	return parts
+}
+
+// lastSentenceEnd returns the index just after the last sentence-ending
+// punctuation, or -1 if there is none. Half-width punctuation must be followed
+// by whitespace, and the last rune of window is only used to check for it.
+func lastSentenceEnd(window []rune) int {
+	for i := len(window) - 2; i >= 0; i-- {
+		switch window[i] {
+		case '.', '!', '?':
+			if unicode.IsSpace(window[i+1]) {
+				return i + 1
+			}
+		case '。', '！', '？':
+			// full-width punctuation isn't followed by a space
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// lastSpace returns the index of the last whitespace in window, or -1 if
+// there is none.
+func lastSpace(window []rune) int {
+	for i := len(window) - 1; i >= 0; i-- {
+		if unicode.IsSpace(window[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+func TestEncodeResponse_WithLimits(t *testing.T) {
+	longSay := &Say{Message: strings.Repeat("a", MaxSayLength+1)}
+	manySays := make([]interface{}, 0, 2000)
+
+	for i := 0; i < 2000; i++ {
+		manySays = append(manySays, &Say{Message: "This is a reasonably sized message."})
+	}
+
+	tests := []struct {
+		desc string
+		in   *Response
+		kind LimitKind
+		path string
+	}{
+		{"Short response should not exceed limits", &Response{Verbs: []interface{}{&Say{Message: "Hi!"}}}, 0, ""},
+		{"Long Say should exceed the Say length", &Response{Verbs: []interface{}{&Hangup{}, longSay}}, LimitSayLength, "Say[1]"},
+		{"Long nested Say should exceed the Say length", &Response{Verbs: []interface{}{&Gather{NestedVerbs: []interface{}{longSay}}}}, LimitSayLength, "Gather[0]/Say[0]"},
+		{"Many Says should exceed the response size", &Response{Verbs: manySays}, LimitResponseSize, ""},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		err := EncodeResponse(buf, test.in, WithLimits(TwilioLimits))
+
+		if test.kind == 0 {
+			if err != nil {
+				t.Errorf("\nDescription: %s\nEncodeResponse() Unexpected Error: %s", test.desc, err)
+			}
+
+			continue
+		}
+
+		le, ok := errors.Cause(err).(*LimitError)
+
+		if !ok {
+			t.Errorf("\nDescription: %s\nEncodeResponse() error = %v; want *LimitError", test.desc, err)
+			continue
+		}
+
+		if le.Kind != test.kind {
+			t.Errorf("\nDescription: %s\nLimitError.Kind = %s; want %s", test.desc, le.Kind, test.kind)
+		}
+
+		if p := le.Path.String(); p != test.path {
+			t.Errorf("\nDescription: %s\nLimitError.Path = %q; want %q", test.desc, p, test.path)
+		}
+
+		if buf.Len() != 0 {
+			t.Errorf("\nDescription: %s\nEncodeResponse() wrote %d bytes; want 0", test.desc, buf.Len())
+		}
+	}
+
+	// without the option the limits should not be enforced
+	if _, err := MarshalResponse(&Response{Verbs: []interface{}{longSay}}); err != nil {
+		t.Errorf("MarshalResponse() without limits Unexpected Error: %s", err)
+	}
+
+	if _, err := MarshalSlice([]interface{}{longSay}, WithLimits(Limits{MaxSayLength: 10})); err == nil {
+		t.Error("MarshalSlice() with limits should fail for a long Say")
+	}
+}
+
+func TestSplitSay(t *testing.T) {
+	say := func(message string) *Say {
+		return &Say{Message: message, Language: LangEnglishUK, Voice: VoiceAlice, Loop: 2}
+	}
+
+	tests := []struct {
+		desc string
+		in   *Say
+		max  int
+		out  []string
+	}{
+		{"Short message should not be split", say("Hello there."), 20, []string{"Hello there."}},
+		{"Message should be split at sentences", say("One two. Three four. Five six."), 20, []string{"One two. Three four.", "Five six."}},
+		{"Message should be split at words without sentences", say("one two three four five"), 10, []string{"one two", "three four", "five"}},
+		{"Long words should be split mid-word", say("abcdefghij"), 4, []string{"abcd", "efgh", "ij"}},
+		{"Exclamations should end sentences", say("Wow! That is great."), 12, []string{"Wow!", "That is", "great."}},
+		{"Decimals should not end sentences", say("It costs 1.50 today"), 12, []string{"It costs", "1.50 today"}},
+		{"Full-width punctuation should end sentences", say("こんにちは。元気ですか。"), 7, []string{"こんにちは。", "元気ですか。"}},
+		{"Empty message should produce no verbs", say(""), 10, nil},
+		{"Nil Say should produce no verbs", nil, 10, nil},
+	}
+
+	for _, test := range tests {
+		verbs := SplitSay(test.in, test.max)
+
+		var out []string
+
+		for _, v := range verbs {
+			s := v.(*Say)
+
+			if s.Language != test.in.Language || s.Voice != test.in.Voice || s.Loop != test.in.Loop {
+				t.Errorf("\nDescription: %s\nSplitSay() part %#v did not preserve attributes", test.desc, s)
+			}
+
+			if n := utf8.RuneCountInString(s.Message); n > test.max {
+				t.Errorf("\nDescription: %s\nSplitSay() part %q has %d characters; max %d", test.desc, s.Message, n, test.max)
+			}
+
+			out = append(out, s.Message)
+		}
+
+		if strings.Join(out, "|") != strings.Join(test.out, "|") {
+			t.Errorf("\nDescription: %s\nSplitSay(%+v, %d) = %q; want %q", test.desc, test.in, test.max, out, test.out)
+		}
+	}
+
+	long := &Say{Message: strings.Repeat("All work and no play. ", 500)}
+
+	if _, err := MarshalSlice(SplitSay(long, 0), WithLimits(TwilioLimits)); err != nil {
+		t.Errorf("MarshalSlice(SplitSay()) Unexpected Error: %s", err)
+	}
+}
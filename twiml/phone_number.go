@@ -0,0 +1,375 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidPhoneNumber is the cause of errors returned when a phone number
+// contains characters that aren't digits or common separators, or when a
+// national-format number is parsed without a known default region.
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+// ErrPhoneNumberLength is the cause of errors returned when a phone number has
+// too few or too many digits for its country.
+var ErrPhoneNumberLength = errors.New("invalid phone number length")
+
+// PhoneNumber is a phone number, ideally in E.164 format (e.g.,
+// "+14155555555"). It renders to XML as a plain string, so existing string
+// constants can still be used for any field of this type. Use
+// ParsePhoneNumber to normalize human-formatted numbers to E.164.
+type PhoneNumber string
+
+// countryInfo contains the numbering plan details for a single country.
+type countryInfo struct {
+	region      string // ISO 3166-1 alpha-2, upper-case
+	callingCode string
+	trunk       string // national trunk prefix, dropped from E.164 numbers
+	minLen      int    // minimum length of the national significant number
+	maxLen      int    // maximum length of the national significant number
+}
+
+// countries is the list of numbering plans known to this package. Countries
+// sharing a calling code (e.g., the NANP) are listed with the one returned by
+// PhoneNumber.Region() first. Numbers with calling codes that aren't listed
+// are only held to the generic E.164 rules.
+var countries = []countryInfo{
+	{"US", "1", "1", 10, 10},
+	{"CA", "1", "1", 10, 10},
+	{"RU", "7", "8", 10, 10},
+	{"EG", "20", "0", 8, 10},
+	{"ZA", "27", "0", 9, 9},
+	{"GR", "30", "", 10, 10},
+	{"NL", "31", "0", 9, 9},
+	{"BE", "32", "0", 8, 9},
+	{"FR", "33", "0", 9, 9},
+	{"ES", "34", "", 9, 9},
+	{"HU", "36", "06", 8, 9},
+	{"IT", "39", "", 6, 11},
+	{"RO", "40", "0", 9, 9},
+	{"CH", "41", "0", 9, 9},
+	{"AT", "43", "0", 4, 13},
+	{"GB", "44", "0", 9, 10},
+	{"DK", "45", "", 8, 8},
+	{"SE", "46", "0", 7, 10},
+	{"NO", "47", "", 8, 8},
+	{"PL", "48", "", 9, 9},
+	{"DE", "49", "0", 6, 13},
+	{"PE", "51", "0", 8, 9},
+	{"MX", "52", "", 10, 10},
+	{"AR", "54", "0", 10, 10},
+	{"BR", "55", "0", 10, 11},
+	{"CL", "56", "", 9, 9},
+	{"CO", "57", "", 10, 10},
+	{"VE", "58", "0", 10, 10},
+	{"MY", "60", "0", 8, 10},
+	{"AU", "61", "0", 9, 9},
+	{"ID", "62", "0", 8, 12},
+	{"PH", "63", "0", 8, 10},
+	{"NZ", "64", "0", 8, 10},
+	{"SG", "65", "", 8, 8},
+	{"TH", "66", "0", 8, 9},
+	{"JP", "81", "0", 9, 10},
+	{"KR", "82", "0", 8, 10},
+	{"VN", "84", "0", 9, 10},
+	{"CN", "86", "0", 9, 11},
+	{"TR", "90", "0", 10, 10},
+	{"IN", "91", "0", 10, 10},
+	{"PT", "351", "", 9, 9},
+	{"IE", "353", "0", 7, 9},
+	{"IS", "354", "", 7, 9},
+	{"FI", "358", "0", 5, 12},
+	{"BG", "359", "0", 8, 9},
+	{"LT", "370", "8", 8, 8},
+	{"EE", "372", "", 7, 8},
+	{"UA", "380", "0", 9, 9},
+	{"HR", "385", "0", 8, 9},
+	{"SI", "386", "0", 8, 8},
+	{"CZ", "420", "", 9, 9},
+	{"HK", "852", "", 8, 8},
+	{"TW", "886", "0", 8, 9},
+	{"SA", "966", "0", 9, 9},
+	{"AE", "971", "0", 8, 9},
+	{"IL", "972", "0", 8, 9},
+}
+
+// regionAliases maps commonly used, but non-ISO, region codes to their ISO
+// equivalent.
+var regionAliases = map[string]string{
+	"UK": "GB",
+}
+
+func countryByRegion(region string) (countryInfo, bool) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+
+	if alias, ok := regionAliases[region]; ok {
+		region = alias
+	}
+
+	for _, c := range countries {
+		if c.region == region {
+			return c, true
+		}
+	}
+
+	return countryInfo{}, false
+}
+
+// countryByDigits finds the country whose calling code prefixes digits.
+// Calling codes are prefix-free, so the first match is the only match.
+func countryByDigits(digits string) (countryInfo, bool) {
+	for _, c := range countries {
+		if strings.HasPrefix(digits, c.callingCode) {
+			return c, true
+		}
+	}
+
+	return countryInfo{}, false
+}
+
+// ParsePhoneNumber parses a phone number in a common human format, such as
+// "(415) 555-5555", "+44 (0)20 7946 0000", or "0049 30 123456", and
+// normalizes it to E.164. Numbers without an international prefix ("+", "00",
+// or "011" within the NANP) are treated as national numbers within
+// defaultRegion, which is an ISO 3166-1 alpha-2 country code (e.g., "US" or
+// "de"). This function returns a wrapped error (see package documentation for
+// more info), whose cause is either ErrInvalidPhoneNumber or
+// ErrPhoneNumberLength.
+func ParsePhoneNumber(s, defaultRegion string) (PhoneNumber, error) {
+	in := strings.TrimSpace(s)
+
+	international := strings.HasPrefix(in, "+")
+
+	if international {
+		in = in[1:]
+
+		// "+44 (0)20..." includes the trunk prefix as a hint for callers
+		// dialing nationally; it's never part of the E.164 number
+		in = strings.Replace(in, "(0)", "", 1)
+	}
+
+	digits, err := stripSeparators(in)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %q", s)
+	}
+
+	if !international {
+		country, known := countryByRegion(defaultRegion)
+
+		switch {
+		case country.callingCode == "1" && strings.HasPrefix(digits, "011"):
+			digits, international = digits[3:], true
+		case country.callingCode != "1" && strings.HasPrefix(digits, "00"):
+			digits, international = digits[2:], true
+		case !known:
+			return "", errors.Wrapf(ErrInvalidPhoneNumber, "parsing %q: national number with unknown default region %q", s, defaultRegion)
+		default:
+			national := digits
+
+			// NANP numbers are ten digits without the trunk prefix, so only
+			// strip it when it's clearly there
+			if country.trunk != "" && strings.HasPrefix(national, country.trunk) &&
+				len(national)-len(country.trunk) >= country.minLen {
+				national = national[len(country.trunk):]
+			}
+
+			digits = country.callingCode + national
+		}
+	}
+
+	p := PhoneNumber("+" + digits)
+
+	if err := p.Validate(); err != nil {
+		return "", errors.Wrapf(err, "parsing %q", s)
+	}
+
+	return p, nil
+}
+
+// stripSeparators removes the characters commonly used to group the digits of
+// a phone number, returning an error if any other characters are present.
+func stripSeparators(s string) (string, error) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			buf.WriteRune(r)
+		case r == ' ', r == '-', r == '.', r == '(', r == ')', r == '/', r == '\u00a0':
+			continue
+		default:
+			return "", errors.Wrapf(ErrInvalidPhoneNumber, "unexpected character %q", r)
+		}
+	}
+
+	if buf.Len() == 0 {
+		return "", errors.Wrap(ErrInvalidPhoneNumber, "no digits")
+	}
+
+	return buf.String(), nil
+}
+
+// Validate returns an error if the PhoneNumber is not in E.164 format, or if
+// the number of digits is not valid for its country. Numbers whose calling
+// code isn't known to this package only need a leading "+" and at most 15
+// digits. This function returns a wrapped error (see package documentation for
+// more info).
+func (p PhoneNumber) Validate() error {
+	s := string(p)
+
+	// country calling codes never start with 0
+	if !strings.HasPrefix(s, "+") || len(s) < 2 || s[1] == '0' {
+		return errors.Wrapf(ErrInvalidPhoneNumber, "%q is not in E.164 format", s)
+	}
+
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return errors.Wrapf(ErrInvalidPhoneNumber, "%q is not in E.164 format", s)
+		}
+	}
+
+	// E.164 numbers are at most 15 digits, including the calling code
+	if len(s)-1 > 15 {
+		return errors.Wrapf(ErrPhoneNumberLength, "%q is longer than 15 digits", s)
+	}
+
+	c, ok := countryByDigits(s[1:])
+
+	if !ok {
+		return nil
+	}
+
+	if n := len(s) - 1 - len(c.callingCode); n < c.minLen || n > c.maxLen {
+		return errors.Wrapf(ErrPhoneNumberLength, "%q has %d national digits for +%s", s, n, c.callingCode)
+	}
+
+	return nil
+}
+
+// IsValid returns whether the PhoneNumber is a valid E.164 number. See
+// Validate for details on why it might not be.
+func (p PhoneNumber) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// CountryCode returns the country calling code of the PhoneNumber, such as 1
+// for the US and 49 for Germany. It returns 0 if the code can't be determined,
+// including for valid numbers with a calling code this package doesn't know.
+func (p PhoneNumber) CountryCode() int {
+	c, ok := p.country()
+
+	if !ok {
+		return 0
+	}
+
+	n, _ := strconv.Atoi(c.callingCode)
+
+	return n
+}
+
+// Region returns the upper-case ISO 3166-1 alpha-2 code of the country the
+// PhoneNumber belongs to, or an empty string if it can't be determined. For
+// calling codes shared by multiple countries the first known country is
+// returned, so all +1 numbers return "US".
+func (p PhoneNumber) Region() string {
+	c, _ := p.country()
+	return c.region
+}
+
+// NationalNumber returns the national significant number of the PhoneNumber,
+// which is the number without the country calling code or trunk prefix. It
+// returns an empty string if the PhoneNumber isn't in E.164 format or its
+// calling code is unknown.
+func (p PhoneNumber) NationalNumber() string {
+	c, ok := p.country()
+
+	if !ok {
+		return ""
+	}
+
+	return string(p)[1+len(c.callingCode):]
+}
+
+func (p PhoneNumber) country() (countryInfo, bool) {
+	s := string(p)
+
+	if !strings.HasPrefix(s, "+") {
+		return countryInfo{}, false
+	}
+
+	return countryByDigits(s[1:])
+}
+
+// FormatInternational returns the PhoneNumber formatted for display to people
+// outside of its country, such as "+1 415-555-5555" or "+44 207 946 0000". If
+// the PhoneNumber isn't in E.164 format, or its calling code is unknown, it's
+// returned unmodified.
+func (p PhoneNumber) FormatInternational() string {
+	c, ok := p.country()
+
+	if !ok {
+		return string(p)
+	}
+
+	national := p.NationalNumber()
+
+	if c.callingCode == "1" && len(national) == 10 {
+		return "+1 " + national[:3] + "-" + national[3:6] + "-" + national[6:]
+	}
+
+	return "+" + c.callingCode + " " + groupDigits(national)
+}
+
+// FormatNational returns the PhoneNumber formatted for display to people in the
+// same country, such as "(415) 555-5555" or "0207 946 0000". If the
+// PhoneNumber isn't in E.164 format, or its calling code is unknown, it's
+// returned unmodified.
+func (p PhoneNumber) FormatNational() string {
+	c, ok := p.country()
+
+	if !ok {
+		return string(p)
+	}
+
+	national := p.NationalNumber()
+
+	if c.callingCode == "1" && len(national) == 10 {
+		return "(" + national[:3] + ") " + national[3:6] + "-" + national[6:]
+	}
+
+	return c.trunk + groupDigits(national)
+}
+
+// groupDigits splits digits in to space-separated groups of three, leaving a
+// final group of four instead of a trailing single digit.
+func groupDigits(digits string) string {
+	if len(digits) <= 4 {
+		return digits
+	}
+
+	var groups []string
+
+	for len(digits) > 4 {
+		groups = append(groups, digits[:3])
+		digits = digits[3:]
+	}
+
+	return strings.Join(append(groups, digits), " ")
+}
+
+func (p PhoneNumber) String() string {
+	return string(p)
+}
@@ -0,0 +1,141 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		desc   string
+		in     string
+		region string
+		out    PhoneNumber
+		err    error
+	}{
+		{"E.164 number should be unchanged", "+14155555555", "", "+14155555555", nil},
+		{"US national number should get +1", "(415) 555-5555", "US", "+14155555555", nil},
+		{"US number with trunk prefix should get +1", "1-415-555-5555", "us", "+14155555555", nil},
+		{"US dotted number should get +1", "415.555.5555", "US", "+14155555555", nil},
+		{"NANP international prefix should be handled", "011 44 20 7946 0000", "US", "+442079460000", nil},
+		{"UK national number should drop trunk prefix", "020 7946 0000", "GB", "+442079460000", nil},
+		{"UK alias should be accepted as a region", "020 7946 0000", "UK", "+442079460000", nil},
+		{"UK number with (0) should drop it", "+44 (0)20 7946 0000", "", "+442079460000", nil},
+		{"German number with 00 prefix should be handled", "0049 30 123456", "FR", "+4930123456", nil},
+		{"German national number should drop trunk prefix", "030/123456", "DE", "+4930123456", nil},
+		{"Russian national number should drop trunk prefix 8", "8 916 123-45-67", "RU", "+79161234567", nil},
+		{"Non-breaking spaces should be accepted", "+1 415 555 5555", "", "+14155555555", nil},
+		{"Letters should be rejected", "1-800-FLOWERS", "US", "", ErrInvalidPhoneNumber},
+		{"Empty string should be rejected", "", "US", "", ErrInvalidPhoneNumber},
+		{"National number without a region should be rejected", "415 555 5555", "", "", ErrInvalidPhoneNumber},
+		{"Luxembourg number should be accepted", "+352 26 12 34 56", "", "+35226123456", nil},
+		{"Nigerian number with 00 prefix should be accepted", "00234 802 123 4567", "GB", "+2348021234567", nil},
+		{"Pakistani number should be accepted", "+92 300 1234567", "", "+923001234567", nil},
+		{"Unlisted code over 15 digits should be rejected", "+352 1234 5678 9012 34", "", "", ErrPhoneNumberLength},
+		{"Calling code starting with 0 should be rejected", "+012 3456 7890", "", "", ErrInvalidPhoneNumber},
+		{"Short US number should be rejected", "555-5555", "US", "", ErrPhoneNumberLength},
+		{"Long UK number should be rejected", "+44 20 7946 0000 00", "", "", ErrPhoneNumberLength},
+		{"Numbers over 15 digits should be rejected", "+49 1234 5678 9012 3456", "", "", ErrPhoneNumberLength},
+	}
+
+	for _, test := range tests {
+		out, err := ParsePhoneNumber(test.in, test.region)
+
+		if cause := errors.Cause(err); cause != test.err {
+			t.Errorf(
+				"\nDescription: %s\nParsePhoneNumber(%q, %q) error = %v; want cause %v",
+				test.desc, test.in, test.region, err, test.err,
+			)
+			continue
+		}
+
+		if out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nParsePhoneNumber(%q, %q) = %q; want %q",
+				test.desc, test.in, test.region, out, test.out,
+			)
+		}
+	}
+}
+
+func TestPhoneNumber_Validate(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   PhoneNumber
+		err  error
+	}{
+		{"Valid US number should pass", "+14155555555", nil},
+		{"Valid Japanese number should pass", "+81312345678", nil},
+		{"Number without + should fail", "14155555555", ErrInvalidPhoneNumber},
+		{"Number with separators should fail", "+1 415 555 5555", ErrInvalidPhoneNumber},
+		{"Only + should fail", "+", ErrInvalidPhoneNumber},
+		{"Unlisted country code should pass", "+35226123456", nil},
+		{"Unlisted country code over 15 digits should fail", "+9230012345678901", ErrPhoneNumberLength},
+		{"Leading 0 should fail", "+0123456789", ErrInvalidPhoneNumber},
+		{"Short number should fail", "+1415555", ErrPhoneNumberLength},
+	}
+
+	for _, test := range tests {
+		if err := test.in.Validate(); errors.Cause(err) != test.err {
+			t.Errorf(
+				"\nDescription: %s\nPhoneNumber(%q).Validate() = %v; want cause %v",
+				test.desc, test.in, err, test.err,
+			)
+		}
+
+		if valid := test.in.IsValid(); valid != (test.err == nil) {
+			t.Errorf(
+				"\nDescription: %s\nPhoneNumber(%q).IsValid() = %t; want %t",
+				test.desc, test.in, valid, test.err == nil,
+			)
+		}
+	}
+}
+
+func TestPhoneNumber_Details(t *testing.T) {
+	tests := []struct {
+		desc          string
+		in            PhoneNumber
+		countryCode   int
+		region        string
+		national      string
+		international string
+		nationalFmt   string
+	}{
+		{"US number", "+14155555555", 1, "US", "4155555555", "+1 415-555-5555", "(415) 555-5555"},
+		{"UK number", "+442079460000", 44, "GB", "2079460000", "+44 207 946 0000", "0207 946 0000"},
+		{"German number", "+4930123456", 49, "DE", "30123456", "+49 301 234 56", "0301 234 56"},
+		{"Portuguese number", "+351912345678", 351, "PT", "912345678", "+351 912 345 678", "912 345 678"},
+		{"Unlisted country code", "+35226123456", 0, "", "", "+35226123456", "+35226123456"},
+		{"Non-E.164 number", "415-555-5555", 0, "", "", "415-555-5555", "415-555-5555"},
+	}
+
+	for _, test := range tests {
+		if cc := test.in.CountryCode(); cc != test.countryCode {
+			t.Errorf("\nDescription: %s\nPhoneNumber(%q).CountryCode() = %d; want %d", test.desc, test.in, cc, test.countryCode)
+		}
+
+		if r := test.in.Region(); r != test.region {
+			t.Errorf("\nDescription: %s\nPhoneNumber(%q).Region() = %q; want %q", test.desc, test.in, r, test.region)
+		}
+
+		if n := test.in.NationalNumber(); n != test.national {
+			t.Errorf("\nDescription: %s\nPhoneNumber(%q).NationalNumber() = %q; want %q", test.desc, test.in, n, test.national)
+		}
+
+		if f := test.in.FormatInternational(); f != test.international {
+			t.Errorf("\nDescription: %s\nPhoneNumber(%q).FormatInternational() = %q; want %q", test.desc, test.in, f, test.international)
+		}
+
+		if f := test.in.FormatNational(); f != test.nationalFmt {
+			t.Errorf("\nDescription: %s\nPhoneNumber(%q).FormatNational() = %q; want %q", test.desc, test.in, f, test.nationalFmt)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package prompt provides catalogs of localized prompts for TwiML responses.
+// Each prompt is identified by a message ID, and translated in to any of the
+// languages in twiml.Language. A translation can have a recording, which is
+// played with a Play verb, and falls back to text-to-speech with a Say verb in
+// the right Language and Voice when it doesn't.
+//
+// Translations may contain placeholders in the form {name}, which are replaced
+// by the Args passed when rendering the prompt, and may have a form for each
+// plural category of the language (see PluralForm), chosen by the count Arg.
+// Prompts with placeholders are always spoken, as a recording can't contain
+// the values.
+//
+// Catalogs are usually loaded from a spreadsheet exported as CSV, using
+// LoadCSV, which reports any missing translations. Errors are wrapped, like in
+// the twiml package.
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// CountArg is the name of the Arg that selects the plural form of a prompt.
+// It may be any integer type, and can also be used as a placeholder.
+const CountArg = "count"
+
+// ErrNotFound is the cause of errors returned when rendering a prompt that
+// isn't in the Catalog for the language.
+var ErrNotFound = errors.New("prompt not found")
+
+// Args are the values of the placeholders of a prompt, keyed by name.
+type Args map[string]interface{}
+
+// Translation is a prompt in a single language and plural form.
+type Translation struct {
+	// Text is what Twilio says, which may contain {name} placeholders.
+	Text string
+
+	// AudioURL is the URL of a recording of the prompt, played instead of
+	// Text if it's set and Text has no placeholders.
+	AudioURL string
+
+	// Voice is the voice Text is spoken in. The zero value uses the
+	// Twilio default.
+	Voice twiml.Voice
+}
+
+// message is a prompt in a single language, with a translation for each
+// plural form.
+type message map[PluralForm]Translation
+
+// Catalog is a set of prompts, keyed by message ID and language. The zero
+// value is not usable; use NewCatalog() or LoadCSV(). A Catalog is safe for
+// concurrent use once it's no longer being modified.
+type Catalog struct {
+	messages map[string]map[twiml.Language]message
+}
+
+// NewCatalog returns an empty *Catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{messages: make(map[string]map[twiml.Language]message)}
+}
+
+// Set adds the translation of the message ID for the language and plural form,
+// replacing any previous one. Messages that aren't pluralized only need
+// PluralOther.
+func (c *Catalog) Set(id string, lang twiml.Language, form PluralForm, t Translation) {
+	langs, ok := c.messages[id]
+
+	if !ok {
+		langs = make(map[twiml.Language]message)
+		c.messages[id] = langs
+	}
+
+	m, ok := langs[lang]
+
+	if !ok {
+		m = make(message)
+		langs[lang] = m
+	}
+
+	m[form] = t
+}
+
+// IDs returns the message IDs in the Catalog, sorted.
+func (c *Catalog) IDs() []string {
+	ids := make([]string, 0, len(c.messages))
+
+	for id := range c.messages {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Missing is a translation that's missing from a Catalog.
+type Missing struct {
+	ID       string
+	Language twiml.Language
+
+	// Form is the missing plural form. It's PluralOther if the message
+	// isn't translated in to the language at all.
+	Form PluralForm
+}
+
+func (m Missing) String() string {
+	if m.Form == PluralOther {
+		return fmt.Sprintf("%s (%s)", m.ID, m.Language)
+	}
+
+	return fmt.Sprintf("%s (%s, %s)", m.ID, m.Language, m.Form)
+}
+
+// MissingError is the error returned, wrapped, by LoadCSV when translations
+// are missing. Use errors.Cause() from github.com/pkg/errors to get to it.
+type MissingError struct {
+	Missing []Missing
+}
+
+func (e *MissingError) Error() string {
+	s := make([]string, len(e.Missing))
+
+	for i, m := range e.Missing {
+		s[i] = m.String()
+	}
+
+	return "missing translations: " + strings.Join(s, ", ")
+}
+
+// Missing returns the translations needed for every message to be available
+// in each of the languages, sorted by message ID. A message is pluralized if
+// any of its translations has a form other than PluralOther, in which case it
+// needs every form in PluralForms() for each language.
+func (c *Catalog) Missing(langs ...twiml.Language) []Missing {
+	var out []Missing
+
+	for _, id := range c.IDs() {
+		plural := false
+
+		for _, m := range c.messages[id] {
+			for form := range m {
+				if form != PluralOther {
+					plural = true
+				}
+			}
+		}
+
+		for _, lang := range langs {
+			m, ok := c.messages[id][lang]
+
+			if !ok {
+				out = append(out, Missing{ID: id, Language: lang})
+				continue
+			}
+
+			if !plural {
+				continue
+			}
+
+			for _, form := range PluralForms(lang) {
+				if _, ok := m[form]; !ok {
+					out = append(out, Missing{ID: id, Language: lang, Form: form})
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// Verb renders the prompt with the message ID in the language, returning a
+// *twiml.Play if the translation has a recording, or a *twiml.Say otherwise.
+// This function returns a wrapped error (see package documentation for more
+// info).
+func (c *Catalog) Verb(id string, lang twiml.Language, args Args) (interface{}, error) {
+	t, err := c.translation(id, lang, args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if names, _ := placeholders(t.Text); t.AudioURL != "" && len(names) == 0 {
+		return &twiml.Play{URL: t.AudioURL}, nil
+	}
+
+	text, err := expand(t.Text, args)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering prompt %s (%s) failed", id, lang)
+	}
+
+	return &twiml.Say{Message: text, Language: lang, Voice: t.Voice}, nil
+}
+
+// Text renders the prompt with the message ID in the language as text, for
+// use where a verb isn't wanted, such as an Sms. This function returns a
+// wrapped error (see package documentation for more info).
+func (c *Catalog) Text(id string, lang twiml.Language, args Args) (string, error) {
+	t, err := c.translation(id, lang, args)
+
+	if err != nil {
+		return "", err
+	}
+
+	text, err := expand(t.Text, args)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "rendering prompt %s (%s) failed", id, lang)
+	}
+
+	return text, nil
+}
+
+// translation returns the translation of the message in the plural form
+// selected by the count Arg, falling back to PluralOther.
+func (c *Catalog) translation(id string, lang twiml.Language, args Args) (Translation, error) {
+	m, ok := c.messages[id][lang]
+
+	if !ok {
+		return Translation{}, errors.Wrapf(ErrNotFound, "%s (%s)", id, lang)
+	}
+
+	form := PluralOther
+
+	if v, ok := args[CountArg]; ok {
+		n, err := count(v)
+
+		if err != nil {
+			return Translation{}, errors.Wrapf(err, "rendering prompt %s (%s) failed", id, lang)
+		}
+
+		form = PluralFormFor(lang, n)
+	}
+
+	if t, ok := m[form]; ok {
+		return t, nil
+	}
+
+	if t, ok := m[PluralOther]; ok {
+		return t, nil
+	}
+
+	return Translation{}, errors.Wrapf(ErrNotFound, "%s (%s, %s)", id, lang, form)
+}
+
+// count converts the count Arg to a uint64. Negative counts use the form of
+// their absolute value.
+func count(v interface{}) (uint64, error) {
+	switch n := v.(type) {
+	case int:
+		return abs(int64(n)), nil
+	case int8:
+		return abs(int64(n)), nil
+	case int16:
+		return abs(int64(n)), nil
+	case int32:
+		return abs(int64(n)), nil
+	case int64:
+		return abs(n), nil
+	case uint:
+		return uint64(n), nil
+	case uint8:
+		return uint64(n), nil
+	case uint16:
+		return uint64(n), nil
+	case uint32:
+		return uint64(n), nil
+	case uint64:
+		return n, nil
+	default:
+		return 0, errors.Errorf("%s must be an integer, not %T", CountArg, v)
+	}
+}
+
+func abs(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+
+	return uint64(n)
+}
+
+// expand replaces the {name} placeholders in text with the Args. A doubled
+// brace, {{ or }}, renders a literal brace.
+func expand(text string, args Args) (string, error) {
+	var b bytes.Buffer
+
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '{' && strings.HasPrefix(text[i:], "{{"), c == '}' && strings.HasPrefix(text[i:], "}}"):
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(text[i:], '}')
+
+			if end < 0 {
+				return "", errors.Errorf("unterminated placeholder at offset %d", i)
+			}
+
+			name := text[i+1 : i+end]
+			v, ok := args[name]
+
+			if !ok {
+				return "", errors.Errorf("no value for placeholder {%s}", name)
+			}
+
+			fmt.Fprint(&b, v)
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// placeholders returns the names of the placeholders in text, or an error if
+// a placeholder isn't terminated.
+func placeholders(text string) ([]string, error) {
+	var names []string
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '{' {
+			continue
+		}
+
+		if strings.HasPrefix(text[i:], "{{") {
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(text[i:], '}')
+
+		if end < 0 {
+			return nil, errors.Errorf("unterminated placeholder at offset %d", i)
+		}
+
+		names = append(names, text[i+1:i+end])
+		i += end
+	}
+
+	return names, nil
+}
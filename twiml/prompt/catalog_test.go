@@ -0,0 +1,144 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package prompt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+func testCatalog() *Catalog {
+	c := NewCatalog()
+
+	c.Set("welcome", twiml.LangEnglishUS, PluralOther, Translation{Text: "Welcome!", AudioURL: "https://example.org/en/welcome.mp3"})
+	c.Set("welcome", twiml.LangGermanGermany, PluralOther, Translation{Text: "Willkommen!", Voice: twiml.VoiceAlice})
+	c.Set("messages", twiml.LangEnglishUS, PluralOne, Translation{Text: "You have one new message, {name}."})
+	c.Set("messages", twiml.LangEnglishUS, PluralOther, Translation{Text: "You have {count} new messages, {name}.", AudioURL: "https://example.org/unused.mp3"})
+	c.Set("messages", twiml.LangRussianRussia, PluralOne, Translation{Text: "У вас {count} новое сообщение."})
+	c.Set("messages", twiml.LangRussianRussia, PluralFew, Translation{Text: "У вас {count} новых сообщения."})
+	c.Set("messages", twiml.LangRussianRussia, PluralMany, Translation{Text: "У вас {count} новых сообщений."})
+	c.Set("braces", twiml.LangEnglishUS, PluralOther, Translation{Text: "Press {{pound}} {key}"})
+
+	return c
+}
+
+func TestCatalog_Verb(t *testing.T) {
+	c := testCatalog()
+
+	tests := []struct {
+		desc string
+		id   string
+		lang twiml.Language
+		args Args
+		verb interface{}
+	}{
+		{
+			desc: "recording should be played",
+			id:   "welcome", lang: twiml.LangEnglishUS,
+			verb: &twiml.Play{URL: "https://example.org/en/welcome.mp3"},
+		},
+		{
+			desc: "translation without a recording should be spoken",
+			id:   "welcome", lang: twiml.LangGermanGermany,
+			verb: &twiml.Say{Message: "Willkommen!", Language: twiml.LangGermanGermany, Voice: twiml.VoiceAlice},
+		},
+		{
+			desc: "singular form should be chosen by count",
+			id:   "messages", lang: twiml.LangEnglishUS, args: Args{"count": 1, "name": "Ada"},
+			verb: &twiml.Say{Message: "You have one new message, Ada.", Language: twiml.LangEnglishUS},
+		},
+		{
+			desc: "prompt with placeholders should be spoken even with a recording",
+			id:   "messages", lang: twiml.LangEnglishUS, args: Args{"count": uint(3), "name": "Ada"},
+			verb: &twiml.Say{Message: "You have 3 new messages, Ada.", Language: twiml.LangEnglishUS},
+		},
+		{
+			desc: "Russian few form should be chosen by count",
+			id:   "messages", lang: twiml.LangRussianRussia, args: Args{"count": 22},
+			verb: &twiml.Say{Message: "У вас 22 новых сообщения.", Language: twiml.LangRussianRussia},
+		},
+		{
+			desc: "doubled braces should render literal braces",
+			id:   "braces", lang: twiml.LangEnglishUS, args: Args{"key": 1},
+			verb: &twiml.Say{Message: "Press {pound} 1", Language: twiml.LangEnglishUS},
+		},
+	}
+
+	for _, test := range tests {
+		verb, err := c.Verb(test.id, test.lang, test.args)
+
+		if err != nil {
+			t.Errorf("\nDescription: %s\nVerb() Unexpected Error: %s", test.desc, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(verb, test.verb) {
+			t.Errorf("\nDescription: %s\nVerb() = %#v, want %#v", test.desc, verb, test.verb)
+		}
+	}
+}
+
+func TestCatalog_Verb_Errors(t *testing.T) {
+	c := testCatalog()
+
+	if _, err := c.Verb("welcome", twiml.LangFrenchFrance, nil); errors.Cause(err) != ErrNotFound {
+		t.Errorf("Verb() for a missing language error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := c.Verb("messages", twiml.LangEnglishUS, Args{"count": 2}); err == nil {
+		t.Error("Verb() without a placeholder value expected an error")
+	}
+
+	if _, err := c.Verb("messages", twiml.LangEnglishUS, Args{"count": "two", "name": "Ada"}); err == nil {
+		t.Error("Verb() with a non-integer count expected an error")
+	}
+}
+
+func TestCatalog_Text(t *testing.T) {
+	text, err := testCatalog().Text("welcome", twiml.LangEnglishUS, nil)
+
+	if err != nil {
+		t.Fatalf("Text() Unexpected Error: %s", err)
+	}
+
+	if text != "Welcome!" {
+		t.Errorf("Text() = %q, want %q", text, "Welcome!")
+	}
+}
+
+func TestCatalog_Missing(t *testing.T) {
+	missing := testCatalog().Missing(twiml.LangEnglishUS, twiml.LangGermanGermany)
+
+	want := []Missing{
+		{ID: "braces", Language: twiml.LangGermanGermany},
+		{ID: "messages", Language: twiml.LangGermanGermany},
+	}
+
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("Missing() = %v, want %v", missing, want)
+	}
+
+	c := NewCatalog()
+	c.Set("items", twiml.LangEnglishUS, PluralOne, Translation{Text: "one item"})
+	c.Set("items", twiml.LangPolishPoland, PluralOther, Translation{Text: "{count} przedmiotów"})
+
+	missing = c.Missing(twiml.LangEnglishUS, twiml.LangPolishPoland)
+
+	want = []Missing{
+		{ID: "items", Language: twiml.LangEnglishUS, Form: PluralOther},
+		{ID: "items", Language: twiml.LangPolishPoland, Form: PluralOne},
+		{ID: "items", Language: twiml.LangPolishPoland, Form: PluralFew},
+		{ID: "items", Language: twiml.LangPolishPoland, Form: PluralMany},
+	}
+
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("Missing() = %v, want %v", missing, want)
+	}
+}
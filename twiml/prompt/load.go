@@ -0,0 +1,133 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package prompt
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// LoadCSV loads a Catalog from a CSV file, such as a spreadsheet of prompts
+// exported as CSV. The first row is a header naming the columns, which may be
+// in any order and are matched case-insensitively:
+//
+//	id        the message ID (required)
+//	language  the TwiML language, such as en-US (required)
+//	text      the text of the prompt, with any {name} placeholders (required)
+//	plural    the CLDR plural form: one, few, many, or other (optional)
+//	audio     the URL of a recording of the prompt (optional)
+//	voice     the voice to speak the text in, such as alice (optional)
+//
+// Other columns, such as notes for translators, are ignored, as are rows with
+// an empty id. If any languages are provided, every message must be translated
+// in to each of them, in every plural form it needs; if not, the error's cause
+// is a *MissingError listing all missing translations. This function returns a
+// wrapped error (see package documentation for more info).
+func LoadCSV(r io.Reader, languages ...twiml.Language) (*Catalog, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+
+	if err != nil {
+		return nil, errors.Wrap(err, "reading CSV header failed")
+	}
+
+	cols := make(map[string]int)
+
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"id", "language", "text"} {
+		if _, ok := cols[name]; !ok {
+			return nil, errors.Errorf("CSV header is missing the %s column", name)
+		}
+	}
+
+	c := NewCatalog()
+	seen := make(map[Missing]bool)
+
+	var lines lineCounter
+
+	for {
+		record, err := cr.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "reading CSV failed")
+		}
+
+		line := lines.line(cr, record)
+
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		id := field("id")
+
+		if id == "" {
+			continue
+		}
+
+		lang, err := twiml.ParseLanguage(field("language"))
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		if lang == twiml.LangDefault {
+			return nil, errors.Errorf("line %d: %s has no language", line, id)
+		}
+
+		form, err := ParsePluralForm(field("plural"))
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		voice, err := twiml.ParseVoice(field("voice"))
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		// keep the text as it's written, other than surrounding whitespace
+		text := field("text")
+
+		if _, err := placeholders(text); err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+
+		key := Missing{ID: id, Language: lang, Form: form}
+
+		if seen[key] {
+			return nil, errors.Errorf("line %d: duplicate translation of %s", line, key)
+		}
+
+		seen[key] = true
+
+		c.Set(id, lang, form, Translation{Text: text, AudioURL: field("audio"), Voice: voice})
+	}
+
+	if missing := c.Missing(languages...); len(missing) > 0 {
+		return nil, errors.Wrap(&MissingError{Missing: missing}, "loading prompts failed")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,21 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+//go:build go1.17
+// +build go1.17
+
+package prompt
+
+import "encoding/csv"
+
+// lineCounter finds the line each record of a CSV file starts on.
+type lineCounter struct{}
+
+// line returns the line the record last read by cr starts on.
+func (lineCounter) line(cr *csv.Reader, _ []string) int {
+	line, _ := cr.FieldPos(0)
+	return line
+}
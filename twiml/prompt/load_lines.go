@@ -0,0 +1,40 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+//go:build !go1.17
+// +build !go1.17
+
+package prompt
+
+import (
+	"encoding/csv"
+	"strings"
+)
+
+// lineCounter finds the line each record of a CSV file starts on, by counting
+// the newlines in the fields of the records read so far. Before Go 1.17 the
+// csv package doesn't report positions, so lines it skips, such as blank
+// lines, aren't counted.
+type lineCounter struct {
+	next int
+}
+
+// line returns the line the record last read by cr starts on. It must be
+// called once for each record.
+func (l *lineCounter) line(_ *csv.Reader, record []string) int {
+	if l.next == 0 {
+		l.next = 2 // the line after the header
+	}
+
+	line := l.next
+	l.next++
+
+	for _, field := range record {
+		l.next += strings.Count(field, "\n")
+	}
+
+	return line
+}
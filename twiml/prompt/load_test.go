@@ -0,0 +1,100 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package prompt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+const testCSV = `Notes,ID,Language,Plural,Text,Audio,Voice
+greeting for all callers,welcome,en-US,,Welcome!,https://example.org/en/welcome.mp3,
+,welcome,de-DE,,Willkommen!,,alice
+,,,,,,
+,messages,en-US,one,"You have one message, {name}.",,
+,messages,en-US,other,"You have {count} messages, {name}.",,
+,messages,de-DE,one,"Sie haben eine Nachricht, {name}.",,
+,messages,de-DE,other,"Sie haben {count} Nachrichten, {name}.",,
+`
+
+func TestLoadCSV(t *testing.T) {
+	c, err := LoadCSV(strings.NewReader(testCSV), twiml.LangEnglishUS, twiml.LangGermanGermany)
+
+	if err != nil {
+		t.Fatalf("LoadCSV() Unexpected Error: %s", err)
+	}
+
+	if ids := c.IDs(); !reflect.DeepEqual(ids, []string{"messages", "welcome"}) {
+		t.Errorf("IDs() = %v, want [messages welcome]", ids)
+	}
+
+	verb, err := c.Verb("welcome", twiml.LangGermanGermany, nil)
+
+	if err != nil {
+		t.Fatalf("Verb() Unexpected Error: %s", err)
+	}
+
+	want := &twiml.Say{Message: "Willkommen!", Language: twiml.LangGermanGermany, Voice: twiml.VoiceAlice}
+
+	if !reflect.DeepEqual(verb, want) {
+		t.Errorf("Verb() = %#v, want %#v", verb, want)
+	}
+}
+
+func TestLoadCSV_Missing(t *testing.T) {
+	_, err := LoadCSV(strings.NewReader(testCSV), twiml.LangEnglishUS, twiml.LangRussianRussia)
+
+	me, ok := errors.Cause(err).(*MissingError)
+
+	if !ok {
+		t.Fatalf("LoadCSV() error cause = %#v, want *MissingError", errors.Cause(err))
+	}
+
+	want := []Missing{
+		{ID: "messages", Language: twiml.LangRussianRussia},
+		{ID: "welcome", Language: twiml.LangRussianRussia},
+	}
+
+	if !reflect.DeepEqual(me.Missing, want) {
+		t.Errorf("MissingError.Missing = %v, want %v", me.Missing, want)
+	}
+}
+
+func TestLoadCSV_Errors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{"missing column should fail", "id,language\n", "missing the text column"},
+		{"unknown language should fail", "id,language,text\nhi,xx-XX,Hi\n", "line 2"},
+		{"missing language should fail", "id,language,text\nhi,,Hi\n", "line 2: hi has no language"},
+		{"unknown plural form should fail", "id,language,text,plural\nhi,en-US,Hi,dual\n", "line 2"},
+		{"unknown voice should fail", "id,language,text,voice\nhi,en-US,Hi,robot\n", "line 2"},
+		{"unterminated placeholder should fail", "id,language,text\nhi,en-US,Hi {name\n", "line 2: unterminated placeholder"},
+		{"duplicate translation should fail", "id,language,text\nhi,en-US,Hi\nhi,en-US,Hello\n", "line 3: duplicate translation of hi (en-US)"},
+		{
+			"line should count the lines of multi-line fields",
+			"id,language,text\nhi,en-US,\"Hi\nthere\"\nbye,en-US,Bye\nbye,en-US,\"Bye\r\nnow\"\n",
+			"line 5: duplicate translation of bye (en-US)",
+		},
+		{"line should be where a multi-line record starts", "id,language,text\nhi,xx-XX,\"Hi\nthere\"\n", "line 2"},
+		{"empty input should fail", "", "reading CSV header failed"},
+	}
+
+	for _, test := range tests {
+		_, err := LoadCSV(strings.NewReader(test.in))
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("\nDescription: %s\nLoadCSV() error = %v, want it to contain %q", test.desc, err, test.err)
+		}
+	}
+}
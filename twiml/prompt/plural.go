@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package prompt
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// PluralForm is a grammatical plural category, as defined by the Unicode CLDR
+// plural rules. Which forms a language uses, and which counts select each
+// form, depends on the language.
+type PluralForm uint8
+
+const (
+	// PluralOther is the form used for any count without a more specific
+	// form in the language. It's the only form of messages that aren't
+	// pluralized.
+	PluralOther PluralForm = iota
+
+	// PluralOne is the singular form, e.g. "1 message".
+	PluralOne
+
+	// PluralFew is the paucal form used by some Slavic languages, e.g. for
+	// counts ending in 2 through 4 in Russian.
+	PluralFew
+
+	// PluralMany is the form used by some Slavic languages for the remaining
+	// counts, e.g. 5 through 20 in Russian.
+	PluralMany
+)
+
+func (p PluralForm) String() string {
+	switch p {
+	case PluralOther:
+		return "other"
+	case PluralOne:
+		return "one"
+	case PluralFew:
+		return "few"
+	case PluralMany:
+		return "many"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePluralForm returns the PluralForm with the CLDR name s, such as "one"
+// or "few". The empty string parses to PluralOther. This function returns a
+// wrapped error (see the twiml package documentation for more info).
+func ParsePluralForm(s string) (PluralForm, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "other":
+		return PluralOther, nil
+	case "one":
+		return PluralOne, nil
+	case "few":
+		return PluralFew, nil
+	case "many":
+		return PluralMany, nil
+	default:
+		return PluralOther, errors.Wrapf(twiml.ErrUnknownValue, "parsing PluralForm %q", s)
+	}
+}
+
+// pluralRule selects the form for a count. The rules only need to handle
+// integer counts, as prompts count things callers can have a whole number of.
+type pluralRule struct {
+	forms  []PluralForm
+	choose func(n uint64) PluralForm
+}
+
+var (
+	// e.g., English: 1 message, 2 messages
+	ruleOne = pluralRule{
+		forms: []PluralForm{PluralOne, PluralOther},
+		choose: func(n uint64) PluralForm {
+			if n == 1 {
+				return PluralOne
+			}
+
+			return PluralOther
+		},
+	}
+
+	// e.g., French: 0 message, 1 message, 2 messages
+	ruleZeroOne = pluralRule{
+		forms: []PluralForm{PluralOne, PluralOther},
+		choose: func(n uint64) PluralForm {
+			if n <= 1 {
+				return PluralOne
+			}
+
+			return PluralOther
+		},
+	}
+
+	// Chinese, Japanese, and Korean don't inflect for number
+	ruleNone = pluralRule{
+		forms:  []PluralForm{PluralOther},
+		choose: func(uint64) PluralForm { return PluralOther },
+	}
+
+	ruleRussian = pluralRule{
+		forms: []PluralForm{PluralOne, PluralFew, PluralMany},
+		choose: func(n uint64) PluralForm {
+			switch mod10, mod100 := n%10, n%100; {
+			case mod10 == 1 && mod100 != 11:
+				return PluralOne
+			case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		},
+	}
+
+	rulePolish = pluralRule{
+		forms: []PluralForm{PluralOne, PluralFew, PluralMany},
+		choose: func(n uint64) PluralForm {
+			switch mod10, mod100 := n%10, n%100; {
+			case n == 1:
+				return PluralOne
+			case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+				return PluralFew
+			default:
+				return PluralMany
+			}
+		},
+	}
+)
+
+// pluralRuleFor returns the plural rule of the language.
+func pluralRuleFor(lang twiml.Language) pluralRule {
+	switch lang {
+	case twiml.LangFrenchCanada, twiml.LangFrenchFrance, twiml.LangPortugeseBrazil:
+		return ruleZeroOne
+	case twiml.LangChineseCantonese, twiml.LangChineseMandarin, twiml.LangChineseTaiwaneseMandarin,
+		twiml.LangJapaneseJapan, twiml.LangKoreanKorea:
+		return ruleNone
+	case twiml.LangRussianRussia:
+		return ruleRussian
+	case twiml.LangPolishPoland:
+		return rulePolish
+	default:
+		return ruleOne
+	}
+}
+
+// PluralFormFor returns the PluralForm the language uses for the count n.
+func PluralFormFor(lang twiml.Language, n uint64) PluralForm {
+	return pluralRuleFor(lang).choose(n)
+}
+
+// PluralForms returns the forms a pluralized message needs in the language.
+func PluralForms(lang twiml.Language) []PluralForm {
+	forms := pluralRuleFor(lang).forms
+	out := make([]PluralForm, len(forms))
+	copy(out, forms)
+
+	return out
+}
@@ -0,0 +1,79 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package prompt
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+func TestPluralFormFor(t *testing.T) {
+	tests := []struct {
+		desc string
+		lang twiml.Language
+		n    uint64
+		form PluralForm
+	}{
+		{"English 0 should be other", twiml.LangEnglishUS, 0, PluralOther},
+		{"English 1 should be one", twiml.LangEnglishUK, 1, PluralOne},
+		{"English 2 should be other", twiml.LangEnglishUS, 2, PluralOther},
+		{"French 0 should be one", twiml.LangFrenchFrance, 0, PluralOne},
+		{"French 2 should be other", twiml.LangFrenchCanada, 2, PluralOther},
+		{"Japanese 1 should be other", twiml.LangJapaneseJapan, 1, PluralOther},
+		{"Russian 1 should be one", twiml.LangRussianRussia, 1, PluralOne},
+		{"Russian 21 should be one", twiml.LangRussianRussia, 21, PluralOne},
+		{"Russian 11 should be many", twiml.LangRussianRussia, 11, PluralMany},
+		{"Russian 3 should be few", twiml.LangRussianRussia, 3, PluralFew},
+		{"Russian 13 should be many", twiml.LangRussianRussia, 13, PluralMany},
+		{"Russian 5 should be many", twiml.LangRussianRussia, 5, PluralMany},
+		{"Polish 1 should be one", twiml.LangPolishPoland, 1, PluralOne},
+		{"Polish 21 should be many", twiml.LangPolishPoland, 21, PluralMany},
+		{"Polish 22 should be few", twiml.LangPolishPoland, 22, PluralFew},
+	}
+
+	for _, test := range tests {
+		if form := PluralFormFor(test.lang, test.n); form != test.form {
+			t.Errorf("\nDescription: %s\nPluralFormFor() = %s, want %s", test.desc, form, test.form)
+		}
+	}
+}
+
+func TestParsePluralForm(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		form PluralForm
+		err  bool
+	}{
+		{"empty string should be other", "", PluralOther, false},
+		{"name should be case-insensitive", " Few ", PluralFew, false},
+		{"unknown name should fail", "dual", PluralOther, true},
+	}
+
+	for _, test := range tests {
+		form, err := ParsePluralForm(test.in)
+
+		if test.err {
+			if errors.Cause(err) != twiml.ErrUnknownValue {
+				t.Errorf("\nDescription: %s\nParsePluralForm() error = %v, want twiml.ErrUnknownValue", test.desc, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("\nDescription: %s\nParsePluralForm() Unexpected Error: %s", test.desc, err)
+			continue
+		}
+
+		if form != test.form {
+			t.Errorf("\nDescription: %s\nParsePluralForm() = %s, want %s", test.desc, form, test.form)
+		}
+	}
+}
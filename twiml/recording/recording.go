@@ -0,0 +1,175 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package recording consumes the callbacks made to the RecordingStatusCallback
+// URL of a Record, a Dial, or the Conference noun of a Dial, and to the
+// TranscribeCallback URL of a Record. Its Handler parses the callbacks and
+// calls the function handling recordings or transcriptions, so a single URL
+// can be used for both:
+//
+//	h := recording.New()
+//
+//	h.HandleRecording(func(ctx context.Context, e *twilio.RecordingStatusEvent) error {
+//		if e.RecordingStatus != twilio.RecordingStatusCompleted {
+//			return nil
+//		}
+//
+//		mp3, err := e.MediaURL(twilio.MediaMP3)
+//		...
+//	})
+//
+//	h.HandleTranscription(func(ctx context.Context, e *twilio.TranscriptionEvent) error {
+//		return saveVoicemailText(ctx, e.RecordingSid, e.TranscriptionText)
+//	})
+//
+//	http.Handle("/voice/recording", h)
+package recording
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+)
+
+// RecordingFunc is a function that handles a recording status event.
+type RecordingFunc func(ctx context.Context, e *twilio.RecordingStatusEvent) error
+
+// TranscriptionFunc is a function that handles a transcription event.
+type TranscriptionFunc func(ctx context.Context, e *twilio.TranscriptionEvent) error
+
+// Option configures optional behavior of a Handler.
+type Option func(*Handler)
+
+// WithErrorHook sets a function that's called with the error whenever a
+// callback can't be parsed or its function fails, such as to log it.
+func WithErrorHook(fn func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// Handler is an http.Handler for recording status and transcription
+// callbacks, which tells them apart by whether they have a TranscriptionSid.
+// It responds with 204 No Content once the event has been handled, 400 Bad
+// Request to callbacks that can't be parsed, and 500 Internal Server Error
+// when the function handling it fails.
+type Handler struct {
+	onError func(*http.Request, error)
+
+	mu            sync.RWMutex
+	recording     RecordingFunc
+	transcription TranscriptionFunc
+}
+
+// New returns a new *Handler.
+func New(opts ...Option) *Handler {
+	h := &Handler{}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// HandleRecording sets the function that handles recording status events.
+func (h *Handler) HandleRecording(fn RecordingFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.recording = fn
+}
+
+// HandleTranscription sets the function that handles transcription events.
+func (h *Handler) HandleTranscription(fn TranscriptionFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.transcription = fn
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.fail(w, r, errors.Wrap(err, "handling recording callback failed: parsing form failed"), http.StatusBadRequest)
+		return
+	}
+
+	var code int
+	var err error
+
+	if r.Form.Get("TranscriptionSid") != "" {
+		code, err = h.serveTranscription(r)
+	} else {
+		code, err = h.serveRecording(r)
+	}
+
+	if err != nil {
+		h.fail(w, r, err, code)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) serveRecording(r *http.Request) (int, error) {
+	e, err := twilio.ParseRecordingStatusEvent(r)
+
+	if err == nil && e.RecordingSid == "" {
+		err = errors.New("missing RecordingSid")
+	}
+
+	if err != nil {
+		return http.StatusBadRequest, errors.Wrap(err, "handling recording status event failed")
+	}
+
+	h.mu.RLock()
+	fn := h.recording
+	h.mu.RUnlock()
+
+	if fn == nil {
+		return 0, nil
+	}
+
+	if err := fn(r.Context(), e); err != nil {
+		return http.StatusInternalServerError, errors.Wrapf(err, "handling %s event of %s failed", e.RecordingStatus, e.RecordingSid)
+	}
+
+	return 0, nil
+}
+
+func (h *Handler) serveTranscription(r *http.Request) (int, error) {
+	e, err := twilio.ParseTranscriptionEvent(r)
+
+	if err != nil {
+		return http.StatusBadRequest, errors.Wrap(err, "handling transcription event failed")
+	}
+
+	h.mu.RLock()
+	fn := h.transcription
+	h.mu.RUnlock()
+
+	if fn == nil {
+		return 0, nil
+	}
+
+	if err := fn(r.Context(), e); err != nil {
+		return http.StatusInternalServerError, errors.Wrapf(err, "handling transcription %s failed", e.TranscriptionSid)
+	}
+
+	return 0, nil
+}
+
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error, code int) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+
+	http.Error(w, http.StatusText(code), code)
+}
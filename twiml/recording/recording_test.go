@@ -0,0 +1,136 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package recording
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+)
+
+const (
+	testRecordingSid     = "RE0123456789abcdef0123456789abcdef"
+	testTranscriptionSid = "TR0123456789abcdef0123456789abcdef"
+)
+
+func callbackRequest(params url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "http://example.com/voice/recording", strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	var got []string
+
+	h := New()
+
+	h.HandleRecording(func(_ context.Context, e *twilio.RecordingStatusEvent) error {
+		got = append(got, "recording "+e.RecordingStatus.String())
+		return nil
+	})
+
+	h.HandleTranscription(func(_ context.Context, e *twilio.TranscriptionEvent) error {
+		got = append(got, "transcription "+e.TranscriptionText)
+		return nil
+	})
+
+	requests := []url.Values{
+		{"RecordingSid": {testRecordingSid}, "RecordingStatus": {"completed"}},
+		{"RecordingSid": {testRecordingSid}, "TranscriptionSid": {testTranscriptionSid}, "TranscriptionText": {"hello"}},
+	}
+
+	for _, params := range requests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, callbackRequest(params))
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+	}
+
+	if len(got) != 2 || got[0] != "recording completed" || got[1] != "transcription hello" {
+		t.Errorf("handled %q, want the recording and then the transcription", got)
+	}
+}
+
+func TestHandler_errors(t *testing.T) {
+	var hooked []string
+
+	h := New(WithErrorHook(func(_ *http.Request, err error) {
+		hooked = append(hooked, err.Error())
+	}))
+
+	h.HandleRecording(func(context.Context, *twilio.RecordingStatusEvent) error {
+		return errors.New("storage is down")
+	})
+
+	h.HandleTranscription(func(context.Context, *twilio.TranscriptionEvent) error {
+		return errors.New("storage is down")
+	})
+
+	tests := []struct {
+		desc   string
+		params url.Values
+		code   int
+		err    string
+	}{
+		{
+			desc:   "missing RecordingSid should be a bad request",
+			params: url.Values{"RecordingStatus": {"completed"}},
+			code:   http.StatusBadRequest,
+			err:    "handling recording status event failed: missing RecordingSid",
+		},
+		{
+			desc:   "malformed transcription should be a bad request",
+			params: url.Values{"TranscriptionSid": {testTranscriptionSid}, "TranscriptionStatus": {"pending"}},
+			code:   http.StatusBadRequest,
+			err:    `handling transcription event failed: parsing transcription event failed: parameter TranscriptionStatus "pending": unknown value`,
+		},
+		{
+			desc:   "RecordingFunc error should be an internal server error",
+			params: url.Values{"RecordingSid": {testRecordingSid}, "RecordingStatus": {"failed"}},
+			code:   http.StatusInternalServerError,
+			err:    "handling failed event of " + testRecordingSid + " failed: storage is down",
+		},
+		{
+			desc:   "TranscriptionFunc error should be an internal server error",
+			params: url.Values{"TranscriptionSid": {testTranscriptionSid}},
+			code:   http.StatusInternalServerError,
+			err:    "handling transcription " + testTranscriptionSid + " failed: storage is down",
+		},
+	}
+
+	for _, test := range tests {
+		hooked = nil
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, callbackRequest(test.params))
+
+		if w.Code != test.code {
+			t.Errorf("\nDescription: %s\nstatus = %d, want %d", test.desc, w.Code, test.code)
+		}
+
+		if len(hooked) != 1 || hooked[0] != test.err {
+			t.Errorf("\nDescription: %s\nerror hook got %q, want %q", test.desc, hooked, test.err)
+		}
+	}
+}
+
+func TestHandler_noFunc(t *testing.T) {
+	w := httptest.NewRecorder()
+	New().ServeHTTP(w, callbackRequest(url.Values{"RecordingSid": {testRecordingSid}}))
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status without a RecordingFunc = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,206 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRedactMask is the value sensitive content is replaced with when a
+// RedactPolicy does not specify its own Mask.
+const DefaultRedactMask = "[REDACTED]"
+
+// RedactPolicy describes which parts of a TwiML document are sensitive, and
+// should be masked when the document is rendered for logging.
+type RedactPolicy struct {
+	// Mask is the value that sensitive content is replaced with. If empty,
+	// DefaultRedactMask is used.
+	Mask string
+
+	// Attributes is the list of sensitive attributes, in the form of
+	// "Element.attribute" using the names as they are rendered in the XML
+	// (e.g., "Sip.password"). An element name of "*" matches all elements.
+	Attributes []string
+
+	// Bodies is the list of elements whose character data is sensitive, such
+	// as "Say" or "Sms".
+	Bodies []string
+}
+
+// DefaultRedactPolicy is the RedactPolicy used by the String(), Format(), and
+// LogValue() methods of *Response. It masks the SIP credentials of the DialSIP
+// noun and the values of Parameter nouns, which may carry payment details
+// within Pay, but leaves message bodies untouched. Applications that speak or
+// send PII can add "Say" and "Sms" to the Bodies field.
+var DefaultRedactPolicy = RedactPolicy{
+	Attributes: []string{"Sip.username", "Sip.password", "Parameter.value"},
+}
+
+// Redact returns a copy of the *Response with all sensitive attributes and
+// bodies replaced by the policy's mask. The original is not modified. Only
+// non-empty string fields are masked, so omitted attributes stay omitted.
+func (p RedactPolicy) Redact(r *Response) *Response {
+	c := Clone(r)
+
+	// the TransformFunc never returns an error
+	_ = Transform(c, func(_ Path, node interface{}) (interface{}, error) {
+		return p.redactNode(node), nil
+	})
+
+	return c
+}
+
+func (p RedactPolicy) mask() string {
+	if p.Mask == "" {
+		return DefaultRedactMask
+	}
+
+	return p.Mask
+}
+
+func (p RedactPolicy) sensitiveAttr(element, attr string) bool {
+	for _, a := range p.Attributes {
+		i := strings.LastIndex(a, ".")
+
+		if i < 0 || a[i+1:] != attr {
+			continue
+		}
+
+		if e := a[:i]; e == "*" || e == element {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p RedactPolicy) sensitiveBody(element string) bool {
+	for _, b := range p.Bodies {
+		if b == element {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactNode masks the sensitive fields of node. Pointers are modified in
+// place, so node must be owned by the caller (e.g., from Clone).
+func (p RedactPolicy) redactNode(node interface{}) interface{} {
+	v := reflect.ValueOf(node)
+	isPtr := v.Kind() == reflect.Ptr
+
+	if isPtr {
+		if v.IsNil() {
+			return node
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return node
+	}
+
+	if !isPtr {
+		// values stored in an interface{} aren't addressable
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+
+	element := NodeName(node)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+
+		if fv.Kind() != reflect.String || fv.Len() == 0 || !fv.CanSet() {
+			continue
+		}
+
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("xml"), ",")
+
+		if len(tag) < 2 {
+			continue
+		}
+
+		name := tag[0]
+
+		if name == "" {
+			name = f.Name
+		}
+
+		switch tag[1] {
+		case "attr":
+			if p.sensitiveAttr(element, name) {
+				fv.SetString(p.mask())
+			}
+		case "chardata":
+			if p.sensitiveBody(element) {
+				fv.SetString(p.mask())
+			}
+		}
+	}
+
+	if isPtr {
+		return node
+	}
+
+	return v.Interface()
+}
+
+// EncodeRedacted encodes a copy of the *Response with the sensitive content
+// described by p masked, writing it to w. This function returns a wrapped
+// error (see package documentation for more info).
+func EncodeRedacted(w io.Writer, r *Response, p RedactPolicy) error {
+	if err := EncodeResponse(w, p.Redact(r)); err != nil {
+		return errors.Wrap(err, "encoding redacted response failed")
+	}
+
+	return nil
+}
+
+// MarshalRedacted renders a copy of the *Response to XML, with the sensitive
+// content described by p masked. This function returns a wrapped error (see
+// package documentation for more info).
+func MarshalRedacted(r *Response, p RedactPolicy) ([]byte, error) {
+	return MarshalResponse(p.Redact(r))
+}
+
+// String renders the *Response to XML using DefaultRedactPolicy, making it safe
+// to include in logs. Use MarshalResponse to render the document for Twilio.
+func (r *Response) String() string {
+	b, err := MarshalRedacted(r, DefaultRedactPolicy)
+
+	if err != nil {
+		return "<invalid TwiML: " + err.Error() + ">"
+	}
+
+	return string(b)
+}
+
+// Format implements the fmt.Formatter interface, so that all verbs (including
+// %#v) print the redacted rendering from String() instead of the raw struct
+// fields. The %q verb prints the rendering as a quoted string.
+func (r *Response) Format(f fmt.State, verb rune) {
+	s := r.String()
+
+	if verb == 'q' {
+		s = strconv.Quote(s)
+	}
+
+	// the fmt.State writer is never expected to fail
+	_, _ = io.WriteString(f, s)
+}
@@ -0,0 +1,18 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+//go:build go1.21
+// +build go1.21
+
+package twiml
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface, logging the *Response as
+// its XML rendering with DefaultRedactPolicy applied.
+func (r *Response) LogValue() slog.Value {
+	return slog.StringValue(r.String())
+}
@@ -0,0 +1,28 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+//go:build go1.21
+// +build go1.21
+
+package twiml
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestResponse_LogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, nil))
+
+	logger.Info("rendered", "twiml", redactTestResponse())
+
+	if out := buf.String(); strings.Contains(out, "hunter2") || !strings.Contains(out, "[REDACTED]") {
+		t.Errorf("slog output = %s; want redacted rendering", out)
+	}
+}
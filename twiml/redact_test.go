@@ -0,0 +1,129 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func redactTestResponse() *Response {
+	return &Response{
+		Verbs: []interface{}{
+			&Say{Message: "Your PIN is 1234"},
+			Sms{Message: "Your code is 9876", To: "+14155555555"},
+			&Dial{
+				Nouns: []interface{}{
+					&DialSIP{URI: "sip:alice@example.org", Username: "alice", Password: "hunter2"},
+					&DialSIP{URI: "sip:bob@example.org"},
+				},
+			},
+			&Pay{
+				Nouns: []interface{}{
+					&Parameter{Name: "card", Value: "4111111111111111"},
+				},
+			},
+		},
+	}
+}
+
+func TestRedactPolicy_Redact(t *testing.T) {
+	tests := []struct {
+		desc     string
+		policy   RedactPolicy
+		contains []string
+		excludes []string
+	}{
+		{
+			"DefaultRedactPolicy should mask SIP credentials",
+			DefaultRedactPolicy,
+			[]string{`username="[REDACTED]"`, `password="[REDACTED]"`, "Your PIN is 1234", "Your code is 9876"},
+			[]string{"alice\"", "hunter2"},
+		},
+		{
+			"DefaultRedactPolicy should mask Parameter values within Pay",
+			DefaultRedactPolicy,
+			[]string{`<Parameter name="card" value="[REDACTED]"></Parameter>`},
+			[]string{"4111111111111111"},
+		},
+		{
+			"Policy with Bodies should mask Say and Sms messages",
+			RedactPolicy{Mask: "***", Bodies: []string{"Say", "Sms"}},
+			[]string{"<Say>***</Say>", `<Sms to="+14155555555">***</Sms>`, "hunter2"},
+			[]string{"1234", "9876"},
+		},
+		{
+			"Wildcard element should mask attribute on all elements",
+			RedactPolicy{Attributes: []string{"*.to", "*.password"}},
+			[]string{`to="[REDACTED]"`, `password="[REDACTED]"`},
+			[]string{"+14155555555", "hunter2"},
+		},
+		{
+			"Empty attributes should not be rendered",
+			DefaultRedactPolicy,
+			[]string{`<Sip hangupOnStar="false" answerOnBridge="false">sip:bob@example.org</Sip>`},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		b, err := MarshalRedacted(redactTestResponse(), test.policy)
+
+		if err != nil {
+			t.Errorf("\nDescription: %s\nMarshalRedacted() Unexpected Error: %s", test.desc, err)
+			continue
+		}
+
+		out := string(b)
+
+		for _, s := range test.contains {
+			if !strings.Contains(out, s) {
+				t.Errorf("\nDescription: %s\nRendered XML:\n%s\nshould contain %q", test.desc, out, s)
+			}
+		}
+
+		for _, s := range test.excludes {
+			if strings.Contains(out, s) {
+				t.Errorf("\nDescription: %s\nRendered XML:\n%s\nshould not contain %q", test.desc, out, s)
+			}
+		}
+	}
+}
+
+func TestRedactPolicy_Redact_original(t *testing.T) {
+	resp := redactTestResponse()
+	_ = RedactPolicy{Bodies: []string{"Say", "Sms"}, Attributes: []string{"*.password"}}.Redact(resp)
+
+	if m := resp.Verbs[0].(*Say).Message; m != "Your PIN is 1234" {
+		t.Errorf("original Say.Message = %q; want unchanged", m)
+	}
+
+	if m := resp.Verbs[1].(Sms).Message; m != "Your code is 9876" {
+		t.Errorf("original Sms.Message = %q; want unchanged", m)
+	}
+
+	if p := resp.Verbs[2].(*Dial).Nouns[0].(*DialSIP).Password; p != "hunter2" {
+		t.Errorf("original DialSIP.Password = %q; want unchanged", p)
+	}
+}
+
+func TestResponse_Format(t *testing.T) {
+	resp := redactTestResponse()
+
+	for _, verb := range []string{"%v", "%s", "%+v", "%#v", "%q"} {
+		out := fmt.Sprintf(verb, resp)
+
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("fmt.Sprintf(%q, resp) leaked the SIP password:\n%s", verb, out)
+		}
+
+		if !strings.Contains(out, "[REDACTED]") {
+			t.Errorf("fmt.Sprintf(%q, resp) = %s; want redacted rendering", verb, out)
+		}
+	}
+}
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // RejectReason specifies the rejection reason for a rejected call.
 type RejectReason uint8
@@ -30,6 +35,11 @@ func (r RejectReason) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (r *RejectReason) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.Set(attr.Value)
+}
+
 func (r RejectReason) String() string {
 	switch r {
 	case RejectReasonRejected:
@@ -40,3 +50,52 @@ func (r RejectReason) String() string {
 		return ""
 	}
 }
+
+// RejectReasonValues returns all of the RejectReason constants that render a
+// value, for listing the valid choices in configuration or help text.
+func RejectReasonValues() []RejectReason {
+	return []RejectReason{
+		RejectReasonRejected,
+		RejectReasonBusy,
+	}
+}
+
+// ParseRejectReason returns the RejectReason whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseRejectReason(s string) (RejectReason, error) {
+	if s == "" {
+		return RejectReason(0), nil
+	}
+
+	for _, v := range RejectReasonValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return RejectReason(0), unknownValue("RejectReason", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r RejectReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *RejectReason) UnmarshalText(text []byte) error {
+	return r.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (r *RejectReason) Set(value string) error {
+	parsed, err := ParseRejectReason(value)
+
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // RingTone lets you record both legs of a call within the associated Dial verb. Recordings are available in two options: mono-channel or dual-channel.
 type RingTone uint8
@@ -149,8 +154,15 @@ func (r RingTone) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (r *RingTone) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.Set(attr.Value)
+}
+
 func (r RingTone) String() string {
 	switch r {
+	case RingToneAutomatic:
+		return "automatic"
 	case RingToneAustralia:
 		return "au"
 	case RingToneAustria:
@@ -187,10 +199,10 @@ func (r RingTone) String() string {
 		return "in"
 	case RingToneItaly:
 		return "it"
-	case RingToneJapan:
-		return "jp"
 	case RingToneLithuania:
 		return "lt"
+	case RingToneJapan:
+		return "jp"
 	case RingToneMexico:
 		return "mx"
 	case RingToneMalaysia:
@@ -231,9 +243,95 @@ func (r RingTone) String() string {
 		return "ve"
 	case RingToneSouthAfrica:
 		return "za"
-	case RingToneAutomatic:
-		return "automatic"
 	default:
 		return ""
 	}
 }
+
+// RingToneValues returns all of the RingTone constants that render a value, for
+// listing the valid choices in configuration or help text.
+func RingToneValues() []RingTone {
+	return []RingTone{
+		RingToneAutomatic,
+		RingToneAustralia,
+		RingToneAustria,
+		RingToneBelgium,
+		RingToneBulgaria,
+		RingToneBrazil,
+		RingToneChile,
+		RingToneChina,
+		RingToneCzechia,
+		RingToneDenmark,
+		RingToneEstonia,
+		RingToneFinland,
+		RingToneFrance,
+		RingToneGreece,
+		RingToneGermany,
+		RingToneHungary,
+		RingToneIsrael,
+		RingToneIndia,
+		RingToneItaly,
+		RingToneLithuania,
+		RingToneJapan,
+		RingToneMexico,
+		RingToneMalaysia,
+		RingToneNetherlands,
+		RingToneNorway,
+		RingToneNewZealand,
+		RingTonePhilippines,
+		RingTonePoland,
+		RingTonePortugal,
+		RingToneRussia,
+		RingToneSingapore,
+		RingToneSpain,
+		RingToneSweden,
+		RingToneSwitzerland,
+		RingToneTaiwan,
+		RingToneThailand,
+		RingToneUK,
+		RingToneUS,
+		RingToneUSOld,
+		RingToneVenezuela,
+		RingToneSouthAfrica,
+	}
+}
+
+// ParseRingTone returns the RingTone whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseRingTone(s string) (RingTone, error) {
+	if s == "" {
+		return RingTone(0), nil
+	}
+
+	for _, v := range RingToneValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return RingTone(0), unknownValue("RingTone", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r RingTone) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *RingTone) UnmarshalText(text []byte) error {
+	return r.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (r *RingTone) Set(value string) error {
+	parsed, err := ParseRingTone(value)
+
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
@@ -0,0 +1,261 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package router routes the voice webhook requests of a multi-step call flow
+// to a function for each step, and keeps state for each call between them.
+//
+// Steps are mounted by name under a path prefix, and refer to each other with
+// Step(name) in the action URLs of their verbs, which the Router replaces with
+// the URL of that step:
+//
+//	rt := router.New("/voice", router.NewMemoryStore(time.Hour))
+//
+//	rt.Handle("menu", func(ctx context.Context, c *router.Call) (*twiml.Response, error) {
+//		c.Session.Set("attempts", "1")
+//
+//		return &twiml.Response{Verbs: []interface{}{
+//			&twiml.Gather{Action: router.Step("choice"), NumDigits: 1},
+//		}}, nil
+//	})
+//
+//	rt.Handle("choice", func(ctx context.Context, c *router.Call) (*twiml.Response, error) {
+//		attempts := c.Session.Get("attempts")
+//		...
+//	})
+//
+//	http.Handle("/voice/", rt)
+//
+// Each step is served by a twiml.Handler, so errors and panics result in its
+// fallback response.
+package router
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+	"github.com/theckman/twilio/twiml"
+)
+
+// ErrUnknownStep is the cause of the error returned when a response refers to
+// a step that isn't mounted.
+var ErrUnknownStep = errors.New("unknown step")
+
+// DefaultSessionTTL is the TTL of the MemoryStore used by a Router without a
+// Store, which is the default time limit of a call.
+const DefaultSessionTTL = 4 * time.Hour
+
+// stepPrefix is the prefix of the placeholder URLs returned by Step.
+const stepPrefix = "step:"
+
+var validStepName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Step returns a placeholder for the URL of the named step, to be used as the
+// Action of a Gather, Record, Dial, Pay, Connect, or Enqueue, the WaitURL of
+// an Enqueue, or the URL of a Redirect. The Router replaces it with the URL
+// of the step before the response is written.
+func Step(name string) string {
+	return stepPrefix + name
+}
+
+// StepFunc is a function that returns the TwiML response for a step of a
+// call flow.
+type StepFunc func(ctx context.Context, c *Call) (*twiml.Response, error)
+
+// Call is the state of a call that a StepFunc is called with.
+type Call struct {
+	// Request is the webhook request of the step.
+	Request *twilio.VoiceRequest
+
+	// Session is the call's state from the previous steps, which can be
+	// changed for the next steps.
+	Session *Session
+
+	// Step is the name of the step.
+	Step string
+
+	router *Router
+}
+
+// URL returns the URL of the named step, for URLs that Step can't be used
+// for, such as status callbacks.
+func (c *Call) URL(step string) string {
+	return c.router.URL(step)
+}
+
+// Router is an http.Handler that routes voice webhook requests to the
+// StepFunc mounted for the last element of their path.
+type Router struct {
+	prefix   string
+	store    Store
+	opts     []twiml.HandlerOption
+	handlers map[string]http.Handler
+}
+
+// New returns a *Router for steps mounted under the path prefix (e.g.,
+// "/voice"), whose sessions are kept in the store. A nil store is a
+// MemoryStore with the DefaultSessionTTL. The options are used for the
+// twiml.Handler of each step.
+func New(prefix string, store Store, opts ...twiml.HandlerOption) *Router {
+	if store == nil {
+		store = NewMemoryStore(DefaultSessionTTL)
+	}
+
+	return &Router{
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		store:    store,
+		opts:     opts,
+		handlers: make(map[string]http.Handler),
+	}
+}
+
+// Handle mounts the StepFunc as the named step. Names can only contain
+// letters, digits, dots, dashes, and underscores. Handle panics if the name
+// isn't valid or is already mounted, like http.ServeMux.
+func (rt *Router) Handle(step string, fn StepFunc) {
+	if !validStepName.MatchString(step) {
+		panic("router: invalid step name " + step)
+	}
+
+	if _, ok := rt.handlers[step]; ok {
+		panic("router: multiple registrations for step " + step)
+	}
+
+	rt.handlers[step] = twiml.NewHandler(func(ctx context.Context, req *twilio.VoiceRequest) (*twiml.Response, error) {
+		return rt.run(ctx, step, fn, req)
+	}, rt.opts...)
+}
+
+// URL returns the URL of the named step, which is relative to the host.
+func (rt *Router) URL(step string) string {
+	return rt.prefix + "/" + step
+}
+
+// ServeHTTP implements the http.Handler interface. Requests for steps that
+// aren't mounted get a 404 Not Found.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, rt.prefix+"/")
+
+	h, ok := rt.handlers[name]
+
+	if !ok || name == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+
+	h.ServeHTTP(w, r)
+}
+
+// run calls the StepFunc with the call's session, and saves the session
+// afterwards.
+func (rt *Router) run(ctx context.Context, step string, fn StepFunc, req *twilio.VoiceRequest) (*twiml.Response, error) {
+	s := &Session{callSid: req.CallSid}
+
+	if req.CallSid != "" {
+		values, err := rt.store.Load(ctx, req.CallSid)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading session of %s failed", req.CallSid)
+		}
+
+		s.values = values
+	}
+
+	resp, err := fn(ctx, &Call{Request: req, Session: s, Step: step, router: rt})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "step %s failed", step)
+	}
+
+	if resp, err = rt.resolveSteps(resp); err != nil {
+		return nil, errors.Wrapf(err, "step %s failed", step)
+	}
+
+	switch {
+	case req.CallSid == "":
+	case req.CallStatus.Final():
+		err = rt.store.Delete(ctx, req.CallSid)
+	case s.changed:
+		err = rt.store.Save(ctx, req.CallSid, s.values)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "saving session of %s failed", req.CallSid)
+	}
+
+	return resp, nil
+}
+
+// resolveSteps returns a copy of the response with the placeholders returned
+// by Step replaced with the URLs of the steps, leaving the response the
+// StepFunc returned unchanged.
+func (rt *Router) resolveSteps(resp *twiml.Response) (*twiml.Response, error) {
+	if resp == nil {
+		return nil, nil
+	}
+
+	resp = twiml.Clone(resp)
+
+	err := twiml.Transform(resp, func(_ twiml.Path, node interface{}) (interface{}, error) {
+		return rt.resolveNode(node)
+	})
+
+	return resp, err
+}
+
+func (rt *Router) resolveNode(node interface{}) (interface{}, error) {
+	// resolve verbs that aren't pointers through a pointer to a copy
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Struct {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+
+		if _, err := rt.resolveNode(p.Interface()); err != nil {
+			return nil, err
+		}
+
+		return p.Elem().Interface(), nil
+	}
+
+	var urls []*string
+
+	switch v := node.(type) {
+	case *twiml.Gather:
+		urls = []*string{&v.Action}
+	case *twiml.Record:
+		urls = []*string{&v.Action}
+	case *twiml.Dial:
+		urls = []*string{&v.Action}
+	case *twiml.Pay:
+		urls = []*string{&v.Action}
+	case *twiml.Connect:
+		urls = []*string{&v.Action}
+	case *twiml.Enqueue:
+		urls = []*string{&v.Action, &v.WaitURL}
+	case *twiml.Redirect:
+		urls = []*string{&v.URL}
+	}
+
+	for _, u := range urls {
+		if !strings.HasPrefix(*u, stepPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(*u, stepPrefix)
+
+		if _, ok := rt.handlers[name]; !ok {
+			return nil, errors.Wrapf(ErrUnknownStep, "resolving %q failed", name)
+		}
+
+		*u = rt.URL(name)
+	}
+
+	return node, nil
+}
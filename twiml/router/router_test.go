@@ -0,0 +1,194 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+const testCallSid = "CA0123456789abcdef0123456789abcdef"
+
+func post(rt http.Handler, path string, params url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "http://example.com"+path, strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, r)
+
+	return w
+}
+
+func mustMarshal(t *testing.T, verbs ...interface{}) string {
+	doc, err := twiml.MarshalResponse(&twiml.Response{Verbs: verbs})
+
+	if err != nil {
+		t.Fatalf("MarshalResponse() unexpected error: %s", err)
+	}
+
+	return string(doc)
+}
+
+func TestRouter(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	rt := New("/voice/", store)
+
+	menu := &twiml.Response{Verbs: []interface{}{
+		&twiml.Gather{Action: Step("choice"), NumDigits: 1},
+		twiml.Redirect{URL: Step("menu")},
+	}}
+
+	rt.Handle("menu", func(_ context.Context, c *Call) (*twiml.Response, error) {
+		c.Session.Set("visits", c.Session.Get("visits")+"x")
+		return menu, nil
+	})
+
+	rt.Handle("choice", func(_ context.Context, c *Call) (*twiml.Response, error) {
+		msg := "visits " + c.Session.Get("visits") + ", pressed " + c.Request.Digits + ", step " + c.Step
+		return &twiml.Response{Verbs: []interface{}{&twiml.Say{Message: msg}, &twiml.Redirect{URL: c.URL("menu")}}}, nil
+	})
+
+	params := url.Values{"CallSid": {testCallSid}, "CallStatus": {"in-progress"}}
+
+	w := post(rt, "/voice/menu", params)
+
+	want := mustMarshal(t, &twiml.Gather{Action: "/voice/choice", NumDigits: 1}, twiml.Redirect{URL: "/voice/menu"})
+
+	if w.Body.String() != want {
+		t.Errorf("menu body =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+
+	if menu.Verbs[0].(*twiml.Gather).Action != Step("choice") {
+		t.Error("resolving steps should not change the response returned by the StepFunc")
+	}
+
+	_ = post(rt, "/voice/menu", params)
+
+	params.Set("Digits", "2")
+	w = post(rt, "/voice/choice", params)
+
+	want = mustMarshal(t, &twiml.Say{Message: "visits xx, pressed 2, step choice"}, &twiml.Redirect{URL: "/voice/menu"})
+
+	if w.Body.String() != want {
+		t.Errorf("choice body =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+
+	if store.Len() != 1 {
+		t.Errorf("store Len() = %d, want 1", store.Len())
+	}
+
+	params.Set("CallStatus", "completed")
+	_ = post(rt, "/voice/choice", params)
+
+	if store.Len() != 0 {
+		t.Errorf("session should be deleted when the call ends, Len() = %d", store.Len())
+	}
+
+	for _, path := range []string{"/voice/other", "/voice/", "/menu", "/voice/menu/x"} {
+		if w := post(rt, path, params); w.Code != http.StatusNotFound {
+			t.Errorf("POST %s status = %d, want %d", path, w.Code, http.StatusNotFound)
+		}
+	}
+}
+
+// failingStore is a Store whose every method fails.
+type failingStore struct{}
+
+func (failingStore) Load(context.Context, string) (map[string]string, error) {
+	return nil, errors.New("load failed")
+}
+
+func (failingStore) Save(context.Context, string, map[string]string) error {
+	return errors.New("save failed")
+}
+
+func (failingStore) Delete(context.Context, string) error {
+	return errors.New("delete failed")
+}
+
+func TestRouter_errors(t *testing.T) {
+	tests := []struct {
+		desc  string
+		store Store
+		fn    StepFunc
+		err   string
+	}{
+		{
+			"unknown step should fail",
+			nil,
+			func(context.Context, *Call) (*twiml.Response, error) {
+				return &twiml.Response{Verbs: []interface{}{&twiml.Record{Action: Step("nowhere")}}}, nil
+			},
+			`handling voice request failed: step start failed: resolving "nowhere" failed: unknown step`,
+		},
+		{
+			"step error should fail",
+			nil,
+			func(context.Context, *Call) (*twiml.Response, error) { return nil, errors.New("oops") },
+			"handling voice request failed: step start failed: oops",
+		},
+		{
+			"session that can't be loaded should fail",
+			failingStore{},
+			func(context.Context, *Call) (*twiml.Response, error) { return nil, nil },
+			"handling voice request failed: loading session of " + testCallSid + " failed: load failed",
+		},
+	}
+
+	for _, test := range tests {
+		var hookErr error
+
+		rt := New("/voice", test.store, twiml.WithErrorHook(func(_ *http.Request, err error) { hookErr = err }))
+		rt.Handle("start", test.fn)
+
+		w := post(rt, "/voice/start", url.Values{"CallSid": {testCallSid}})
+
+		if hookErr == nil || hookErr.Error() != test.err {
+			t.Errorf("\nDescription: %s\nerror = %v, want %q", test.desc, hookErr, test.err)
+		}
+
+		if want := mustMarshal(t, twiml.DefaultFallback().Verbs...); w.Body.String() != want {
+			t.Errorf("\nDescription: %s\nbody =\n%s\nwant the fallback", test.desc, w.Body.String())
+		}
+	}
+}
+
+func TestRouter_Handle(t *testing.T) {
+	fn := func(context.Context, *Call) (*twiml.Response, error) { return nil, nil }
+
+	tests := []struct {
+		desc string
+		name string
+	}{
+		{"empty name should panic", ""},
+		{"name with a slash should panic", "a/b"},
+		{"duplicate name should panic", "start"},
+	}
+
+	for _, test := range tests {
+		rt := New("/voice", nil)
+		rt.Handle("start", fn)
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("\nDescription: %s\nHandle(%q) did not panic", test.desc, test.name)
+				}
+			}()
+
+			rt.Handle(test.name, fn)
+		}()
+	}
+}
@@ -0,0 +1,174 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store is where the Router keeps the Session of each call between webhook
+// requests, keyed by CallSid. Implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the values of the call's session, or a nil map and a nil
+	// error if it doesn't have one.
+	Load(ctx context.Context, callSid string) (map[string]string, error)
+
+	// Save replaces the values of the call's session.
+	Save(ctx context.Context, callSid string, values map[string]string) error
+
+	// Delete removes the call's session, if it has one.
+	Delete(ctx context.Context, callSid string) error
+}
+
+// Session is the state of a call, kept between the webhook requests of the
+// steps of a flow. It's loaded before each step and saved afterwards if it
+// was changed, and deleted once the call has ended.
+type Session struct {
+	callSid string
+	values  map[string]string
+	changed bool
+}
+
+// CallSid returns the CallSid of the call the session belongs to.
+func (s *Session) CallSid() string {
+	return s.callSid
+}
+
+// Get returns the value of the key, or an empty string if it's not set.
+func (s *Session) Get(key string) string {
+	return s.values[key]
+}
+
+// Lookup returns the value of the key, and whether it's set.
+func (s *Session) Lookup(key string) (string, bool) {
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Set sets the value of the key.
+func (s *Session) Set(key, value string) {
+	if s.values == nil {
+		s.values = make(map[string]string)
+	}
+
+	s.values[key] = value
+	s.changed = true
+}
+
+// Delete removes the key.
+func (s *Session) Delete(key string) {
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.changed = true
+	}
+}
+
+// memoryEntry is a session in a MemoryStore.
+type memoryEntry struct {
+	values  map[string]string
+	expires time.Time
+}
+
+// MemoryStore is a Store that keeps sessions in memory, for services running
+// as a single instance. Sessions expire once they haven't been saved for the
+// TTL, so that sessions of calls whose end the Router never saw don't pile
+// up.
+type MemoryStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	sessions  map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryStore returns a *MemoryStore whose sessions expire after the TTL.
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: make(map[string]memoryEntry),
+	}
+}
+
+// Load implements the Store interface.
+func (m *MemoryStore) Load(_ context.Context, callSid string) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.sessions[callSid]
+
+	if !ok {
+		return nil, nil
+	}
+
+	if !m.now().Before(e.expires) {
+		delete(m.sessions, callSid)
+		return nil, nil
+	}
+
+	return copyValues(e.values), nil
+}
+
+// Save implements the Store interface.
+func (m *MemoryStore) Save(_ context.Context, callSid string, values map[string]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	m.sessions[callSid] = memoryEntry{values: copyValues(values), expires: now.Add(m.ttl)}
+
+	// remove expired sessions at most once per TTL, so it doesn't cost
+	// more than the sessions being saved
+	if now.Sub(m.lastSweep) >= m.ttl {
+		for sid, e := range m.sessions {
+			if !now.Before(e.expires) {
+				delete(m.sessions, sid)
+			}
+		}
+
+		m.lastSweep = now
+	}
+
+	return nil
+}
+
+// Delete implements the Store interface.
+func (m *MemoryStore) Delete(_ context.Context, callSid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, callSid)
+
+	return nil
+}
+
+// Len returns the number of sessions in the store, including any that have
+// expired but haven't been removed yet.
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sessions)
+}
+
+func copyValues(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	out := make(map[string]string, len(values))
+
+	for k, v := range values {
+		out[k] = v
+	}
+
+	return out
+}
@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package router
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSession(t *testing.T) {
+	s := &Session{callSid: "CA1"}
+
+	if v, ok := s.Lookup("a"); ok || v != "" || s.changed {
+		t.Fatalf("empty session Lookup() = %q, %t, changed = %t", v, ok, s.changed)
+	}
+
+	s.Delete("a")
+
+	if s.changed {
+		t.Error("deleting a missing key should not change the session")
+	}
+
+	s.Set("a", "1")
+
+	if v := s.Get("a"); v != "1" || !s.changed {
+		t.Errorf("after Set() Get() = %q, changed = %t, want 1 and true", v, s.changed)
+	}
+
+	s.Delete("a")
+
+	if _, ok := s.Lookup("a"); ok {
+		t.Error("Lookup() after Delete() should be false")
+	}
+
+	if s.CallSid() != "CA1" {
+		t.Errorf("CallSid() = %q, want CA1", s.CallSid())
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	m := NewMemoryStore(time.Minute)
+	m.now = func() time.Time { return now }
+
+	if values, err := m.Load(ctx, "CA1"); values != nil || err != nil {
+		t.Fatalf("Load() of a missing session = %v, %v, want nil", values, err)
+	}
+
+	values := map[string]string{"step": "menu"}
+
+	if err := m.Save(ctx, "CA1", values); err != nil {
+		t.Fatalf("Save() unexpected error: %s", err)
+	}
+
+	// the store should keep its own copy
+	values["step"] = "changed"
+
+	loaded, err := m.Load(ctx, "CA1")
+
+	if err != nil || !reflect.DeepEqual(loaded, map[string]string{"step": "menu"}) {
+		t.Fatalf("Load() = %v, %v, want step=menu", loaded, err)
+	}
+
+	loaded["step"] = "changed"
+
+	if loaded, _ = m.Load(ctx, "CA1"); loaded["step"] != "menu" {
+		t.Errorf("changing a loaded session should not change the store, got %v", loaded)
+	}
+
+	now = now.Add(59 * time.Second)
+
+	if loaded, _ = m.Load(ctx, "CA1"); loaded == nil {
+		t.Error("session should not expire before the TTL")
+	}
+
+	now = now.Add(time.Second)
+
+	if loaded, _ = m.Load(ctx, "CA1"); loaded != nil {
+		t.Errorf("session should expire after the TTL, got %v", loaded)
+	}
+
+	if m.Len() != 0 {
+		t.Errorf("expired session should be removed when loaded, Len() = %d", m.Len())
+	}
+
+	_ = m.Save(ctx, "CA2", nil)
+	_ = m.Save(ctx, "CA3", nil)
+
+	now = now.Add(2 * time.Minute)
+	_ = m.Save(ctx, "CA4", nil)
+
+	if m.Len() != 1 {
+		t.Errorf("expired sessions should be swept when saving, Len() = %d, want 1", m.Len())
+	}
+
+	if err := m.Delete(ctx, "CA4"); err != nil || m.Len() != 0 {
+		t.Errorf("Delete() = %v, Len() = %d, want nil and 0", err, m.Len())
+	}
+}
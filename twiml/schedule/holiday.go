@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date, without a time or location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of t, in its location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// days returns the number of days since the Unix epoch, for comparing dates.
+func (d Date) days() int64 {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// Holiday is one or more days on which a Schedule is closed all day, in the
+// Schedule's location.
+type Holiday struct {
+	Name string
+
+	// Date is the first day of the holiday. The Year is ignored if the
+	// holiday is Yearly.
+	Date Date
+
+	// Days is the length of the holiday, with zero meaning one day.
+	Days int
+
+	// Yearly makes the holiday repeat on the same date every year.
+	Yearly bool
+}
+
+// Contains returns whether the holiday includes the date.
+func (h Holiday) Contains(d Date) bool {
+	n := h.Days
+
+	if n < 1 {
+		n = 1
+	}
+
+	within := func(start Date) bool {
+		diff := d.days() - start.days()
+		return diff >= 0 && diff < int64(n)
+	}
+
+	if !h.Yearly {
+		return within(h.Date)
+	}
+
+	// a yearly holiday may have started the year before, e.g. one that spans
+	// New Year's Day
+	return within(Date{Year: d.Year, Month: h.Date.Month, Day: h.Date.Day}) ||
+		within(Date{Year: d.Year - 1, Month: h.Date.Month, Day: h.Date.Day})
+}
+
+// Override opens or closes a Schedule from Start until End, regardless of its
+// hours and holidays, such as for a staff meeting or extended hours.
+type Override struct {
+	Name       string
+	Start, End time.Time
+	Open       bool
+}
+
+// Contains returns whether t is within the override.
+func (o Override) Contains(t time.Time) bool {
+	return !t.Before(o.Start) && t.Before(o.End)
+}
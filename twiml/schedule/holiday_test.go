@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoliday_Contains(t *testing.T) {
+	tests := []struct {
+		desc    string
+		holiday Holiday
+		date    Date
+		want    bool
+	}{
+		{
+			"date should be contained",
+			Holiday{Date: Date{2026, time.July, 3}},
+			Date{2026, time.July, 3},
+			true,
+		},
+		{
+			"zero days should be one day",
+			Holiday{Date: Date{2026, time.July, 3}},
+			Date{2026, time.July, 4},
+			false,
+		},
+		{
+			"last day should be contained",
+			Holiday{Date: Date{2026, time.December, 24}, Days: 3},
+			Date{2026, time.December, 26},
+			true,
+		},
+		{
+			"day before should not be contained",
+			Holiday{Date: Date{2026, time.December, 24}, Days: 3},
+			Date{2026, time.December, 23},
+			false,
+		},
+		{
+			"one-off holiday should not repeat",
+			Holiday{Date: Date{2026, time.July, 3}},
+			Date{2027, time.July, 3},
+			false,
+		},
+		{
+			"yearly holiday should repeat",
+			Holiday{Date: Date{Month: time.July, Day: 4}, Yearly: true},
+			Date{2031, time.July, 4},
+			true,
+		},
+		{
+			"yearly holiday should continue in to the next year",
+			Holiday{Date: Date{Month: time.December, Day: 31}, Days: 2, Yearly: true},
+			Date{2027, time.January, 1},
+			true,
+		},
+		{
+			"yearly holiday should end",
+			Holiday{Date: Date{Month: time.December, Day: 31}, Days: 2, Yearly: true},
+			Date{2027, time.January, 2},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.holiday.Contains(test.date); got != test.want {
+			t.Errorf("\nDescription: %s\nContains(%s) = %t, want %t", test.desc, test.date, got, test.want)
+		}
+	}
+}
+
+func TestOverride_Contains(t *testing.T) {
+	start := time.Date(2026, time.October, 20, 12, 0, 0, 0, time.UTC)
+	o := Override{Start: start, End: start.Add(time.Hour)}
+
+	tests := []struct {
+		desc string
+		t    time.Time
+		want bool
+	}{
+		{"start should be contained", start, true},
+		{"middle should be contained", start.Add(30 * time.Minute), true},
+		{"end should not be contained", start.Add(time.Hour), false},
+		{"before should not be contained", start.Add(-time.Nanosecond), false},
+		{"other zones should be compared", start.In(est), true},
+	}
+
+	for _, test := range tests {
+		if got := o.Contains(test.t); got != test.want {
+			t.Errorf("\nDescription: %s\nContains(%s) = %t, want %t", test.desc, test.t, got, test.want)
+		}
+	}
+}
+
+func TestDateOf(t *testing.T) {
+	d := DateOf(time.Date(2026, time.October, 17, 23, 0, 0, 0, est))
+
+	if d != (Date{2026, time.October, 17}) || d.String() != "2026-10-17" {
+		t.Errorf("DateOf() = %s, want 2026-10-17", d)
+	}
+}
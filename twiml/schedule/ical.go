@@ -0,0 +1,419 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package schedule
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidICalendar is the cause of the error returned by LoadICalendar for
+// files it can't parse, or that use features it doesn't support.
+var ErrInvalidICalendar = errors.New("invalid iCalendar")
+
+// Calendar is a set of holidays and overrides, such as those loaded from an
+// iCalendar file.
+type Calendar struct {
+	Holidays  []Holiday
+	Overrides []Override
+}
+
+// icalLine is an unfolded content line of an iCalendar file.
+type icalLine struct {
+	num    int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icalEvent is the properties of a VEVENT that LoadICalendar uses.
+type icalEvent struct {
+	num                    int
+	summary, rrule, status string
+	start, end, duration   *icalLine
+}
+
+// LoadICalendar loads the events of an iCalendar (RFC 5545) file, such as a
+// holiday calendar exported from a calendar app, as closures:
+//
+//   - all-day events become a Holiday, which is Yearly if the event has a
+//     RRULE of FREQ=YEARLY
+//   - events with a start and end time become an Override that closes the
+//     Schedule
+//
+// Times without a time zone are in the location, with a nil location being
+// UTC. Cancelled events are skipped. Other recurrence rules aren't supported,
+// and are an error. This function returns a wrapped error (see package
+// documentation for more info).
+func LoadICalendar(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := readICalLines(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{}
+
+	var stack []string
+	var ev *icalEvent
+
+	for _, l := range lines {
+		switch l.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(l.value))
+
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				ev = &icalEvent{num: l.num}
+			}
+
+			continue
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(l.value) {
+				return nil, errors.Wrapf(ErrInvalidICalendar, "line %d: unexpected END:%s", l.num, l.value)
+			}
+
+			stack = stack[:len(stack)-1]
+
+			if len(stack) == 1 && ev != nil {
+				if err := ev.addTo(cal, loc); err != nil {
+					return nil, err
+				}
+
+				ev = nil
+			}
+
+			continue
+		}
+
+		// only the properties of the event itself matter, not those of
+		// components within it such as alarms
+		if ev == nil || len(stack) != 2 {
+			continue
+		}
+
+		line := l
+
+		switch l.name {
+		case "SUMMARY":
+			ev.summary = unescapeICalText(l.value)
+		case "RRULE":
+			ev.rrule = strings.ToUpper(l.value)
+		case "STATUS":
+			ev.status = strings.ToUpper(l.value)
+		case "DTSTART":
+			ev.start = &line
+		case "DTEND":
+			ev.end = &line
+		case "DURATION":
+			ev.duration = &line
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, errors.Wrapf(ErrInvalidICalendar, "missing END:%s", stack[len(stack)-1])
+	}
+
+	return cal, nil
+}
+
+// addTo adds the event to the calendar as a Holiday or Override.
+func (ev *icalEvent) addTo(cal *Calendar, loc *time.Location) error {
+	if ev.status == "CANCELLED" {
+		return nil
+	}
+
+	if ev.start == nil {
+		return errors.Wrapf(ErrInvalidICalendar, "line %d: event without DTSTART", ev.num)
+	}
+
+	yearly, err := parseRRule(ev.rrule)
+
+	if err != nil {
+		return errors.Wrapf(err, "line %d", ev.num)
+	}
+
+	start, allDay, err := parseICalTime(ev.start, loc)
+
+	if err != nil {
+		return err
+	}
+
+	var end time.Time
+
+	switch {
+	case ev.end != nil:
+		var endAllDay bool
+
+		if end, endAllDay, err = parseICalTime(ev.end, loc); err != nil {
+			return err
+		}
+
+		if endAllDay != allDay {
+			return errors.Wrapf(ErrInvalidICalendar, "line %d: DTSTART and DTEND are of different types", ev.end.num)
+		}
+	case ev.duration != nil:
+		d, err := parseICalDuration(ev.duration.value)
+
+		if err != nil {
+			return errors.Wrapf(err, "line %d", ev.duration.num)
+		}
+
+		end = start.Add(d)
+	case allDay:
+		end = start.AddDate(0, 0, 1)
+	default:
+		return errors.Wrapf(ErrInvalidICalendar, "line %d: timed event without DTEND or DURATION", ev.num)
+	}
+
+	if !end.After(start) {
+		return errors.Wrapf(ErrInvalidICalendar, "line %d: event ends before it starts", ev.num)
+	}
+
+	if !allDay {
+		if yearly {
+			return errors.Wrapf(ErrInvalidICalendar, "line %d: yearly timed events aren't supported", ev.num)
+		}
+
+		cal.Overrides = append(cal.Overrides, Override{Name: ev.summary, Start: start, End: end})
+
+		return nil
+	}
+
+	cal.Holidays = append(cal.Holidays, Holiday{
+		Name:   ev.summary,
+		Date:   DateOf(start),
+		Days:   int(DateOf(end).days() - DateOf(start).days()),
+		Yearly: yearly,
+	})
+
+	return nil
+}
+
+// readICalLines reads and unfolds the content lines of an iCalendar file.
+func readICalLines(r io.Reader) ([]icalLine, error) {
+	var raw []string
+	var nums []int
+
+	sc := bufio.NewScanner(r)
+
+	for num := 1; sc.Scan(); num++ {
+		text := strings.TrimRight(sc.Text(), "\r")
+
+		switch {
+		case text == "":
+		case (text[0] == ' ' || text[0] == '\t') && len(raw) > 0:
+			raw[len(raw)-1] += text[1:]
+		default:
+			raw = append(raw, text)
+			nums = append(nums, num)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading iCalendar failed")
+	}
+
+	lines := make([]icalLine, 0, len(raw))
+
+	for i, text := range raw {
+		l, err := parseICalLine(text)
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", nums[i])
+		}
+
+		l.num = nums[i]
+		lines = append(lines, l)
+	}
+
+	return lines, nil
+}
+
+// parseICalLine splits a content line in to its name, parameters, and value.
+// The value starts at the first colon that isn't within a quoted parameter.
+func parseICalLine(text string) (icalLine, error) {
+	var quoted bool
+
+	colon := -1
+
+	for i := 0; i < len(text) && colon < 0; i++ {
+		switch text[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+
+	if colon < 0 {
+		return icalLine{}, errors.Wrapf(ErrInvalidICalendar, "content line %q has no value", text)
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	l := icalLine{name: strings.ToUpper(parts[0]), value: text[colon+1:]}
+
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+
+		if len(kv) != 2 {
+			return icalLine{}, errors.Wrapf(ErrInvalidICalendar, "parameter %q has no value", p)
+		}
+
+		if l.params == nil {
+			l.params = make(map[string]string)
+		}
+
+		l.params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+
+	return l, nil
+}
+
+// parseICalTime parses a DATE or DATE-TIME value, returning whether it's a
+// date. Dates are midnight in the location.
+func parseICalTime(l *icalLine, loc *time.Location) (time.Time, bool, error) {
+	if tzid := l.params["TZID"]; tzid != "" {
+		var err error
+
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, false, errors.Wrapf(ErrInvalidICalendar, "line %d: unknown TZID %q", l.num, tzid)
+		}
+	}
+
+	var t time.Time
+	var err error
+
+	allDay := strings.EqualFold(l.params["VALUE"], "DATE") || len(l.value) == 8
+
+	switch {
+	case allDay:
+		t, err = time.ParseInLocation("20060102", l.value, loc)
+	case strings.HasSuffix(l.value, "Z"):
+		t, err = time.Parse("20060102T150405Z", l.value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", l.value, loc)
+	}
+
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(ErrInvalidICalendar, "line %d: invalid %s %q", l.num, l.name, l.value)
+	}
+
+	return t, allDay, nil
+}
+
+// parseRRule returns whether the recurrence rule is yearly, which is the only
+// rule supported besides none.
+func parseRRule(rule string) (bool, error) {
+	if rule == "" {
+		return false, nil
+	}
+
+	var yearly bool
+
+	for _, part := range strings.Split(rule, ";") {
+		switch {
+		case part == "FREQ=YEARLY":
+			yearly = true
+		case part == "INTERVAL=1", strings.HasPrefix(part, "WKST="):
+		default:
+			return false, errors.Wrapf(ErrInvalidICalendar, "unsupported RRULE %q", rule)
+		}
+	}
+
+	if !yearly {
+		return false, errors.Wrapf(ErrInvalidICalendar, "unsupported RRULE %q", rule)
+	}
+
+	return true, nil
+}
+
+// parseICalDuration parses a DURATION value, such as P1D or PT1H30M.
+func parseICalDuration(s string) (time.Duration, error) {
+	invalid := errors.Wrapf(ErrInvalidICalendar, "invalid DURATION %q", s)
+
+	s = strings.TrimPrefix(s, "+")
+
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, invalid
+	}
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+	}
+
+	var d time.Duration
+	var timePart bool
+
+	num := -1
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == 'T':
+			timePart = true
+		case c >= '0' && c <= '9':
+			if num < 0 {
+				num = 0
+			}
+
+			num = num*10 + int(c-'0')
+		case num >= 0 && units[c] != 0 && timePart == (c == 'H' || c == 'M' || c == 'S'):
+			d += time.Duration(num) * units[c]
+			num = -1
+		default:
+			return 0, invalid
+		}
+	}
+
+	if num >= 0 {
+		return 0, invalid
+	}
+
+	return d, nil
+}
+
+// unescapeICalText unescapes a TEXT value.
+func unescapeICalText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var out []byte
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			out = append(out, s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 'n', 'N':
+			out = append(out, '\n')
+		default:
+			out = append(out, s[i])
+		}
+	}
+
+	return string(out)
+}
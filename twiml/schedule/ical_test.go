@@ -0,0 +1,150 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package schedule
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const testICalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Example//Holidays//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:christmas@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20261225\r\n" +
+	"DTEND;VALUE=DATE:20261226\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Christmas Day\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"SUMMARY:Reminder\r\n" +
+	"TRIGGER:-P1D\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20261126\r\n" +
+	"DTEND;VALUE=DATE:20261128\r\n" +
+	"SUMMARY:Thanksgiving\\, and the day\r\n" +
+	"  after\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20260704\r\n" +
+	"SUMMARY:Independence Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=America/New_York:20261031T120000\r\n" +
+	"DTEND;TZID=America/New_York:20261031T170000\r\n" +
+	"SUMMARY:Team outing\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20261102T150000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"SUMMARY:All hands\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20261103T090000\r\n" +
+	"DTEND:20261103T100000\r\n" +
+	"SUMMARY:Floating\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20261111\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"SUMMARY:Cancelled\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestLoadICalendar(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Skipf("time zone data unavailable: %s", err)
+	}
+
+	cal, err := LoadICalendar(strings.NewReader(testICalendar), est)
+
+	if err != nil {
+		t.Fatalf("LoadICalendar() unexpected error: %s", err)
+	}
+
+	holidays := []Holiday{
+		{Name: "Christmas Day", Date: Date{2026, time.December, 25}, Days: 1, Yearly: true},
+		{Name: "Thanksgiving, and the day after", Date: Date{2026, time.November, 26}, Days: 2},
+		{Name: "Independence Day", Date: Date{2026, time.July, 4}, Days: 1},
+	}
+
+	if !reflect.DeepEqual(cal.Holidays, holidays) {
+		t.Errorf("Holidays = %+v, want %+v", cal.Holidays, holidays)
+	}
+
+	overrides := []Override{
+		{Name: "Team outing", Start: time.Date(2026, time.October, 31, 12, 0, 0, 0, ny), End: time.Date(2026, time.October, 31, 17, 0, 0, 0, ny)},
+		{Name: "All hands", Start: time.Date(2026, time.November, 2, 15, 0, 0, 0, time.UTC), End: time.Date(2026, time.November, 2, 16, 30, 0, 0, time.UTC)},
+		{Name: "Floating", Start: time.Date(2026, time.November, 3, 9, 0, 0, 0, est), End: time.Date(2026, time.November, 3, 10, 0, 0, 0, est)},
+	}
+
+	if len(cal.Overrides) != len(overrides) {
+		t.Fatalf("len(Overrides) = %d, want %d", len(cal.Overrides), len(overrides))
+	}
+
+	for i, o := range cal.Overrides {
+		want := overrides[i]
+
+		if o.Name != want.Name || !o.Start.Equal(want.Start) || !o.End.Equal(want.End) || o.Open {
+			t.Errorf("Overrides[%d] = %+v, want %+v", i, o, want)
+		}
+	}
+
+	s := New(est)
+	s.AddCalendar(cal)
+
+	if status := s.StatusAt(time.Date(2027, time.December, 25, 10, 0, 0, 0, est)); status.Reason != ReasonHoliday {
+		t.Errorf("StatusAt(Christmas 2027) = %+v, want a holiday", status)
+	}
+}
+
+func TestLoadICalendar_errors(t *testing.T) {
+	event := func(lines ...string) string {
+		return "BEGIN:VCALENDAR\nBEGIN:VEVENT\n" + strings.Join(lines, "\n") + "\nEND:VEVENT\nEND:VCALENDAR\n"
+	}
+
+	tests := []struct {
+		desc string
+		in   string
+		msg  string
+	}{
+		{"missing DTSTART", event("SUMMARY:x"), "line 2: event without DTSTART"},
+		{"invalid date", event("DTSTART;VALUE=DATE:2026-12-25"), `line 3: invalid DTSTART "2026-12-25"`},
+		{"unsupported rule", event("DTSTART:20261225", "RRULE:FREQ=WEEKLY"), `line 2: unsupported RRULE "FREQ=WEEKLY"`},
+		{"yearly timed event", event("DTSTART:20261225T090000", "DTEND:20261225T100000", "RRULE:FREQ=YEARLY"), "line 2: yearly timed events aren't supported"},
+		{"timed event without an end", event("DTSTART:20261225T090000"), "line 2: timed event without DTEND or DURATION"},
+		{"end before start", event("DTSTART:20261225", "DTEND:20261224"), "line 2: event ends before it starts"},
+		{"mixed types", event("DTSTART:20261225", "DTEND:20261225T100000"), "line 4: DTSTART and DTEND are of different types"},
+		{"invalid duration", event("DTSTART:20261225T090000", "DURATION:P1H"), `line 4: invalid DURATION "P1H"`},
+		{"unknown time zone", event("DTSTART;TZID=Nowhere/Special:20261225T090000"), `line 3: unknown TZID "Nowhere/Special"`},
+		{"line without a value", "BEGIN:VCALENDAR\nNOPE\nEND:VCALENDAR\n", `line 2: content line "NOPE" has no value`},
+		{"unexpected END", "BEGIN:VCALENDAR\nEND:VEVENT\n", "line 2: unexpected END:VEVENT"},
+		{"missing END", "BEGIN:VCALENDAR\n", "missing END:VCALENDAR"},
+	}
+
+	for _, test := range tests {
+		_, err := LoadICalendar(strings.NewReader(test.in), nil)
+
+		if errors.Cause(err) != ErrInvalidICalendar {
+			t.Errorf("\nDescription: %s\nLoadICalendar() error = %v, want cause %v", test.desc, err, ErrInvalidICalendar)
+			continue
+		}
+
+		if !strings.HasPrefix(err.Error(), test.msg) {
+			t.Errorf("\nDescription: %s\nLoadICalendar() error = %q, want prefix %q", test.desc, err, test.msg)
+		}
+	}
+}
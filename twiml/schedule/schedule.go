@@ -0,0 +1,380 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package schedule decides whether a business is open, from weekly opening
+// hours in a time zone, holidays, and overrides, so that a call flow can pick
+// between alternative responses at request time:
+//
+//	s := schedule.New(loc)
+//	s.SetWeekdays(schedule.Range{Open: schedule.At(9, 0), Close: schedule.At(17, 0)})
+//	s.AddHoliday(schedule.Holiday{Name: "Christmas", Date: schedule.Date{Month: time.December, Day: 25}, Yearly: true})
+//
+//	resp := s.Select(schedule.Branches{Open: menu, Closed: voicemail})
+//
+// Holidays can be imported from an iCalendar file with LoadICalendar. The
+// current time comes from the Schedule's clock, which tests can replace with
+// the WithClock option.
+//
+// Errors are wrapped, like in the twiml package.
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// ErrInvalidRange is the cause of the error returned when setting opening
+// hours with a Range that's out of bounds or empty.
+var ErrInvalidRange = errors.New("invalid range")
+
+// minutesPerDay is the number of minutes in a day, ignoring daylight saving
+// time changes.
+const minutesPerDay = 24 * 60
+
+// lookahead is how many days NextOpen searches, which is enough to find the
+// next opening after a year of holidays.
+const lookahead = 400
+
+// TimeOfDay is a wall clock time, in minutes since midnight.
+type TimeOfDay int
+
+// At returns the TimeOfDay of the hour and minute. At(24, 0) is the end of the
+// day, for ranges that close at midnight.
+func At(hour, minute int) TimeOfDay {
+	return TimeOfDay(hour*60 + minute)
+}
+
+// Hour returns the hour of t.
+func (t TimeOfDay) Hour() int { return int(t) / 60 }
+
+// Minute returns the minute within the hour of t.
+func (t TimeOfDay) Minute() int { return int(t) % 60 }
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour(), t.Minute())
+}
+
+// Range is a period of opening hours within a day. If Close is before Open,
+// the range closes on the following day (e.g., 22:00 to 02:00).
+type Range struct {
+	Open, Close TimeOfDay
+}
+
+func (r Range) String() string {
+	return r.Open.String() + "-" + r.Close.String()
+}
+
+func (r Range) validate() error {
+	if r.Open < 0 || r.Open >= minutesPerDay || r.Close <= 0 || r.Close > minutesPerDay || r.Open == r.Close {
+		return errors.Wrapf(ErrInvalidRange, "range %s", r)
+	}
+
+	return nil
+}
+
+// overnight returns whether the range closes on the following day.
+func (r Range) overnight() bool {
+	return r.Close < r.Open
+}
+
+// Reason is why a Schedule is open or closed at a given time.
+type Reason uint8
+
+const (
+	// ReasonHours means the weekly opening hours decided.
+	ReasonHours Reason = iota
+
+	// ReasonHoliday means it's a holiday, so it's closed.
+	ReasonHoliday
+
+	// ReasonOverride means an Override decided.
+	ReasonOverride
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonHours:
+		return "hours"
+	case ReasonHoliday:
+		return "holiday"
+	case ReasonOverride:
+		return "override"
+	default:
+		return fmt.Sprintf("Reason(%d)", uint8(r))
+	}
+}
+
+// Status is whether a Schedule is open at a given time, and why.
+type Status struct {
+	Open   bool
+	Reason Reason
+
+	// Name is the name of the holiday or override, if Reason is
+	// ReasonHoliday or ReasonOverride.
+	Name string
+}
+
+// Option configures optional behavior of a Schedule.
+type Option func(*Schedule)
+
+// WithClock sets the function the Schedule uses to get the current time,
+// which is time.Now by default. It's mostly useful in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Schedule) {
+		s.now = now
+	}
+}
+
+// Schedule is the opening hours of a business in a time zone. Holidays and
+// overrides take precedence over the weekly hours, and overrides take
+// precedence over holidays. A holiday closes the ranges that open on its
+// dates, including any part of them after midnight. A Schedule is not safe for
+// concurrent use while it's being changed, but is safe for concurrent reads
+// once set up.
+type Schedule struct {
+	loc       *time.Location
+	now       func() time.Time
+	weekly    [7][]Range
+	holidays  []Holiday
+	overrides []Override
+}
+
+// New returns a *Schedule in the location, which is always closed until
+// opening hours are set. A nil location is UTC.
+func New(loc *time.Location, opts ...Option) *Schedule {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	s := &Schedule{loc: loc, now: time.Now}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Location returns the location of the Schedule.
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// SetHours replaces the opening hours of the day of the week. Without any
+// ranges, it's closed all day. This function returns a wrapped error (see
+// package documentation for more info).
+func (s *Schedule) SetHours(day time.Weekday, ranges ...Range) error {
+	for _, r := range ranges {
+		if err := r.validate(); err != nil {
+			return errors.Wrapf(err, "setting %s hours failed", day)
+		}
+	}
+
+	rs := make([]Range, len(ranges))
+	copy(rs, ranges)
+
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Open < rs[j].Open })
+
+	s.weekly[day] = rs
+
+	return nil
+}
+
+// SetWeekdays replaces the opening hours of Monday through Friday. This
+// function returns a wrapped error (see package documentation for more info).
+func (s *Schedule) SetWeekdays(ranges ...Range) error {
+	for day := time.Monday; day <= time.Friday; day++ {
+		if err := s.SetHours(day, ranges...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hours returns the opening hours of the day of the week, in order, for
+// telling callers when to call back.
+func (s *Schedule) Hours(day time.Weekday) []Range {
+	rs := make([]Range, len(s.weekly[day]))
+	copy(rs, s.weekly[day])
+	return rs
+}
+
+// AddHoliday adds a holiday, on which the Schedule is closed all day.
+func (s *Schedule) AddHoliday(h Holiday) {
+	s.holidays = append(s.holidays, h)
+}
+
+// AddOverride adds an override, which opens or closes the Schedule for a
+// period regardless of its hours and holidays. When overrides overlap, the
+// last one added wins.
+func (s *Schedule) AddOverride(o Override) {
+	s.overrides = append(s.overrides, o)
+}
+
+// AddCalendar adds the holidays and overrides of the calendar.
+func (s *Schedule) AddCalendar(c *Calendar) {
+	s.holidays = append(s.holidays, c.Holidays...)
+	s.overrides = append(s.overrides, c.Overrides...)
+}
+
+// Now returns the current time from the Schedule's clock, in its location.
+func (s *Schedule) Now() time.Time {
+	return s.now().In(s.loc)
+}
+
+// StatusAt returns whether the Schedule is open at t, and why.
+func (s *Schedule) StatusAt(t time.Time) Status {
+	for i := len(s.overrides) - 1; i >= 0; i-- {
+		if o := s.overrides[i]; o.Contains(t) {
+			return Status{Open: o.Open, Reason: ReasonOverride, Name: o.Name}
+		}
+	}
+
+	t = t.In(s.loc)
+
+	// a range that's open past midnight belongs to the day it opened, so a
+	// holiday closes the whole of that day's ranges but none of the day
+	// before's
+	open, day := s.withinHours(t)
+
+	if !open {
+		day = DateOf(t)
+	}
+
+	for _, h := range s.holidays {
+		if h.Contains(day) {
+			return Status{Reason: ReasonHoliday, Name: h.Name}
+		}
+	}
+
+	return Status{Open: open, Reason: ReasonHours}
+}
+
+// withinHours returns whether t, in the Schedule's location, is within the
+// weekly opening hours, including ranges continuing from the day before, and
+// the date the range opened on.
+func (s *Schedule) withinHours(t time.Time) (bool, Date) {
+	m := At(t.Hour(), t.Minute())
+
+	for _, r := range s.weekly[t.Weekday()] {
+		if m >= r.Open && (r.overnight() || m < r.Close) {
+			return true, DateOf(t)
+		}
+	}
+
+	for _, r := range s.weekly[(t.Weekday()+6)%7] {
+		if r.overnight() && m < r.Close {
+			return true, DateOf(t.AddDate(0, 0, -1))
+		}
+	}
+
+	return false, Date{}
+}
+
+// IsOpen returns whether the Schedule is open at t.
+func (s *Schedule) IsOpen(t time.Time) bool {
+	return s.StatusAt(t).Open
+}
+
+// Status returns whether the Schedule is open now, according to its clock.
+func (s *Schedule) Status() Status {
+	return s.StatusAt(s.Now())
+}
+
+// NextOpen returns the first time at or after t that the Schedule is open, in
+// its location. It returns false if the Schedule doesn't open within the next
+// year or so.
+func (s *Schedule) NextOpen(t time.Time) (time.Time, bool) {
+	t = t.In(s.loc)
+
+	if s.IsOpen(t) {
+		return t, true
+	}
+
+	// the Schedule can only open at midnight, at the start of a range, or at
+	// the start or end of an override
+	var candidates []time.Time
+
+	for _, o := range s.overrides {
+		candidates = append(candidates, o.Start, o.End)
+	}
+
+	for i := 0; i <= lookahead; i++ {
+		day := time.Date(t.Year(), t.Month(), t.Day()+i, 0, 0, 0, 0, s.loc)
+		candidates = append(candidates, day)
+
+		for _, r := range s.weekly[day.Weekday()] {
+			candidates = append(candidates, time.Date(day.Year(), day.Month(), day.Day(), r.Open.Hour(), r.Open.Minute(), 0, 0, s.loc))
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	for _, c := range candidates {
+		if c.After(t) && s.IsOpen(c) {
+			return c.In(s.loc), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Branches are alternative responses for when a Schedule is open or closed.
+type Branches struct {
+	Open   *twiml.Response
+	Closed *twiml.Response
+
+	// Holiday is used instead of Closed on holidays, if it's not nil.
+	Holiday *twiml.Response
+}
+
+// Select returns the branch for whether the Schedule is open now.
+func (s *Schedule) Select(b Branches) *twiml.Response {
+	st := s.Status()
+
+	switch {
+	case st.Open:
+		return b.Open
+	case st.Reason == ReasonHoliday && b.Holiday != nil:
+		return b.Holiday
+	default:
+		return b.Closed
+	}
+}
+
+// Targets are alternative URLs to redirect a call to when a Schedule is open
+// or closed.
+type Targets struct {
+	Open   string
+	Closed string
+
+	// Holiday is used instead of Closed on holidays, if it's not empty.
+	Holiday string
+
+	// Method is the HTTP method of the redirect.
+	Method string
+}
+
+// Redirect returns a Redirect verb to the target for whether the Schedule is
+// open now.
+func (s *Schedule) Redirect(t Targets) *twiml.Redirect {
+	st := s.Status()
+	url := t.Closed
+
+	switch {
+	case st.Open:
+		url = t.Open
+	case st.Reason == ReasonHoliday && t.Holiday != "":
+		url = t.Holiday
+	}
+
+	return &twiml.Redirect{URL: url, Method: t.Method}
+}
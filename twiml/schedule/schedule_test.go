@@ -0,0 +1,196 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+var est = time.FixedZone("EST", -5*60*60)
+
+func at(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, est)
+}
+
+// testSchedule is open 9 to 5 on weekdays, 10 to 2 on Saturdays, and late on
+// Fridays, and is closed on Christmas.
+func testSchedule(t *testing.T, opts ...Option) *Schedule {
+	s := New(est, opts...)
+
+	if err := s.SetWeekdays(Range{Open: At(9, 0), Close: At(17, 0)}); err != nil {
+		t.Fatalf("SetWeekdays() error = %s", err)
+	}
+
+	if err := s.SetHours(time.Friday, Range{Open: At(22, 0), Close: At(2, 0)}, Range{Open: At(9, 0), Close: At(17, 0)}); err != nil {
+		t.Fatalf("SetHours() error = %s", err)
+	}
+
+	if err := s.SetHours(time.Saturday, Range{Open: At(10, 0), Close: At(14, 0)}); err != nil {
+		t.Fatalf("SetHours() error = %s", err)
+	}
+
+	s.AddHoliday(Holiday{Name: "Christmas", Date: Date{Month: time.December, Day: 25}, Yearly: true})
+	s.AddHoliday(Holiday{Name: "Staff party", Date: Date{Year: 2026, Month: time.December, Day: 12}})
+	s.AddOverride(Override{Name: "Staff meeting", Start: at(time.October, 20, 12, 0), End: at(time.October, 20, 13, 0)})
+	s.AddOverride(Override{Name: "Open house", Start: at(time.October, 18, 10, 0), End: at(time.October, 18, 12, 0), Open: true})
+
+	return s
+}
+
+func TestSchedule_SetHours(t *testing.T) {
+	tests := []struct {
+		desc string
+		r    Range
+		err  bool
+	}{
+		{"normal range should be valid", Range{Open: At(9, 0), Close: At(17, 30)}, false},
+		{"range to midnight should be valid", Range{Open: At(18, 0), Close: At(24, 0)}, false},
+		{"overnight range should be valid", Range{Open: At(22, 0), Close: At(6, 0)}, false},
+		{"empty range should be invalid", Range{Open: At(9, 0), Close: At(9, 0)}, true},
+		{"open at midnight the next day should be invalid", Range{Open: At(24, 0), Close: At(1, 0)}, true},
+		{"negative time should be invalid", Range{Open: -1, Close: At(1, 0)}, true},
+		{"close after the end of the day should be invalid", Range{Open: At(9, 0), Close: At(25, 0)}, true},
+	}
+
+	for _, test := range tests {
+		err := New(nil).SetHours(time.Monday, test.r)
+
+		if test.err {
+			if errors.Cause(err) != ErrInvalidRange {
+				t.Errorf("\nDescription: %s\nSetHours() error = %v, want cause %v", test.desc, err, ErrInvalidRange)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("\nDescription: %s\nSetHours() unexpected error: %s", test.desc, err)
+		}
+	}
+}
+
+func TestSchedule_Hours(t *testing.T) {
+	s := testSchedule(t)
+
+	hours := s.Hours(time.Friday)
+
+	if len(hours) != 2 || hours[0].String() != "09:00-17:00" || hours[1].String() != "22:00-02:00" {
+		t.Errorf("Hours(Friday) = %v, want sorted ranges [09:00-17:00 22:00-02:00]", hours)
+	}
+
+	if hours := s.Hours(time.Sunday); len(hours) != 0 {
+		t.Errorf("Hours(Sunday) = %v, want none", hours)
+	}
+}
+
+func TestSchedule_StatusAt(t *testing.T) {
+	s := testSchedule(t)
+
+	tests := []struct {
+		desc   string
+		t      time.Time
+		status Status
+	}{
+		{"before opening should be closed", at(time.October, 19, 8, 59), Status{Reason: ReasonHours}},
+		{"opening time should be open", at(time.October, 19, 9, 0), Status{Open: true, Reason: ReasonHours}},
+		{"closing time should be closed", at(time.October, 19, 17, 0), Status{Reason: ReasonHours}},
+		{"time in another zone should be converted", time.Date(2026, time.October, 19, 14, 0, 0, 0, time.UTC), Status{Open: true, Reason: ReasonHours}},
+		{"overnight range should be open", at(time.October, 16, 23, 0), Status{Open: true, Reason: ReasonHours}},
+		{"overnight range should be open after midnight", at(time.October, 17, 1, 30), Status{Open: true, Reason: ReasonHours}},
+		{"overnight range should close", at(time.October, 17, 2, 0), Status{Reason: ReasonHours}},
+		{"Saturday hours should be open", at(time.October, 17, 12, 0), Status{Open: true, Reason: ReasonHours}},
+		{"Sunday should be closed", at(time.October, 25, 12, 0), Status{Reason: ReasonHours}},
+		{"closing override should be closed", at(time.October, 20, 12, 30), Status{Reason: ReasonOverride, Name: "Staff meeting"}},
+		{"closing override should end", at(time.October, 20, 13, 0), Status{Open: true, Reason: ReasonHours}},
+		{"opening override should be open", at(time.October, 18, 11, 0), Status{Open: true, Reason: ReasonOverride, Name: "Open house"}},
+		{"holiday should be closed", at(time.December, 25, 10, 0), Status{Reason: ReasonHoliday, Name: "Christmas"}},
+		{"holiday should close its overnight range", at(time.December, 26, 1, 0), Status{Reason: ReasonHoliday, Name: "Christmas"}},
+		{"holiday should not close the day before's overnight range", at(time.December, 12, 1, 0), Status{Open: true, Reason: ReasonHours}},
+		{"holiday should close after the day before's overnight range", at(time.December, 12, 2, 0), Status{Reason: ReasonHoliday, Name: "Staff party"}},
+	}
+
+	for _, test := range tests {
+		if status := s.StatusAt(test.t); status != test.status {
+			t.Errorf("\nDescription: %s\nStatusAt(%s) = %+v, want %+v", test.desc, test.t, status, test.status)
+		}
+	}
+}
+
+func TestSchedule_NextOpen(t *testing.T) {
+	s := testSchedule(t)
+
+	tests := []struct {
+		desc string
+		t    time.Time
+		want time.Time
+	}{
+		{"open should be now", at(time.October, 19, 10, 0), at(time.October, 19, 10, 0)},
+		{"before opening should be the opening", at(time.October, 19, 7, 15), at(time.October, 19, 9, 0)},
+		{"after closing should be the next day", at(time.October, 19, 18, 0), at(time.October, 20, 9, 0)},
+		{"override should be an opening", at(time.October, 17, 15, 0), at(time.October, 18, 10, 0)},
+		{"end of a closing override should be an opening", at(time.October, 20, 12, 15), at(time.October, 20, 13, 0)},
+		{"holiday should be skipped", at(time.December, 24, 18, 0), at(time.December, 26, 10, 0)},
+	}
+
+	for _, test := range tests {
+		got, ok := s.NextOpen(test.t)
+
+		if !ok || !got.Equal(test.want) {
+			t.Errorf("\nDescription: %s\nNextOpen(%s) = %s, %t, want %s", test.desc, test.t, got, ok, test.want)
+		}
+
+		if ok && got.Location() != est {
+			t.Errorf("\nDescription: %s\nNextOpen() location = %s, want %s", test.desc, got.Location(), est)
+		}
+	}
+
+	if _, ok := New(est).NextOpen(at(time.October, 19, 10, 0)); ok {
+		t.Error("NextOpen() of a schedule without hours should be false")
+	}
+}
+
+func TestSchedule_Select(t *testing.T) {
+	open := &twiml.Response{Verbs: []interface{}{&twiml.Say{Message: "open"}}}
+	closed := &twiml.Response{Verbs: []interface{}{&twiml.Say{Message: "closed"}}}
+	holiday := &twiml.Response{Verbs: []interface{}{&twiml.Say{Message: "holiday"}}}
+
+	tests := []struct {
+		desc     string
+		now      time.Time
+		branches Branches
+		resp     *twiml.Response
+		url      string
+	}{
+		{"open should be the open branch", at(time.October, 19, 10, 0), Branches{Open: open, Closed: closed, Holiday: holiday}, open, "/open"},
+		{"closed should be the closed branch", at(time.October, 19, 20, 0), Branches{Open: open, Closed: closed, Holiday: holiday}, closed, "/closed"},
+		{"holiday should be the holiday branch", at(time.December, 25, 10, 0), Branches{Open: open, Closed: closed, Holiday: holiday}, holiday, "/holiday"},
+		{"holiday without a branch should be closed", at(time.December, 25, 10, 0), Branches{Open: open, Closed: closed}, closed, "/closed"},
+	}
+
+	for _, test := range tests {
+		now := test.now
+		s := testSchedule(t, WithClock(func() time.Time { return now }))
+
+		if resp := s.Select(test.branches); resp != test.resp {
+			t.Errorf("\nDescription: %s\nSelect() = %v, want %v", test.desc, resp.Verbs[0], test.resp.Verbs[0])
+		}
+
+		targets := Targets{Open: "/open", Closed: "/closed", Method: "POST"}
+
+		if test.branches.Holiday != nil {
+			targets.Holiday = "/holiday"
+		}
+
+		if r := s.Redirect(targets); r.URL != test.url || r.Method != "POST" {
+			t.Errorf("\nDescription: %s\nRedirect() = %+v, want URL %q and method POST", test.desc, r, test.url)
+		}
+	}
+}
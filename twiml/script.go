@@ -0,0 +1,276 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WriteScript renders the *Response as a plain-text script of what the caller
+// experiences, one line per verb or noun, and writes it to w. Each line starts
+// with the element name and its notable attributes in brackets, using the
+// TwiML values of the enums, followed by any text the element contains:
+//
+//	[Say, alice, en-US, x2] Welcome to Example Corp.
+//	[Gather, 4 digits, finish on #, timeout 5s]
+//	  [Say] Please enter your PIN.
+//	[Dial +15555550100, ring tone: uk, record-from-answer-dual]
+//
+// Nested verbs and nouns are indented by two spaces per level. Sensitive
+// values are not redacted, so use RedactPolicy.Redact() first if the script
+// will be shared. This function returns a wrapped error (see package
+// documentation for more info).
+func WriteScript(w io.Writer, r *Response) error {
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	if r != nil {
+		_ = Walk(r, func(path Path, node interface{}) error {
+			buf.WriteString(strings.Repeat("  ", path.Depth()-1))
+			buf.WriteString(scriptLine(node))
+			buf.WriteByte('\n')
+
+			return nil
+		})
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "writing script failed")
+	}
+
+	return nil
+}
+
+// Script is like WriteScript, but returns the script as a string.
+func Script(r *Response) string {
+	buf := &bytes.Buffer{}
+
+	// writing to a *bytes.Buffer doesn't fail
+	_ = WriteScript(buf, r)
+
+	return buf.String()
+}
+
+// scriptLine renders a single verb or noun in the form "[Name subject, attr,
+// attr] text".
+func scriptLine(node interface{}) string {
+	var subject, text string
+	var attrs []string
+
+	// add appends the non-empty values to attrs
+	add := func(values ...string) {
+		for _, v := range values {
+			if v != "" {
+				attrs = append(attrs, v)
+			}
+		}
+	}
+
+	switch v := derefNode(node).(type) {
+	case Say:
+		add(v.Voice.String(), v.Language.String(), loops(v.Loop))
+		text = v.Message
+	case Play:
+		add(loops(v.Loop), labelled("digits", v.Digits.String()))
+		text = v.URL
+	case Pause:
+		if v.Length > 0 {
+			subject = seconds(v.Length)
+		}
+	case Gather:
+		add(gatherInput(v), labelled("hints", v.Hints), finishOn(v.FinishOnKey))
+
+		if v.Timeout > 0 {
+			add("timeout " + seconds(v.Timeout))
+		}
+
+		add(v.Language.String(), labelled("barge in", v.BargeIn.String()), labelled("action", v.Action))
+	case Record:
+		if v.MaxLength > 0 {
+			add("max " + seconds(v.MaxLength))
+		}
+
+		add(finishOn(v.FinishOnKey))
+
+		if v.Timeout > 0 {
+			add("timeout " + seconds(v.Timeout))
+		}
+
+		if v.PlayBeep {
+			add("beep")
+		}
+
+		if v.Transcribe {
+			add("transcribe")
+		}
+
+		add(v.Trim.String(), labelled("action", v.Action))
+	case Dial:
+		subject = string(v.Number)
+
+		add(labelled("caller ID", string(v.CallerID)))
+
+		if v.Timeout > 0 {
+			add("timeout " + seconds(v.Timeout))
+		}
+
+		if v.TimeLimit > 0 {
+			add("time limit " + seconds(v.TimeLimit))
+		}
+
+		if v.RingTone != RingToneAutomatic {
+			add(labelled("ring tone", v.RingTone.String()))
+		}
+
+		add(v.Record.String(), v.Trim.String())
+
+		if v.AnswerOnBridge {
+			add("answer on bridge")
+		}
+
+		if v.HangupOnStar {
+			add("hang up on *")
+		}
+
+		add(labelled("action", v.Action))
+	case DialNumber:
+		subject = string(v.Number)
+		add(labelled("send digits", v.SendDigits.String()), labelled("whisper", v.URL))
+	case DialClient:
+		subject = v.ClientName
+		add(labelled("whisper", v.URL))
+	case DialConference:
+		subject = v.Name
+
+		if v.Muted {
+			add("muted")
+		}
+
+		add(labelled("beep", v.Beep.String()), labelled("start on enter", v.StartConferenceOnEnter.String()))
+
+		if v.EndConferenceOnExit {
+			add("end on exit")
+		}
+
+		add(v.Record.String(), labelled("region", v.Region.String()), labelled("wait", v.WaitURL))
+	case DialQueue:
+		subject = v.QueueName
+	case DialSIM:
+		subject = v.SIM
+	case DialSIP:
+		subject = v.URI
+	case Enqueue:
+		subject = v.QueueName
+		add(labelled("wait", v.WaitURL), labelled("workflow", v.WorkflowSID), labelled("action", v.Action))
+		text = v.Task
+	case Redirect:
+		add(v.Method)
+		text = v.URL
+	case Reject:
+		add(v.Reason.String())
+	case Sms:
+		add(labelled("to", string(v.To)), labelled("from", string(v.From)))
+		text = v.Message
+	case Stream:
+		subject = v.Name
+		add(v.URL, labelled("track", v.Track.String()))
+	case Siprec:
+		subject = v.Name
+		add(labelled("connector", v.ConnectorName), labelled("track", v.Track.String()))
+	case Parameter:
+		subject = v.Name
+		text = v.Value
+	case Pay:
+		amount := strings.TrimSpace(v.ChargeAmount + " " + v.Currency)
+		add(labelled("input", v.Input.String()), labelled("method", v.PaymentMethod.String()), labelled("amount", amount))
+	case Prompt:
+		add(labelled("for", v.For.String()), labelled("attempt", v.Attempt))
+	case Room:
+		subject = v.Name
+	}
+
+	line := "[" + NodeName(node)
+
+	if subject != "" {
+		line += " " + subject
+	}
+
+	if len(attrs) > 0 {
+		line += ", " + strings.Join(attrs, ", ")
+	}
+
+	line += "]"
+
+	if text = strings.Join(strings.Fields(text), " "); text != "" {
+		line += " " + text
+	}
+
+	return line
+}
+
+// labelled returns "label: value", or an empty string if value is empty.
+func labelled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return label + ": " + value
+}
+
+func loops(n uint) string {
+	if n <= 1 {
+		return ""
+	}
+
+	return fmt.Sprintf("x%d", n)
+}
+
+func seconds(n uint) string {
+	return fmt.Sprintf("%ds", n)
+}
+
+func finishOn(f FinishOnKey) string {
+	switch f {
+	case 0:
+		return ""
+	case FinishKeyNone:
+		return "no finish key"
+	default:
+		return "finish on " + f.String()
+	}
+}
+
+// gatherInput describes the input a Gather verb accepts, such as "4 digits"
+// or "1 digit or speech". It's shared by the caller script and the CallFlow
+// edge labels.
+func gatherInput(g Gather) string {
+	var parts []string
+
+	if g.Input == 0 || g.Input&GatherInputDTMF != 0 {
+		switch g.NumDigits {
+		case 0:
+			parts = append(parts, "digits")
+		case 1:
+			parts = append(parts, "1 digit")
+		default:
+			parts = append(parts, fmt.Sprintf("%d digits", g.NumDigits))
+		}
+	}
+
+	if g.Input&GatherInputSpeech != 0 {
+		parts = append(parts, "speech")
+	}
+
+	return strings.Join(parts, " or ")
+}
@@ -0,0 +1,94 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	r := &Response{Verbs: []interface{}{
+		&Say{Message: "Welcome to\n Example Corp.", Voice: VoiceAlice, Language: LangEnglishUS, Loop: 2},
+		&Gather{
+			NumDigits:   4,
+			FinishOnKey: FinishKeyPound,
+			Timeout:     5,
+			NestedVerbs: []interface{}{
+				&Say{Message: "Please enter your PIN."},
+				&Play{URL: "https://example.org/beep.mp3"},
+			},
+		},
+		&Dial{
+			Number:   "+15555550100",
+			RingTone: RingToneUK,
+			Record:   DialRecordFromAnswerDual,
+		},
+		&Dial{Nouns: []interface{}{
+			&DialNumber{Number: "+15555550101", SendDigits: "ww1234"},
+			&DialConference{Name: "standup", Muted: true, Beep: ConfBeepFalse},
+		}},
+		Pause{Length: 2},
+		&Record{MaxLength: 30, FinishOnKey: FinishKeyNone, PlayBeep: true, Transcribe: true},
+		&Redirect{URL: "/main", Method: "GET"},
+		&Reject{Reason: RejectReasonBusy},
+		&Hangup{},
+	}}
+
+	want := `[Say, alice, en-US, x2] Welcome to Example Corp.
+[Gather, 4 digits, finish on #, timeout 5s]
+  [Say] Please enter your PIN.
+  [Play] https://example.org/beep.mp3
+[Dial +15555550100, ring tone: uk, record-from-answer-dual]
+[Dial]
+  [Number +15555550101, send digits: ww1234]
+  [Conference standup, muted, beep: false]
+[Pause 2s]
+[Record, max 30s, no finish key, beep, transcribe]
+[Redirect, GET] /main
+[Reject, busy]
+[Hangup]
+`
+
+	if got := Script(r); got != want {
+		t.Errorf("Script() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteScript(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *Response
+		out  string
+	}{
+		{"nil response should render nothing", nil, ""},
+		{"empty response should render nothing", &Response{}, ""},
+		{
+			desc: "speech gather should describe its input",
+			in:   &Response{Verbs: []interface{}{&Gather{Input: GatherInputDTMFSpeech, NumDigits: 1, Hints: "yes, no"}}},
+			out:  "[Gather, 1 digit or speech, hints: yes, no]\n",
+		},
+		{
+			desc: "unknown types should render their name",
+			in:   &Response{Verbs: []interface{}{&Leave{}, &Sms{Message: "Hi", To: "+15555550100"}}},
+			out:  "[Leave]\n[Sms, to: +15555550100] Hi\n",
+		},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+
+		if err := WriteScript(buf, test.in); err != nil {
+			t.Errorf("\nDescription: %s\nWriteScript() Unexpected Error: %s", test.desc, err)
+			continue
+		}
+
+		if got := buf.String(); got != test.out {
+			t.Errorf("\nDescription: %s\nWriteScript() = %q, want %q", test.desc, got, test.out)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"strings"
+	"time"
+)
+
+var englishOnes = [20]string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+	"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+	"seventeen", "eighteen", "nineteen",
+}
+
+var englishTens = [10]string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+}
+
+var englishScales = []string{
+	"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+}
+
+func englishBelow100(n uint64) string {
+	if n < 20 {
+		return englishOnes[n]
+	}
+
+	if n%10 == 0 {
+		return englishTens[n/10]
+	}
+
+	return englishTens[n/10] + "-" + englishOnes[n%10]
+}
+
+func englishBelow1000(n uint64) string {
+	switch h, r := n/100, n%100; {
+	case h == 0:
+		return englishBelow100(r)
+	case r == 0:
+		return englishOnes[h] + " hundred"
+	default:
+		return englishOnes[h] + " hundred " + englishBelow100(r)
+	}
+}
+
+func englishCardinal(n uint64) string {
+	if n == 0 {
+		return englishOnes[0]
+	}
+
+	var words []string
+
+	g := groups(n)
+
+	for i := len(g) - 1; i >= 0; i-- {
+		if g[i] == 0 {
+			continue
+		}
+
+		words = append(words, englishBelow1000(g[i]))
+
+		if englishScales[i] != "" {
+			words = append(words, englishScales[i])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+var englishIrregularOrdinals = map[string]string{
+	"one":    "first",
+	"two":    "second",
+	"three":  "third",
+	"five":   "fifth",
+	"eight":  "eighth",
+	"nine":   "ninth",
+	"twelve": "twelfth",
+}
+
+func englishOrdinal(n uint64) string {
+	s := englishCardinal(n)
+
+	// only the last word changes, e.g. "twenty-one" to "twenty-first"
+	i := strings.LastIndexAny(s, " -") + 1
+	last := s[i:]
+
+	switch {
+	case englishIrregularOrdinals[last] != "":
+		last = englishIrregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+
+	return s[:i] + last
+}
+
+// englishYear reads years the way they're usually said, e.g. "nineteen oh
+// five" and "twenty twenty-six", except for 2000 through 2009.
+func englishYear(y int) string {
+	if y < 1000 || y > 9999 || (y >= 2000 && y < 2010) || y%1000 == 0 {
+		return englishCardinal(uint64(y))
+	}
+
+	hi, lo := uint64(y/100), uint64(y%100)
+
+	switch {
+	case lo == 0:
+		return englishBelow100(hi) + " hundred"
+	case lo < 10:
+		return englishBelow100(hi) + " oh " + englishOnes[lo]
+	default:
+		return englishBelow100(hi) + " " + englishBelow100(lo)
+	}
+}
+
+func englishClock(_ *lexicon, hour, minute int) string {
+	switch {
+	case hour == 0 && minute == 0:
+		return "midnight"
+	case hour == 12 && minute == 0:
+		return "noon"
+	}
+
+	suffix := "AM"
+
+	if hour >= 12 {
+		suffix = "PM"
+	}
+
+	h := hour % 12
+
+	if h == 0 {
+		h = 12
+	}
+
+	switch {
+	case minute == 0:
+		return englishCardinal(uint64(h)) + " " + suffix
+	case minute < 10:
+		return englishCardinal(uint64(h)) + " oh " + englishOnes[minute] + " " + suffix
+	default:
+		return englishCardinal(uint64(h)) + " " + englishBelow100(uint64(minute)) + " " + suffix
+	}
+}
+
+var english = &lexicon{
+	cardinal: englishCardinal,
+	before:   sameBefore(englishCardinal),
+	ordinal:  englishOrdinal,
+	date: func(l *lexicon, t time.Time) string {
+		return l.months[t.Month()-1] + " " + englishOrdinal(uint64(t.Day())) + ", " + englishYear(t.Year())
+	},
+	clock:    englishClock,
+	minus:    "minus",
+	point:    "point",
+	plus:     "plus",
+	infinity: "infinity",
+	nan:      "not a number",
+	and:      "and",
+	moneyAnd: "and",
+	digits:   [10]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"},
+	months: [12]string{
+		"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December",
+	},
+	weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	hour:     unit{one: "hour", other: "hours"},
+	minute:   unit{one: "minute", other: "minutes"},
+	second:   unit{one: "second", other: "seconds"},
+	currencies: map[string]currency{
+		"USD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"CAD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"AUD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"EUR": {unit{one: "euro", other: "euros"}, unit{one: "cent", other: "cents"}},
+		"GBP": {unit{one: "pound", other: "pounds"}, unit{one: "penny", other: "pence"}},
+		"MXN": {unit{one: "peso", other: "pesos"}, unit{one: "centavo", other: "centavos"}},
+	},
+}
+
+// englishUK puts the day before the month, e.g. "the seventeenth of October,
+// twenty twenty-six".
+var englishUK = english.variant(func(l *lexicon) {
+	l.date = func(l *lexicon, t time.Time) string {
+		return "the " + englishOrdinal(uint64(t.Day())) + " of " + l.months[t.Month()-1] + ", " + englishYear(t.Year())
+	}
+})
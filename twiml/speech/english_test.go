@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio/twiml"
+)
+
+func TestEnglish(t *testing.T) {
+	runFormatTests(t, twiml.LangEnglishUS, []formatTest{
+		{"zero", number(0), "zero"},
+		{"compound tens should be hyphenated", number(21), "twenty-one"},
+		{"thousands", number(1234), "one thousand two hundred thirty-four"},
+		{"empty groups should be left out", number(1000001), "one million one"},
+		{"negative numbers", number(-5), "minus five"},
+		{"ordinal first", ordinal(1), "first"},
+		{"ordinal twelfth", ordinal(12), "twelfth"},
+		{"compound ordinal should change the last word", ordinal(21), "twenty-first"},
+		{"ordinal of a tens", ordinal(40), "fortieth"},
+		{"ordinal of a hundred", ordinal(103), "one hundred third"},
+		{"decimal should read digits", decimal(3.14159, 2), "three point one four"},
+		{"negative decimal", decimal(-0.5, 1), "minus zero point five"},
+		{"decimal without places", decimal(2, 0), "two"},
+		{"NaN", decimal(math.NaN(), 2), "not a number"},
+		{"infinity", decimal(math.Inf(1), 2), "infinity"},
+		{"negative infinity", decimal(math.Inf(-1), 2), "minus infinity"},
+		{"dollars and cents", money(123450, "USD"), "one thousand two hundred thirty-four dollars and fifty cents"},
+		{"one dollar should be singular", money(100, "usd"), "one dollar"},
+		{"cents only", money(1, "CAD"), "one cent"},
+		{"pounds and pence", money(250, "GBP"), "two pounds and fifty pence"},
+		{"negative euros", money(-500, "EUR"), "minus five euros"},
+		{"US date", date(2026, time.October, 17), "October seventeenth, twenty twenty-six"},
+		{"year with a zero", date(1905, time.January, 1), "January first, nineteen oh five"},
+		{"year in the 2000s", date(2005, time.March, 2), "March second, two thousand five"},
+		{"year of whole hundreds", date(1900, time.May, 3), "May third, nineteen hundred"},
+		{"weekday", weekday(2026, time.October, 17), "Saturday"},
+		{"midnight", clock(0, 0), "midnight"},
+		{"noon", clock(12, 0), "noon"},
+		{"afternoon", clock(14, 30), "two thirty PM"},
+		{"minutes below ten", clock(9, 5), "nine oh five AM"},
+		{"just after midnight", clock(0, 15), "twelve fifteen AM"},
+		{"on the hour", clock(7, 0), "seven AM"},
+		{"duration list", duration(3903 * time.Second), "one hour, five minutes and three seconds"},
+		{"zero duration", duration(0), "zero seconds"},
+		{"duration should round to seconds", duration(1500 * time.Millisecond), "two seconds"},
+	})
+
+	runFormatTests(t, twiml.LangEnglishUK, []formatTest{
+		{"UK date", date(2026, time.October, 17), "the seventeenth of October, twenty twenty-six"},
+		{"UK money", money(100, "GBP"), "one pound"},
+	})
+}
@@ -0,0 +1,206 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"strings"
+	"time"
+)
+
+var frenchOnes = [17]string{
+	"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+	"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize",
+}
+
+var frenchTens = [7]string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante"}
+
+// frenchScales are the scales above a thousand, which are nouns with a plural.
+var frenchScales = []unit{
+	{}, {},
+	{one: "million", other: "millions"},
+	{one: "milliard", other: "milliards"},
+	{one: "billion", other: "billions"},
+	{one: "billiard", other: "billiards"},
+	{one: "trillion", other: "trillions"},
+}
+
+func frenchBelow100(n uint64) string {
+	switch {
+	case n < 17:
+		return frenchOnes[n]
+	case n < 20:
+		return "dix-" + frenchOnes[n-10]
+	case n < 70:
+		t, u := n/10, n%10
+
+		switch u {
+		case 0:
+			return frenchTens[t]
+		case 1:
+			return frenchTens[t] + " et un"
+		default:
+			return frenchTens[t] + "-" + frenchOnes[u]
+		}
+	case n == 71:
+		return "soixante et onze"
+	case n < 80:
+		return "soixante-" + frenchBelow100(n-60)
+	case n == 80:
+		return "quatre-vingts"
+	default:
+		return "quatre-vingt-" + frenchBelow100(n-80)
+	}
+}
+
+// frenchBelow1000 spells out n. Plural hundreds only take an s at the end of
+// the number, so final is false when n multiplies "mille".
+func frenchBelow1000(n uint64, final bool) string {
+	h, r := n/100, n%100
+
+	if h == 0 {
+		s := frenchBelow100(r)
+
+		if !final && r == 80 {
+			s = strings.TrimSuffix(s, "s")
+		}
+
+		return s
+	}
+
+	s := "cent"
+
+	if h > 1 {
+		s = frenchOnes[h] + " cent"
+	}
+
+	switch {
+	case r > 0:
+		return s + " " + frenchBelow1000(r, final)
+	case h > 1 && final:
+		return s + "s"
+	default:
+		return s
+	}
+}
+
+func frenchCardinal(n uint64) string {
+	if n == 0 {
+		return frenchOnes[0]
+	}
+
+	var words []string
+
+	g := groups(n)
+
+	for i := len(g) - 1; i >= 0; i-- {
+		switch {
+		case g[i] == 0:
+		case i == 0:
+			words = append(words, frenchBelow1000(g[i], true))
+		case i == 1 && g[i] == 1:
+			words = append(words, "mille")
+		case i == 1:
+			words = append(words, frenchBelow1000(g[i], false)+" mille")
+		case g[i] == 1:
+			words = append(words, "un "+frenchScales[i].one)
+		default:
+			words = append(words, frenchBelow1000(g[i], true)+" "+frenchScales[i].other)
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// frenchBefore uses "une" for one before a feminine noun, including at the end
+// of a larger number (e.g., "vingt et une heures").
+func frenchBefore(n uint64, feminine bool) string {
+	s := frenchCardinal(n)
+
+	if feminine && (s == "un" || strings.HasSuffix(s, " un") || strings.HasSuffix(s, "-un")) {
+		s += "e"
+	}
+
+	return s
+}
+
+func frenchOrdinal(n uint64) string {
+	if n == 1 {
+		return "premier"
+	}
+
+	s := frenchCardinal(n)
+
+	switch {
+	case strings.HasSuffix(s, "cents"), strings.HasSuffix(s, "vingts"):
+		s = strings.TrimSuffix(s, "s")
+	case strings.HasSuffix(s, "cinq"):
+		s += "u"
+	case strings.HasSuffix(s, "neuf"):
+		s = strings.TrimSuffix(s, "f") + "v"
+	}
+
+	return strings.TrimSuffix(s, "e") + "ième"
+}
+
+var french = &lexicon{
+	cardinal: frenchCardinal,
+	before:   frenchBefore,
+	ordinal:  frenchOrdinal,
+	date: func(l *lexicon, t time.Time) string {
+		day := frenchCardinal(uint64(t.Day()))
+
+		if t.Day() == 1 {
+			day = "premier"
+		}
+
+		return "le " + day + " " + l.months[t.Month()-1] + " " + frenchCardinal(uint64(t.Year()))
+	},
+	clock: func(_ *lexicon, hour, minute int) string {
+		switch {
+		case hour == 0 && minute == 0:
+			return "minuit"
+		case hour == 12 && minute == 0:
+			return "midi"
+		}
+
+		s := frenchBefore(uint64(hour), true) + " heure"
+
+		if hour > 1 {
+			s += "s"
+		}
+
+		if minute > 0 {
+			s += " " + frenchBefore(uint64(minute), true)
+		}
+
+		return s
+	},
+	minus:    "moins",
+	point:    "virgule",
+	plus:     "plus",
+	infinity: "infini",
+	nan:      "pas un nombre",
+	and:      "et",
+	moneyAnd: "et",
+	digits:   [10]string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf"},
+	months: [12]string{
+		"janvier", "février", "mars", "avril", "mai", "juin", "juillet",
+		"août", "septembre", "octobre", "novembre", "décembre",
+	},
+	weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	hour:     unit{one: "heure", other: "heures", feminine: true},
+	minute:   unit{one: "minute", other: "minutes", feminine: true},
+	second:   unit{one: "seconde", other: "secondes", feminine: true},
+	currencies: map[string]currency{
+		"USD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"CAD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"AUD": {unit{one: "dollar", other: "dollars"}, unit{one: "cent", other: "cents"}},
+		"EUR": {unit{one: "euro", other: "euros"}, unit{one: "centime", other: "centimes"}},
+		"GBP": {unit{one: "livre", other: "livres", feminine: true}, unit{one: "penny", other: "pence"}},
+		"MXN": {unit{one: "peso", other: "pesos"}, unit{one: "centavo", other: "centavos"}},
+	},
+}
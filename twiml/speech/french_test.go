@@ -0,0 +1,53 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio/twiml"
+)
+
+func TestFrench(t *testing.T) {
+	runFormatTests(t, twiml.LangFrenchFrance, []formatTest{
+		{"zero", number(0), "zéro"},
+		{"twenty-one", number(21), "vingt et un"},
+		{"seventy-one", number(71), "soixante et onze"},
+		{"seventy-seven", number(77), "soixante-dix-sept"},
+		{"eighty", number(80), "quatre-vingts"},
+		{"eighty-one", number(81), "quatre-vingt-un"},
+		{"ninety-one", number(91), "quatre-vingt-onze"},
+		{"plural hundreds", number(200), "deux cents"},
+		{"hundreds followed by a number", number(201), "deux cent un"},
+		{"one thousand", number(1000), "mille"},
+		{"eighty thousand", number(80000), "quatre-vingt mille"},
+		{"hundreds of thousands", number(200000), "deux cent mille"},
+		{"one million", number(1000000), "un million"},
+		{"millions", number(2000000), "deux millions"},
+		{"ordinal first", ordinal(1), "premier"},
+		{"ordinal fifth", ordinal(5), "cinquième"},
+		{"ordinal ninth", ordinal(9), "neuvième"},
+		{"ordinal fourth", ordinal(4), "quatrième"},
+		{"compound ordinal", ordinal(21), "vingt et unième"},
+		{"ordinal eightieth", ordinal(80), "quatre-vingtième"},
+		{"ordinal of hundreds", ordinal(200), "deux centième"},
+		{"decimal", decimal(3.14, 2), "trois virgule un quatre"},
+		{"euros and centimes", money(2101, "EUR"), "vingt et un euros et un centime"},
+		{"feminine currency", money(100, "GBP"), "une livre"},
+		{"compound feminine currency", money(2100, "GBP"), "vingt et une livres"},
+		{"date", date(2026, time.October, 17), "le dix-sept octobre deux mille vingt-six"},
+		{"first of the month", date(2026, time.May, 1), "le premier mai deux mille vingt-six"},
+		{"weekday", weekday(2026, time.October, 17), "samedi"},
+		{"midnight", clock(0, 0), "minuit"},
+		{"noon", clock(12, 0), "midi"},
+		{"afternoon", clock(14, 30), "quatorze heures trente"},
+		{"one o'clock", clock(1, 21), "une heure vingt et une"},
+		{"feminine hours", clock(21, 0), "vingt et une heures"},
+		{"duration", duration(3661 * time.Second), "une heure, une minute et une seconde"},
+	})
+}
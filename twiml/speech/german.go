@@ -0,0 +1,207 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"strings"
+	"time"
+)
+
+var germanOnes = [20]string{
+	"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+	"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn",
+	"siebzehn", "achtzehn", "neunzehn",
+}
+
+var germanTens = [10]string{
+	"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig",
+}
+
+// germanScales are the scales above a thousand, which are separate words
+// with a plural.
+var germanScales = []unit{
+	{}, {},
+	{one: "Million", other: "Millionen"},
+	{one: "Milliarde", other: "Milliarden"},
+	{one: "Billion", other: "Billionen"},
+	{one: "Billiarde", other: "Billiarden"},
+	{one: "Trillion", other: "Trillionen"},
+}
+
+// germanBelow100 spells out n, using "ein" for one within compounds such as
+// "einundzwanzig" and "einhundert".
+func germanBelow100(n uint64, compound bool) string {
+	switch {
+	case n == 1 && compound:
+		return "ein"
+	case n < 20:
+		return germanOnes[n]
+	case n%10 == 0:
+		return germanTens[n/10]
+	case n%10 == 1:
+		return "einund" + germanTens[n/10]
+	default:
+		return germanOnes[n%10] + "und" + germanTens[n/10]
+	}
+}
+
+func germanBelow1000(n uint64, compound bool) string {
+	h, r := n/100, n%100
+
+	if h == 0 {
+		return germanBelow100(r, compound)
+	}
+
+	s := germanBelow100(h, true) + "hundert"
+
+	if r > 0 {
+		s += germanBelow100(r, compound)
+	}
+
+	return s
+}
+
+func germanCardinal(n uint64) string {
+	if n == 0 {
+		return germanOnes[0]
+	}
+
+	var words []string
+
+	g := groups(n)
+
+	for i := len(g) - 1; i >= 2; i-- {
+		switch {
+		case g[i] == 0:
+		case g[i] == 1:
+			words = append(words, "eine "+germanScales[i].one)
+		default:
+			words = append(words, germanBelow1000(g[i], false)+" "+germanScales[i].other)
+		}
+	}
+
+	// numbers below a million are written as a single word
+	var low string
+
+	if len(g) > 1 && g[1] > 0 {
+		low = germanBelow1000(g[1], true) + "tausend"
+	}
+
+	if g[0] > 0 {
+		low += germanBelow1000(g[0], false)
+	}
+
+	if low != "" {
+		words = append(words, low)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// germanBefore uses "ein" or "eine" for one before a noun, including at the
+// end of a larger number (e.g., "hundertein Euro").
+func germanBefore(n uint64, feminine bool) string {
+	switch {
+	case n == 1 && feminine:
+		return "eine"
+	case n == 1:
+		return "ein"
+	}
+
+	s := germanCardinal(n)
+
+	if strings.HasSuffix(s, "eins") {
+		s = strings.TrimSuffix(s, "s")
+	}
+
+	return s
+}
+
+var germanIrregularOrdinals = map[uint64]string{
+	1: "erste",
+	3: "dritte",
+	7: "siebte",
+	8: "achte",
+}
+
+func germanOrdinal(n uint64) string {
+	r := n % 100
+
+	if r == 0 || r >= 20 {
+		return germanCardinal(n) + "ste"
+	}
+
+	var prefix string
+
+	if n > r {
+		prefix = germanCardinal(n - r)
+	}
+
+	if s, ok := germanIrregularOrdinals[r]; ok {
+		return prefix + s
+	}
+
+	return prefix + germanOnes[r] + "te"
+}
+
+// germanYear reads years before 2000 in hundreds, e.g.
+// "neunzehnhundertfünf".
+func germanYear(y int) string {
+	if y >= 1100 && y < 2000 {
+		s := germanBelow100(uint64(y/100), false) + "hundert"
+
+		if y%100 > 0 {
+			s += germanBelow100(uint64(y%100), false)
+		}
+
+		return s
+	}
+
+	return germanCardinal(uint64(y))
+}
+
+var german = &lexicon{
+	cardinal: germanCardinal,
+	before:   germanBefore,
+	ordinal:  germanOrdinal,
+	date: func(l *lexicon, t time.Time) string {
+		return "der " + germanOrdinal(uint64(t.Day())) + " " + l.months[t.Month()-1] + " " + germanYear(t.Year())
+	},
+	clock: func(_ *lexicon, hour, minute int) string {
+		s := germanBefore(uint64(hour), false) + " Uhr"
+
+		if minute > 0 {
+			s += " " + germanCardinal(uint64(minute))
+		}
+
+		return s
+	},
+	minus:    "minus",
+	point:    "Komma",
+	plus:     "plus",
+	infinity: "unendlich",
+	nan:      "keine Zahl",
+	and:      "und",
+	moneyAnd: "und",
+	digits:   [10]string{"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun"},
+	months: [12]string{
+		"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+		"August", "September", "Oktober", "November", "Dezember",
+	},
+	weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	hour:     unit{one: "Stunde", other: "Stunden", feminine: true},
+	minute:   unit{one: "Minute", other: "Minuten", feminine: true},
+	second:   unit{one: "Sekunde", other: "Sekunden", feminine: true},
+	currencies: map[string]currency{
+		"USD": {unit{one: "Dollar", other: "Dollar"}, unit{one: "Cent", other: "Cent"}},
+		"CAD": {unit{one: "Dollar", other: "Dollar"}, unit{one: "Cent", other: "Cent"}},
+		"AUD": {unit{one: "Dollar", other: "Dollar"}, unit{one: "Cent", other: "Cent"}},
+		"EUR": {unit{one: "Euro", other: "Euro"}, unit{one: "Cent", other: "Cent"}},
+		"GBP": {unit{one: "Pfund", other: "Pfund"}, unit{one: "Penny", other: "Pence"}},
+		"MXN": {unit{one: "Peso", other: "Pesos"}, unit{one: "Centavo", other: "Centavos"}},
+	},
+}
@@ -0,0 +1,48 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio/twiml"
+)
+
+func TestGerman(t *testing.T) {
+	runFormatTests(t, twiml.LangGermanGermany, []formatTest{
+		{"zero", number(0), "null"},
+		{"one", number(1), "eins"},
+		{"compound tens", number(21), "einundzwanzig"},
+		{"hundred and one", number(101), "einhunderteins"},
+		{"thousands should be a single word", number(1234), "eintausendzweihundertvierunddreißig"},
+		{"one million", number(1000000), "eine Million"},
+		{"millions", number(2500000), "zwei Millionen fünfhunderttausend"},
+		{"negative numbers", number(-7), "minus sieben"},
+		{"ordinal first", ordinal(1), "erste"},
+		{"ordinal third", ordinal(3), "dritte"},
+		{"ordinal seventh", ordinal(7), "siebte"},
+		{"ordinal below twenty", ordinal(19), "neunzehnte"},
+		{"ordinal from twenty", ordinal(20), "zwanzigste"},
+		{"ordinal over a hundred", ordinal(101), "einhunderterste"},
+		{"decimal", decimal(3.14, 2), "drei Komma eins vier"},
+		{"negative infinity", decimal(math.Inf(-1), 2), "minus unendlich"},
+		{"one euro and one cent", money(101, "EUR"), "ein Euro und ein Cent"},
+		{"euros", money(200, "EUR"), "zwei Euro"},
+		{"compound one should not change", money(2101, "EUR"), "einundzwanzig Euro und ein Cent"},
+		{"trailing one before a noun", money(10100, "EUR"), "einhundertein Euro"},
+		{"date", date(2026, time.October, 17), "der siebzehnte Oktober zweitausendsechsundzwanzig"},
+		{"year in hundreds", date(1905, time.March, 1), "der erste März neunzehnhundertfünf"},
+		{"weekday", weekday(2026, time.October, 17), "Samstag"},
+		{"time", clock(14, 30), "vierzehn Uhr dreißig"},
+		{"one o'clock", clock(1, 0), "ein Uhr"},
+		{"midnight", clock(0, 5), "null Uhr fünf"},
+		{"duration should be feminine", duration(3661 * time.Second), "eine Stunde, eine Minute und eine Sekunde"},
+		{"minutes", duration(2 * time.Minute), "zwei Minuten"},
+	})
+}
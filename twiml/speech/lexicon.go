@@ -0,0 +1,98 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"time"
+
+	"github.com/theckman/twilio/twiml"
+)
+
+// lexicon is everything needed to format values in a single language.
+type lexicon struct {
+	// cardinal spells out n on its own
+	cardinal func(n uint64) string
+
+	// before spells out n when it's followed by a noun of the gender, for
+	// languages where that changes the word for one (e.g., "ein Euro")
+	before func(n uint64, feminine bool) string
+
+	ordinal func(n uint64) string
+
+	// date and clock take the lexicon, so that variants of a language can
+	// share them
+	date  func(l *lexicon, t time.Time) string
+	clock func(l *lexicon, hour, minute int) string
+
+	minus, point, plus string
+
+	// infinity and nan are read for the float64 values that aren't numbers
+	infinity, nan string
+
+	// and joins the last item of a list, and moneyAnd joins major and
+	// minor currency units
+	and, moneyAnd string
+
+	digits   [10]string
+	months   [12]string
+	weekdays [7]string // starting with Sunday, like time.Weekday
+
+	hour, minute, second unit
+
+	// currencies are keyed by ISO 4217 code
+	currencies map[string]currency
+}
+
+// unit is a noun that's counted, such as "minute".
+type unit struct {
+	one, other string
+	feminine   bool
+}
+
+type currency struct {
+	major, minor unit
+}
+
+// variant returns a copy of l with fn applied, for regional variants.
+func (l lexicon) variant(fn func(*lexicon)) *lexicon {
+	fn(&l)
+	return &l
+}
+
+// lexicons are the supported languages.
+var lexicons = map[twiml.Language]*lexicon{
+	twiml.LangEnglishUS:        english,
+	twiml.LangEnglishCanada:    english,
+	twiml.LangEnglishUK:        englishUK,
+	twiml.LangEnglishAustralia: englishUK,
+	twiml.LangGermanGermany:    german,
+	twiml.LangFrenchFrance:     french,
+	twiml.LangFrenchCanada:     french,
+	twiml.LangSpanishSpain:     spanish,
+	twiml.LangSpanishMexico:    spanishMexico,
+}
+
+// groups splits n in to groups of three digits, least significant first.
+func groups(n uint64) []uint64 {
+	var out []uint64
+
+	for {
+		out = append(out, n%1000)
+
+		if n /= 1000; n == 0 {
+			return out
+		}
+	}
+}
+
+// sameBefore is the before func of languages where numbers don't change
+// before nouns.
+func sameBefore(cardinal func(uint64) string) func(uint64, bool) string {
+	return func(n uint64, _ bool) string {
+		return cardinal(n)
+	}
+}
@@ -0,0 +1,240 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var spanishOnes = [30]string{
+	"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+	"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete",
+	"dieciocho", "diecinueve", "veinte", "veintiuno", "veintidós", "veintitrés",
+	"veinticuatro", "veinticinco", "veintiséis", "veintisiete", "veintiocho", "veintinueve",
+}
+
+var spanishTens = [10]string{
+	"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa",
+}
+
+var spanishHundreds = [10]string{
+	"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos",
+	"seiscientos", "setecientos", "ochocientos", "novecientos",
+}
+
+// spanishScales use the long scale, where each is a million times the last.
+var spanishScales = []unit{
+	{one: "millón", other: "millones"},
+	{one: "billón", other: "billones"},
+	{one: "trillón", other: "trillones"},
+}
+
+func spanishBelow100(n uint64) string {
+	switch {
+	case n < 30:
+		return spanishOnes[n]
+	case n%10 == 0:
+		return spanishTens[n/10]
+	default:
+		return spanishTens[n/10] + " y " + spanishOnes[n%10]
+	}
+}
+
+func spanishBelow1000(n uint64) string {
+	switch h, r := n/100, n%100; {
+	case n == 100:
+		return "cien"
+	case h == 0:
+		return spanishBelow100(r)
+	case r == 0:
+		return spanishHundreds[h]
+	default:
+		return spanishHundreds[h] + " " + spanishBelow100(r)
+	}
+}
+
+// spanishApocope shortens a trailing "uno" before a masculine noun or scale,
+// e.g. "veintiún mil" and "treinta y un dólares".
+func spanishApocope(s string) string {
+	switch {
+	case strings.HasSuffix(s, "veintiuno"):
+		return strings.TrimSuffix(s, "uno") + "ún"
+	case s == "uno" || strings.HasSuffix(s, " uno"):
+		return strings.TrimSuffix(s, "o")
+	default:
+		return s
+	}
+}
+
+// spanishBelowMillion spells out n < 1,000,000.
+func spanishBelowMillion(n uint64) string {
+	t, r := n/1000, n%1000
+
+	var words []string
+
+	switch {
+	case t == 1:
+		words = append(words, "mil")
+	case t > 1:
+		words = append(words, spanishApocope(spanishBelow1000(t))+" mil")
+	}
+
+	if r > 0 || t == 0 {
+		words = append(words, spanishBelow1000(r))
+	}
+
+	return strings.Join(words, " ")
+}
+
+func spanishCardinal(n uint64) string {
+	var parts []uint64
+
+	for {
+		parts = append(parts, n%1000000)
+
+		if n /= 1000000; n == 0 {
+			break
+		}
+	}
+
+	var words []string
+
+	for i := len(parts) - 1; i >= 1; i-- {
+		switch {
+		case parts[i] == 0:
+		case parts[i] == 1:
+			words = append(words, "un "+spanishScales[i-1].one)
+		default:
+			words = append(words, spanishApocope(spanishBelowMillion(parts[i]))+" "+spanishScales[i-1].other)
+		}
+	}
+
+	if parts[0] > 0 || len(words) == 0 {
+		words = append(words, spanishBelowMillion(parts[0]))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// spanishBefore agrees the number with the gender of the noun it counts, e.g.
+// "veintiún minutos" and "doscientas una horas".
+func spanishBefore(n uint64, feminine bool) string {
+	s := spanishCardinal(n)
+
+	if !feminine {
+		return spanishApocope(s)
+	}
+
+	if strings.HasSuffix(s, "uno") {
+		s = strings.TrimSuffix(s, "o") + "a"
+	}
+
+	if n < 1000000 {
+		s = strings.Replace(s, "ientos", "ientas", -1)
+	}
+
+	return s
+}
+
+var spanishOrdinalOnes = [10]string{
+	"", "primero", "segundo", "tercero", "cuarto", "quinto", "sexto", "séptimo", "octavo", "noveno",
+}
+
+var spanishOrdinalTens = [10]string{
+	"", "décimo", "vigésimo", "trigésimo", "cuadragésimo", "quincuagésimo",
+	"sexagésimo", "septuagésimo", "octogésimo", "nonagésimo",
+}
+
+// spanishOrdinal spells out ordinals below 100, which covers their use in
+// speech. Larger ordinals are written with digits and the ordinal indicator,
+// as they're rarely said as words.
+func spanishOrdinal(n uint64) string {
+	switch {
+	case n == 0 || n >= 100:
+		return strconv.FormatUint(n, 10) + ".º"
+	case n == 11:
+		return "undécimo"
+	case n == 12:
+		return "duodécimo"
+	case n < 10:
+		return spanishOrdinalOnes[n]
+	case n%10 == 0:
+		return spanishOrdinalTens[n/10]
+	case n < 20:
+		return "decimo" + spanishOrdinalOnes[n%10]
+	default:
+		return spanishOrdinalTens[n/10] + " " + spanishOrdinalOnes[n%10]
+	}
+}
+
+var spanish = &lexicon{
+	cardinal: spanishCardinal,
+	before:   spanishBefore,
+	ordinal:  spanishOrdinal,
+	date: func(l *lexicon, t time.Time) string {
+		day := spanishCardinal(uint64(t.Day()))
+
+		if t.Day() == 1 {
+			day = "primero"
+		}
+
+		return day + " de " + l.months[t.Month()-1] + " de " + spanishCardinal(uint64(t.Year()))
+	},
+	clock: func(_ *lexicon, hour, minute int) string {
+		s := "las " + spanishBefore(uint64(hour), true)
+
+		if hour == 1 {
+			s = "la una"
+		}
+
+		if minute == 0 {
+			return s + " en punto"
+		}
+
+		return s + " y " + spanishCardinal(uint64(minute))
+	},
+	minus:    "menos",
+	point:    "coma",
+	plus:     "más",
+	infinity: "infinito",
+	nan:      "no es un número",
+	and:      "y",
+	moneyAnd: "con",
+	digits:   [10]string{"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve"},
+	months: [12]string{
+		"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+		"agosto", "septiembre", "octubre", "noviembre", "diciembre",
+	},
+	weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	hour:     unit{one: "hora", other: "horas", feminine: true},
+	minute:   unit{one: "minuto", other: "minutos"},
+	second:   unit{one: "segundo", other: "segundos"},
+	currencies: map[string]currency{
+		"USD": {unit{one: "dólar", other: "dólares"}, unit{one: "centavo", other: "centavos"}},
+		"CAD": {unit{one: "dólar", other: "dólares"}, unit{one: "centavo", other: "centavos"}},
+		"AUD": {unit{one: "dólar", other: "dólares"}, unit{one: "centavo", other: "centavos"}},
+		"EUR": {unit{one: "euro", other: "euros"}, unit{one: "céntimo", other: "céntimos"}},
+		"GBP": {unit{one: "libra", other: "libras", feminine: true}, unit{one: "penique", other: "peniques"}},
+		"MXN": {unit{one: "peso", other: "pesos"}, unit{one: "centavo", other: "centavos"}},
+	},
+}
+
+// spanishMexico uses a decimal point, and centavos for the euro.
+var spanishMexico = spanish.variant(func(l *lexicon) {
+	l.point = "punto"
+
+	currencies := make(map[string]currency, len(l.currencies))
+
+	for code, c := range l.currencies {
+		currencies[code] = c
+	}
+
+	currencies["EUR"] = currency{unit{one: "euro", other: "euros"}, unit{one: "centavo", other: "centavos"}}
+	l.currencies = currencies
+})
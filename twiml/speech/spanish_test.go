@@ -0,0 +1,57 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"testing"
+	"time"
+
+	"github.com/theckman/twilio/twiml"
+)
+
+func TestSpanish(t *testing.T) {
+	runFormatTests(t, twiml.LangSpanishSpain, []formatTest{
+		{"zero", number(0), "cero"},
+		{"twenty-one", number(21), "veintiuno"},
+		{"thirty-one", number(31), "treinta y uno"},
+		{"one hundred", number(100), "cien"},
+		{"hundred and one", number(101), "ciento uno"},
+		{"five hundred", number(500), "quinientos"},
+		{"one thousand", number(1000), "mil"},
+		{"thousands should shorten one", number(21000), "veintiún mil"},
+		{"thousands should shorten a separate one", number(31000), "treinta y un mil"},
+		{"one million", number(1000000), "un millón"},
+		{"millions", number(21000000), "veintiún millones"},
+		{"thousands of millions", number(1000000000), "mil millones"},
+		{"long scale billion", number(1000000000000), "un billón"},
+		{"ordinal first", ordinal(1), "primero"},
+		{"ordinal eleventh", ordinal(11), "undécimo"},
+		{"ordinal thirteenth", ordinal(13), "decimotercero"},
+		{"compound ordinal", ordinal(21), "vigésimo primero"},
+		{"large ordinal should use digits", ordinal(100), "100.º"},
+		{"decimal", decimal(3.14, 2), "tres coma uno cuatro"},
+		{"shortened one before a noun", money(2100, "USD"), "veintiún dólares"},
+		{"dollar and cent", money(101, "USD"), "un dólar con un centavo"},
+		{"feminine currency", money(20100, "GBP"), "doscientas una libras"},
+		{"céntimos", money(50, "EUR"), "cincuenta céntimos"},
+		{"date", date(2026, time.October, 17), "diecisiete de octubre de dos mil veintiséis"},
+		{"first of the month", date(2026, time.October, 1), "primero de octubre de dos mil veintiséis"},
+		{"day twenty-one", date(2026, time.October, 21), "veintiuno de octubre de dos mil veintiséis"},
+		{"weekday", weekday(2026, time.October, 17), "sábado"},
+		{"one o'clock", clock(1, 0), "la una en punto"},
+		{"afternoon", clock(14, 30), "las catorce y treinta"},
+		{"feminine hours", clock(21, 15), "las veintiuna y quince"},
+		{"duration", duration(3661 * time.Second), "una hora, un minuto y un segundo"},
+		{"shortened minutes", duration(21 * time.Minute), "veintiún minutos"},
+	})
+
+	runFormatTests(t, twiml.LangSpanishMexico, []formatTest{
+		{"decimal point", decimal(3.14, 2), "tres punto uno cuatro"},
+		{"centavos", money(50, "EUR"), "cincuenta centavos"},
+		{"pesos", money(150, "MXN"), "un peso con cincuenta centavos"},
+	})
+}
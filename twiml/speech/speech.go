@@ -0,0 +1,411 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package speech formats numbers, ordinals, money, dates, times, durations,
+// phone numbers, and alphanumeric codes as text that text-to-speech engines
+// read the same way in every language, for use in the Message of a twiml.Say
+// verb. Without it a Say verb reads "$1,234.50" or "2026-10-17" differently
+// depending on the voice and language, and reads confirmation codes as large
+// numbers.
+//
+// A Formatter is created for a twiml.Language, and spells everything out in
+// words in that language:
+//
+//	f, err := speech.NewFormatter(twiml.LangEnglishUS)
+//	f.Money(123450, "USD") // "one thousand two hundred thirty-four dollars and fifty cents"
+//	f.Code("AB12")         // "A, B, one, two"
+//
+// Formatters created with the WithSSML option return SSML say-as markup
+// instead, where SSML has an equivalent, for voices that support SSML. The
+// twiml package escapes the Message of a Say like any other text, so the
+// markup is only useful where the message is written out as-is.
+//
+// Errors are wrapped, like in the twiml package.
+package speech
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// ErrUnsupportedLanguage is the cause of the error returned by NewFormatter
+// for languages this package can't format for.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// ErrUnknownCurrency is the cause of the error returned by Formatter.Money for
+// currencies the language doesn't have names for.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Option configures optional behavior of a Formatter.
+type Option func(*Formatter)
+
+// WithSSML makes the Formatter return SSML say-as markup, instead of words,
+// for numbers, ordinals, dates, times, phone numbers, and codes. Money,
+// decimals, and durations are always spelled out, as SSML has no equivalent
+// that's widely supported.
+func WithSSML() Option {
+	return func(f *Formatter) {
+		f.ssml = true
+	}
+}
+
+// Formatter formats values as speech in a single language. It's safe for
+// concurrent use.
+type Formatter struct {
+	lang twiml.Language
+	lex  *lexicon
+	ssml bool
+}
+
+// NewFormatter returns a *Formatter for the language. Languages() lists the
+// supported languages. This function returns a wrapped error (see package
+// documentation for more info).
+func NewFormatter(lang twiml.Language, opts ...Option) (*Formatter, error) {
+	lex, ok := lexicons[lang]
+
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedLanguage, "creating formatter for %q failed", lang.String())
+	}
+
+	f := &Formatter{lang: lang, lex: lex}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f, nil
+}
+
+// Languages returns the languages supported by NewFormatter.
+func Languages() []twiml.Language {
+	var out []twiml.Language
+
+	for _, lang := range twiml.LanguageValues() {
+		if _, ok := lexicons[lang]; ok {
+			out = append(out, lang)
+		}
+	}
+
+	return out
+}
+
+// Language returns the language of the Formatter.
+func (f *Formatter) Language() twiml.Language {
+	return f.lang
+}
+
+// Number spells out the integer n, e.g. "one thousand two hundred thirty-four".
+func (f *Formatter) Number(n int64) string {
+	if f.ssml {
+		return sayAs("cardinal", "", strconv.FormatInt(n, 10))
+	}
+
+	if n < 0 {
+		return f.lex.minus + " " + f.lex.cardinal(uint64(-n))
+	}
+
+	return f.lex.cardinal(uint64(n))
+}
+
+// Ordinal spells out the ordinal of n, e.g. "twenty-first".
+func (f *Formatter) Ordinal(n uint64) string {
+	if f.ssml {
+		return sayAs("ordinal", "", strconv.FormatUint(n, 10))
+	}
+
+	return f.lex.ordinal(n)
+}
+
+// Decimal spells out v rounded to the number of decimal places, reading the
+// digits after the decimal separator one at a time, e.g. "three point one
+// four". Infinities are read as such, and NaN as "not a number".
+func (f *Formatter) Decimal(v float64, places int) string {
+	switch {
+	case math.IsNaN(v):
+		return f.lex.nan
+	case math.IsInf(v, 1):
+		return f.lex.infinity
+	case math.IsInf(v, -1):
+		return f.lex.minus + " " + f.lex.infinity
+	}
+
+	s := strconv.FormatFloat(v, 'f', places, 64)
+
+	var words []string
+
+	if strings.HasPrefix(s, "-") {
+		words = append(words, f.lex.minus)
+		s = s[1:]
+	}
+
+	whole, frac := s, ""
+
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+
+	n, _ := strconv.ParseUint(whole, 10, 64)
+	words = append(words, f.lex.cardinal(n))
+
+	if frac != "" {
+		words = append(words, f.lex.point)
+
+		for _, d := range frac {
+			words = append(words, f.lex.digits[d-'0'])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Money spells out an amount of the currency, given in its minor units (e.g.
+// cents) to avoid rounding errors. The currency is the ISO 4217 code, and the
+// supported currencies are USD, CAD, AUD, EUR, GBP, and MXN. The minor units
+// are left out if they're zero. This function returns a wrapped error (see
+// package documentation for more info).
+func (f *Formatter) Money(minor int64, currency string) (string, error) {
+	c, ok := f.lex.currencies[strings.ToUpper(currency)]
+
+	if !ok {
+		return "", errors.Wrapf(ErrUnknownCurrency, "formatting %q failed", currency)
+	}
+
+	var prefix string
+
+	if minor < 0 {
+		prefix = f.lex.minus + " "
+		minor = -minor
+	}
+
+	major, cents := uint64(minor/100), uint64(minor%100)
+
+	switch {
+	case cents == 0:
+		return prefix + f.count(major, c.major), nil
+	case major == 0:
+		return prefix + f.count(cents, c.minor), nil
+	default:
+		return prefix + f.count(major, c.major) + " " + f.lex.moneyAnd + " " + f.count(cents, c.minor), nil
+	}
+}
+
+// count spells out n followed by the unit, agreeing in number and gender.
+func (f *Formatter) count(n uint64, u unit) string {
+	if n == 1 {
+		return f.lex.before(1, u.feminine) + " " + u.one
+	}
+
+	return f.lex.before(n, u.feminine) + " " + u.other
+}
+
+// Date spells out the date of t, e.g. "October seventeenth, twenty
+// twenty-six".
+func (f *Formatter) Date(t time.Time) string {
+	if f.ssml {
+		return sayAs("date", "ymd", t.Format("2006-01-02"))
+	}
+
+	return f.lex.date(f.lex, t)
+}
+
+// Weekday returns the name of the day of the week of t.
+func (f *Formatter) Weekday(t time.Time) string {
+	return f.lex.weekdays[t.Weekday()]
+}
+
+// Time spells out the time of day of t, in its location, the way it's usually
+// said in the language, e.g. "two thirty PM" or "vierzehn Uhr dreißig".
+func (f *Formatter) Time(t time.Time) string {
+	if f.ssml {
+		return sayAs("time", "hms24", t.Format("15:04"))
+	}
+
+	return f.lex.clock(f.lex, t.Hour(), t.Minute())
+}
+
+// Duration spells out d in hours, minutes, and seconds, rounded to the
+// nearest second, e.g. "one hour, five minutes and three seconds". Units that
+// are zero are left out.
+func (f *Formatter) Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	secs := uint64((d + time.Second/2) / time.Second)
+	h, m, s := secs/3600, secs/60%60, secs%60
+
+	var parts []string
+
+	if h > 0 {
+		parts = append(parts, f.count(h, f.lex.hour))
+	}
+
+	if m > 0 {
+		parts = append(parts, f.count(m, f.lex.minute))
+	}
+
+	if s > 0 || len(parts) == 0 {
+		parts = append(parts, f.count(s, f.lex.second))
+	}
+
+	return f.list(parts)
+}
+
+// list joins the items like "a, b and c".
+func (f *Formatter) list(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+
+	return strings.Join(items[:len(items)-1], ", ") + " " + f.lex.and + " " + items[len(items)-1]
+}
+
+// PhoneNumber reads out an E.164 phone number digit by digit, in groups, e.g.
+// "plus one, five five five, five five five, zero one zero zero". Numbers in
+// the North American Numbering Plan are grouped as 3-3-4 after the country
+// code; other numbers are grouped in threes after the country code, with any
+// remainder of less than three digits joining the last group.
+func (f *Formatter) PhoneNumber(number twiml.PhoneNumber) string {
+	s := string(number)
+
+	if f.ssml {
+		return sayAs("telephone", "", s)
+	}
+
+	var digits []rune
+
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+
+	var groups [][]rune
+
+	switch {
+	case !strings.HasPrefix(s, "+"):
+		groups = chunk(digits, 3)
+	case len(digits) == 11 && digits[0] == '1':
+		groups = [][]rune{digits[:1], digits[1:4], digits[4:7], digits[7:]}
+	case len(digits) > 3:
+		cc := countryCodeLen(digits)
+		groups = append([][]rune{digits[:cc]}, chunk(digits[cc:], 3)...)
+	default:
+		groups = [][]rune{digits}
+	}
+
+	parts := make([]string, 0, len(groups))
+
+	for _, g := range groups {
+		words := make([]string, len(g))
+
+		for i, d := range g {
+			words[i] = f.lex.digits[d-'0']
+		}
+
+		parts = append(parts, strings.Join(words, " "))
+	}
+
+	out := strings.Join(parts, ", ")
+
+	if strings.HasPrefix(s, "+") {
+		out = f.lex.plus + " " + out
+	}
+
+	return out
+}
+
+// twoDigitCountryCodes are the ITU country codes with two digits. Codes
+// starting with 1 or 7 have one digit, and all others have three.
+var twoDigitCountryCodes = map[string]bool{
+	"20": true, "27": true, "30": true, "31": true, "32": true, "33": true,
+	"34": true, "36": true, "39": true, "40": true, "41": true, "43": true,
+	"44": true, "45": true, "46": true, "47": true, "48": true, "49": true,
+	"51": true, "52": true, "53": true, "54": true, "55": true, "56": true,
+	"57": true, "58": true, "60": true, "61": true, "62": true, "63": true,
+	"64": true, "65": true, "66": true, "81": true, "82": true, "84": true,
+	"86": true, "90": true, "91": true, "92": true, "93": true, "94": true,
+	"95": true, "98": true,
+}
+
+// countryCodeLen returns the number of digits in the country code at the
+// start of digits.
+func countryCodeLen(digits []rune) int {
+	switch {
+	case digits[0] == '1' || digits[0] == '7':
+		return 1
+	case twoDigitCountryCodes[string(digits[:2])]:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// chunk splits digits in to groups of size, joining a remainder smaller than
+// size to the last group.
+func chunk(digits []rune, size int) [][]rune {
+	var out [][]rune
+
+	for len(digits) >= 2*size {
+		out = append(out, digits[:size])
+		digits = digits[size:]
+	}
+
+	if len(digits) > 0 {
+		out = append(out, digits)
+	}
+
+	return out
+}
+
+// Code reads out an alphanumeric code, such as a confirmation code, one
+// character at a time with a pause between each, e.g. "A, B, one, two".
+// Letters are upper-cased so they're read as letters, and characters other
+// than letters and digits (such as dashes) are left out.
+func (f *Formatter) Code(code string) string {
+	if f.ssml {
+		return sayAs("characters", "", code)
+	}
+
+	var parts []string
+
+	for _, r := range code {
+		switch {
+		case r >= '0' && r <= '9':
+			parts = append(parts, f.lex.digits[r-'0'])
+		case unicode.IsLetter(r):
+			parts = append(parts, string(unicode.ToUpper(r)))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// sayAs renders an SSML say-as element.
+func sayAs(interpretAs, format, text string) string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<say-as interpret-as="%s"`, interpretAs)
+
+	if format != "" {
+		fmt.Fprintf(&buf, ` format="%s"`, format)
+	}
+
+	buf.WriteString(">")
+	_ = xml.EscapeText(&buf, []byte(text))
+	buf.WriteString("</say-as>")
+
+	return buf.String()
+}
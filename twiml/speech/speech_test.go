@@ -0,0 +1,192 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package speech
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml"
+)
+
+// formatTest is a single case of the table-driven tests for each language.
+type formatTest struct {
+	desc   string
+	format func(f *Formatter) string
+	want   string
+}
+
+func number(n int64) func(*Formatter) string {
+	return func(f *Formatter) string { return f.Number(n) }
+}
+
+func ordinal(n uint64) func(*Formatter) string {
+	return func(f *Formatter) string { return f.Ordinal(n) }
+}
+
+func decimal(v float64, places int) func(*Formatter) string {
+	return func(f *Formatter) string { return f.Decimal(v, places) }
+}
+
+func money(minor int64, currency string) func(*Formatter) string {
+	return func(f *Formatter) string {
+		s, err := f.Money(minor, currency)
+
+		if err != nil {
+			return "error: " + err.Error()
+		}
+
+		return s
+	}
+}
+
+func date(year int, month time.Month, day int) func(*Formatter) string {
+	return func(f *Formatter) string {
+		return f.Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+func weekday(year int, month time.Month, day int) func(*Formatter) string {
+	return func(f *Formatter) string {
+		return f.Weekday(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+func clock(hour, minute int) func(*Formatter) string {
+	return func(f *Formatter) string {
+		return f.Time(time.Date(2026, time.October, 17, hour, minute, 0, 0, time.UTC))
+	}
+}
+
+func duration(d time.Duration) func(*Formatter) string {
+	return func(f *Formatter) string { return f.Duration(d) }
+}
+
+func phoneNumber(number twiml.PhoneNumber) func(*Formatter) string {
+	return func(f *Formatter) string { return f.PhoneNumber(number) }
+}
+
+func code(s string) func(*Formatter) string {
+	return func(f *Formatter) string { return f.Code(s) }
+}
+
+func runFormatTests(t *testing.T, lang twiml.Language, tests []formatTest, opts ...Option) {
+	f, err := NewFormatter(lang, opts...)
+
+	if err != nil {
+		t.Fatalf("NewFormatter(%s) error = %s", lang, err)
+	}
+
+	for _, test := range tests {
+		if got := test.format(f); got != test.want {
+			t.Errorf("\nDescription: %s\nLanguage: %s\ngot:  %q\nwant: %q", test.desc, lang, got, test.want)
+		}
+	}
+}
+
+func TestNewFormatter(t *testing.T) {
+	f, err := NewFormatter(twiml.LangEnglishUK)
+
+	if err != nil {
+		t.Fatalf("NewFormatter() error = %s", err)
+	}
+
+	if lang := f.Language(); lang != twiml.LangEnglishUK {
+		t.Errorf("Language() = %s, want %s", lang, twiml.LangEnglishUK)
+	}
+
+	_, err = NewFormatter(twiml.LangJapaneseJapan)
+
+	if cause := errors.Cause(err); cause != ErrUnsupportedLanguage {
+		t.Errorf("NewFormatter(%s) error cause = %v, want %v", twiml.LangJapaneseJapan, cause, ErrUnsupportedLanguage)
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	langs := Languages()
+
+	if len(langs) != len(lexicons) {
+		t.Fatalf("len(Languages()) = %d, want %d", len(langs), len(lexicons))
+	}
+
+	for _, lang := range langs {
+		if _, err := NewFormatter(lang); err != nil {
+			t.Errorf("NewFormatter(%s) error = %s", lang, err)
+		}
+	}
+}
+
+func TestFormatter_Money_unknownCurrency(t *testing.T) {
+	f, err := NewFormatter(twiml.LangEnglishUS)
+
+	if err != nil {
+		t.Fatalf("NewFormatter() error = %s", err)
+	}
+
+	if _, err = f.Money(100, "XYZ"); errors.Cause(err) != ErrUnknownCurrency {
+		t.Errorf("Money() error cause = %v, want %v", errors.Cause(err), ErrUnknownCurrency)
+	}
+}
+
+func TestFormatter_PhoneNumber(t *testing.T) {
+	runFormatTests(t, twiml.LangEnglishUS, []formatTest{
+		{
+			"NANP number should be grouped 3-3-4",
+			phoneNumber("+15555550100"),
+			"plus one, five five five, five five five, zero one zero zero",
+		},
+		{
+			"UK number should have a two digit country code",
+			phoneNumber("+442079460123"),
+			"plus four four, two zero seven, nine four six, zero one two three",
+		},
+		{
+			"Russian number should have a one digit country code",
+			phoneNumber("+74951234567"),
+			"plus seven, four nine five, one two three, four five six seven",
+		},
+		{
+			"number without a plus should be grouped in threes",
+			phoneNumber("5551234"),
+			"five five five, one two three four",
+		},
+	})
+
+	runFormatTests(t, twiml.LangGermanGermany, []formatTest{
+		{
+			"German number should use German digits",
+			phoneNumber("+4930123456"),
+			"plus vier neun, drei null eins, zwei drei vier fünf sechs",
+		},
+	})
+}
+
+func TestFormatter_Code(t *testing.T) {
+	runFormatTests(t, twiml.LangEnglishUS, []formatTest{
+		{"letters should be upper-cased", code("ab12"), "A, B, one, two"},
+		{"punctuation should be left out", code("X-7 9"), "X, seven, nine"},
+		{"empty code should be empty", code(""), ""},
+	})
+
+	runFormatTests(t, twiml.LangSpanishMexico, []formatTest{
+		{"digits should be in the language", code("Z09"), "Z, cero, nueve"},
+	})
+}
+
+func TestWithSSML(t *testing.T) {
+	runFormatTests(t, twiml.LangEnglishUS, []formatTest{
+		{"number should be cardinal", number(-42), `<say-as interpret-as="cardinal">-42</say-as>`},
+		{"ordinal should be ordinal", ordinal(3), `<say-as interpret-as="ordinal">3</say-as>`},
+		{"date should be ymd", date(2026, time.October, 17), `<say-as interpret-as="date" format="ymd">2026-10-17</say-as>`},
+		{"time should be hms24", clock(14, 5), `<say-as interpret-as="time" format="hms24">14:05</say-as>`},
+		{"phone number should be telephone", phoneNumber("+15555550100"), `<say-as interpret-as="telephone">+15555550100</say-as>`},
+		{"code should be escaped characters", code("A<1"), `<say-as interpret-as="characters">A&lt;1</say-as>`},
+		{"money should be spelled out", money(150, "USD"), "one dollar and fifty cents"},
+		{"duration should be spelled out", duration(90 * time.Second), "one minute and thirty seconds"},
+	}, WithSSML())
+}
@@ -0,0 +1,78 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// NonceStore records the nonces of decoded state until it expires, so that
+// state can only be decoded once for each callback. Implementations must be
+// safe for concurrent use.
+type NonceStore interface {
+	// Use records the nonce, which includes the parameters of the
+	// callback, returning false if it was already recorded.
+	// The nonce can be forgotten after the expiry time.
+	Use(nonce string, expires time.Time) (bool, error)
+}
+
+// MemoryNonceStore is a NonceStore that keeps nonces in memory.
+type MemoryNonceStore struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	next   time.Time // the earliest expiry time in nonces
+}
+
+// NewMemoryNonceStore returns a new *MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{now: time.Now, nonces: make(map[string]time.Time)}
+}
+
+// Use implements the NonceStore interface.
+func (m *MemoryNonceStore) Use(nonce string, expires time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	// forget expired nonces once any of them have expired
+	if len(m.nonces) > 0 && !now.Before(m.next) {
+		m.next = time.Time{}
+
+		for n, exp := range m.nonces {
+			switch {
+			case !now.Before(exp):
+				delete(m.nonces, n)
+			case m.next.IsZero() || exp.Before(m.next):
+				m.next = exp
+			}
+		}
+	}
+
+	if _, ok := m.nonces[nonce]; ok {
+		return false, nil
+	}
+
+	m.nonces[nonce] = expires
+
+	if m.next.IsZero() || expires.Before(m.next) {
+		m.next = expires
+	}
+
+	return true, nil
+}
+
+// Len returns the number of nonces in the store.
+func (m *MemoryNonceStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.nonces)
+}
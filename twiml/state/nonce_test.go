@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package state
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	m := NewMemoryNonceStore()
+	m.now = func() time.Time { return now }
+
+	if ok, err := m.Use("a", now.Add(time.Minute)); !ok || err != nil {
+		t.Fatalf("first Use() = %t, %v, want true", ok, err)
+	}
+
+	if ok, _ := m.Use("a", now.Add(time.Minute)); ok {
+		t.Error("second Use() should be false")
+	}
+
+	if ok, _ := m.Use("b", now.Add(2*time.Minute)); !ok {
+		t.Error("Use() of another nonce should be true")
+	}
+
+	now = now.Add(time.Minute)
+
+	// "a" has expired, and is forgotten when the next nonce is used
+	if ok, _ := m.Use("c", now.Add(time.Minute)); !ok {
+		t.Error("Use() of a third nonce should be true")
+	}
+
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	if ok, _ := m.Use("b", now.Add(time.Minute)); ok {
+		t.Error("Use() of an unexpired nonce should be false")
+	}
+}
@@ -0,0 +1,441 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+// Package state carries the state of a call flow in the query string of the
+// URLs Twilio calls back, such as the Action of a Gather, so that services can
+// be stateless. The state is a small struct, whose fields are encoded as query
+// parameters along with an expiry time, a nonce, and an HMAC-SHA256 signature:
+//
+//	type menuState struct {
+//		Attempts int    `state:"attempts"`
+//		Account  string `state:"account"`
+//	}
+//
+//	signer := state.NewSigner(key, 10*time.Minute)
+//
+//	action, err := signer.URL("/voice/choice", menuState{Attempts: 1, Account: "42"})
+//	gather := &twiml.Gather{Action: action}
+//
+// and in the handler of the action:
+//
+//	var st menuState
+//	err := signer.Decode(r, &st)
+//
+// Decode rejects state that has been modified, has expired, or has already
+// been decoded for the same callback, so a callback URL can't be replayed.
+// Twilio requests some URLs, such as a StatusCallback, once for each event of
+// a call, so the nonce of the state is recorded along with the parameters
+// that tell the events apart, such as the CallStatus and SequenceNumber. A
+// retry of a callback that was already decoded is then rejected as a replay,
+// which handlers can treat as already handled. The state isn't encrypted, so
+// it mustn't contain secrets.
+//
+// Errors are wrapped, like in the twiml package.
+package state
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidState is the cause of the error returned by Decode for state
+// that's missing, has been modified, or can't be decoded in to the struct.
+var ErrInvalidState = errors.New("invalid state")
+
+// ErrExpiredState is the cause of the error returned by Decode for state
+// that's past its expiry time.
+var ErrExpiredState = errors.New("expired state")
+
+// ErrReplayedState is the cause of the error returned by Decode for state
+// that has already been decoded.
+var ErrReplayedState = errors.New("replayed state")
+
+const (
+	// fieldPrefix is the prefix of the query parameters of the fields of
+	// the state, which keeps them apart from any others in the URL.
+	fieldPrefix = "s."
+
+	expiresParam   = "s_exp"
+	nonceParam     = "s_nonce"
+	signatureParam = "s_sig"
+)
+
+// DefaultEventParams are the request parameters that tell apart the
+// callbacks Twilio makes to the same URL, which are recorded with the nonce
+// of the state by default.
+var DefaultEventParams = []string{
+	"CallSid",
+	"CallStatus",
+	"SequenceNumber",
+	"StatusCallbackEvent",
+	"RecordingSid",
+	"RecordingStatus",
+	"TranscriptionStatus",
+}
+
+// Option configures optional behavior of a Signer.
+type Option func(*Signer)
+
+// WithClock sets the function the Signer uses to get the current time, which
+// is time.Now by default. It's mostly useful in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Signer) {
+		s.now = now
+	}
+}
+
+// WithNonceStore sets where the Signer records the nonces of decoded state,
+// instead of a MemoryNonceStore. Services running as more than one instance
+// need a shared NonceStore to reject state replayed to another instance.
+func WithNonceStore(store NonceStore) Option {
+	return func(s *Signer) {
+		s.nonces = store
+	}
+}
+
+// WithEventParams sets the request parameters recorded with the nonce of the
+// state, instead of DefaultEventParams. With no parameters, state can only be
+// decoded once, even by the different callbacks made to a StatusCallback.
+func WithEventParams(names ...string) Option {
+	return func(s *Signer) {
+		s.events = names
+	}
+}
+
+// WithoutPath makes the signature cover only the state, and not the path of
+// the URL, for services behind a proxy that changes the path. State can then
+// be decoded by any handler using the same key.
+func WithoutPath() Option {
+	return func(s *Signer) {
+		s.noPath = true
+	}
+}
+
+// Signer encodes state in to URLs and decodes it from requests. It's safe for
+// concurrent use.
+type Signer struct {
+	key    []byte
+	ttl    time.Duration
+	now    func() time.Time
+	nonces NonceStore
+	events []string
+	noPath bool
+}
+
+// NewSigner returns a *Signer that signs state with the key, which should be
+// at least 32 random bytes, and that expires after the TTL.
+func NewSigner(key []byte, ttl time.Duration, opts ...Option) *Signer {
+	s := &Signer{key: key, ttl: ttl, now: time.Now, events: DefaultEventParams}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.nonces == nil {
+		s.nonces = NewMemoryNonceStore()
+	}
+
+	return s
+}
+
+// URL returns target with the state added to its query string. The target
+// can be relative, such as "/voice/choice", and any query parameters it
+// already has are kept. The state must be a struct, or a pointer to one,
+// whose exported fields are strings, bools, numbers, or implement
+// encoding.TextMarshaler. A field's query parameter is named by its state tag,
+// or by the field's name if it has none, and fields tagged with "-" are left
+// out. This function returns a wrapped error (see package documentation for
+// more info).
+func (s *Signer) URL(target string, state interface{}) (string, error) {
+	u, err := url.Parse(target)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing URL %q failed", target)
+	}
+
+	params, err := encodeFields(state)
+
+	if err != nil {
+		return "", errors.Wrap(err, "encoding state failed")
+	}
+
+	nonce := make([]byte, 12)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "generating nonce failed")
+	}
+
+	params.Set(expiresParam, strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10))
+	params.Set(nonceParam, base64.RawURLEncoding.EncodeToString(nonce))
+	params.Set(signatureParam, s.sign(u.EscapedPath(), params))
+
+	q := u.Query()
+
+	for k, v := range params {
+		q[k] = v
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Decode verifies the state in the query string of the request's URL, and
+// decodes it in to state, which must be a pointer to a struct of the type the
+// URL was made with. Any other parameters, such as those Twilio adds to the
+// query of GET requests, are only used to tell apart callbacks (see
+// WithEventParams). This function returns a wrapped error (see package
+// documentation for more info).
+func (s *Signer) Decode(r *http.Request, state interface{}) error {
+	if err := s.decode(r.URL, s.event(r), state); err != nil {
+		return errors.Wrap(err, "decoding state failed")
+	}
+
+	return nil
+}
+
+// event returns the parameters of the request that tell apart the callbacks
+// made to the same URL, with the nonce they're recorded with.
+func (s *Signer) event(r *http.Request) string {
+	var b bytes.Buffer
+
+	for _, name := range s.events {
+		b.WriteByte('\n')
+		b.WriteString(r.FormValue(name))
+	}
+
+	return b.String()
+}
+
+func (s *Signer) decode(u *url.URL, event string, state interface{}) error {
+	params := make(url.Values)
+
+	for k, v := range u.Query() {
+		if strings.HasPrefix(k, fieldPrefix) || k == expiresParam || k == nonceParam {
+			params[k] = v
+		}
+	}
+
+	sig := u.Query().Get(signatureParam)
+
+	if sig == "" {
+		return errors.Wrap(ErrInvalidState, "missing signature")
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(s.sign(u.EscapedPath(), params))) {
+		return errors.Wrap(ErrInvalidState, "signature doesn't match")
+	}
+
+	exp, err := strconv.ParseInt(params.Get(expiresParam), 10, 64)
+
+	if err != nil {
+		return errors.Wrap(ErrInvalidState, "invalid expiry time")
+	}
+
+	expires := time.Unix(exp, 0)
+
+	if !s.now().Before(expires) {
+		return errors.Wrapf(ErrExpiredState, "expired at %s", expires.UTC().Format(time.RFC3339))
+	}
+
+	if err := decodeFields(params, state); err != nil {
+		return err
+	}
+
+	fresh, err := s.nonces.Use(params.Get(nonceParam)+event, expires)
+
+	if err != nil {
+		return errors.Wrap(err, "recording nonce failed")
+	}
+
+	if !fresh {
+		return errors.Wrap(ErrReplayedState, "nonce already used")
+	}
+
+	return nil
+}
+
+// sign returns the signature of the path and the state parameters.
+func (s *Signer) sign(path string, params url.Values) string {
+	mac := hmac.New(sha256.New, s.key)
+
+	if !s.noPath {
+		mac.Write([]byte(path))
+	}
+
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(params.Encode()))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+var (
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// stateField is a field of a state struct.
+type stateField struct {
+	index int
+	param string
+}
+
+// stateFields returns the fields of the struct type that are encoded.
+func stateFields(t reflect.Type) []stateField {
+	var fields []stateField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		name := f.Tag.Get("state")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+
+		fields = append(fields, stateField{index: i, param: fieldPrefix + name})
+	}
+
+	return fields
+}
+
+func encodeFields(state interface{}) (url.Values, error) {
+	v := reflect.ValueOf(state)
+
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errors.Errorf("state is a %T, not a struct", state)
+	}
+
+	params := make(url.Values)
+
+	for _, f := range stateFields(v.Type()) {
+		s, err := encodeValue(v.Field(f.index))
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "encoding %s failed", v.Type().Field(f.index).Name)
+		}
+
+		params.Set(f.param, s)
+	}
+
+	return params, nil
+}
+
+func encodeValue(v reflect.Value) (string, error) {
+	if v.Type().Implements(textMarshaler) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", errors.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func decodeFields(params url.Values, state interface{}) error {
+	v := reflect.ValueOf(state)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("state is a %T, not a pointer to a struct", state)
+	}
+
+	v = v.Elem()
+
+	for _, f := range stateFields(v.Type()) {
+		s, ok := params[f.param]
+
+		if !ok {
+			return errors.Wrapf(ErrInvalidState, "missing %s", f.param)
+		}
+
+		if err := decodeValue(v.Field(f.index), s[0]); err != nil {
+			return errors.Wrapf(ErrInvalidState, "decoding %s: %s", f.param, err)
+		}
+	}
+
+	return nil
+}
+
+func decodeValue(v reflect.Value, s string) error {
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshaler) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
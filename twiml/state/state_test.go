@@ -0,0 +1,287 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package state
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio"
+)
+
+type menuState struct {
+	Attempts int    `state:"attempts"`
+	Account  string `state:"account"`
+	Verified bool
+	Amount   float64           `state:"amt"`
+	Status   twilio.CallStatus `state:"status"`
+	Ignored  string            `state:"-"`
+	internal string
+}
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testSigner(now *time.Time, opts ...Option) *Signer {
+	opts = append([]Option{WithClock(func() time.Time { return *now })}, opts...)
+	return NewSigner(testKey, 10*time.Minute, opts...)
+}
+
+func TestSigner_roundTrip(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	s := testSigner(&now)
+
+	in := menuState{
+		Attempts: 2,
+		Account:  "42 & more",
+		Verified: true,
+		Amount:   12.5,
+		Status:   twilio.CallStatusInProgress,
+		Ignored:  "x",
+		internal: "y",
+	}
+
+	target, err := s.URL("/voice/choice?lang=en", in)
+
+	if err != nil {
+		t.Fatalf("URL() unexpected error: %s", err)
+	}
+
+	u, err := url.Parse(target)
+
+	if err != nil {
+		t.Fatalf("URL() = %q, not a URL: %s", target, err)
+	}
+
+	q := u.Query()
+
+	if u.Path != "/voice/choice" || q.Get("lang") != "en" || q.Get("s.attempts") != "2" || q.Get("s.Verified") != "true" {
+		t.Errorf("URL() = %q, want the path, existing query, and fields", target)
+	}
+
+	if _, ok := q["s.Ignored"]; ok {
+		t.Errorf("URL() = %q, should leave out fields tagged with -", target)
+	}
+
+	// Twilio adds its own parameters to GET requests
+	r := httptest.NewRequest("GET", target+"&CallSid=CA1&Digits=1", nil)
+
+	var out menuState
+
+	if err := s.Decode(r, &out); err != nil {
+		t.Fatalf("Decode() unexpected error: %s", err)
+	}
+
+	want := in
+	want.Ignored, want.internal = "", ""
+
+	if out != want {
+		t.Errorf("Decode() = %+v, want %+v", out, want)
+	}
+
+	// a second decode of the same callback is a replay
+	err = s.Decode(httptest.NewRequest("GET", target+"&CallSid=CA1&Digits=1", nil), &out)
+
+	if errors.Cause(err) != ErrReplayedState {
+		t.Errorf("second Decode() error = %v, want ErrReplayedState", err)
+	}
+}
+
+func TestSigner_Decode(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc   string
+		opts   []Option
+		modify func(target string) string
+		later  time.Duration
+		cause  error
+	}{
+		{
+			desc:   "unmodified",
+			modify: func(s string) string { return s },
+		},
+		{
+			desc:   "modified field",
+			modify: func(s string) string { return strings.Replace(s, "s.attempts=1", "s.attempts=9", 1) },
+			cause:  ErrInvalidState,
+		},
+		{
+			desc:   "added field",
+			modify: func(s string) string { return s + "&s.extra=1" },
+			cause:  ErrInvalidState,
+		},
+		{
+			desc:   "modified expiry",
+			modify: func(s string) string { return strings.Replace(s, "s_exp=", "s_exp=9", 1) },
+			cause:  ErrInvalidState,
+		},
+		{
+			desc:   "missing signature",
+			modify: func(s string) string { return s[:strings.Index(s, "&s_sig=")] },
+			cause:  ErrInvalidState,
+		},
+		{
+			desc:   "different path",
+			modify: func(s string) string { return strings.Replace(s, "/voice/choice", "/voice/pay", 1) },
+			cause:  ErrInvalidState,
+		},
+		{
+			desc:   "different path without path",
+			opts:   []Option{WithoutPath()},
+			modify: func(s string) string { return strings.Replace(s, "/voice/choice", "/voice/pay", 1) },
+		},
+		{
+			desc:   "expired",
+			modify: func(s string) string { return s },
+			later:  10 * time.Minute,
+			cause:  ErrExpiredState,
+		},
+		{
+			desc:   "not yet expired",
+			modify: func(s string) string { return s },
+			later:  10*time.Minute - time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		now := now
+		s := testSigner(&now, test.opts...)
+
+		target, err := s.URL("/voice/choice", &menuState{Attempts: 1})
+
+		if err != nil {
+			t.Fatalf("\nDescription: %s\nURL() unexpected error: %s", test.desc, err)
+		}
+
+		now = now.Add(test.later)
+
+		var out menuState
+
+		err = s.Decode(httptest.NewRequest("POST", test.modify(target), nil), &out)
+
+		if cause := errors.Cause(err); cause != test.cause {
+			t.Errorf("\nDescription: %s\nDecode() error = %v, want cause %v", test.desc, err, test.cause)
+			continue
+		}
+
+		if test.cause == nil && out.Attempts != 1 {
+			t.Errorf("\nDescription: %s\nDecode() Attempts = %d, want 1", test.desc, out.Attempts)
+		}
+	}
+}
+
+// statusRequest returns a StatusCallback POST of the call status to the URL.
+func statusRequest(target, status, seq string) *http.Request {
+	form := url.Values{"CallSid": {"CA1"}, "CallStatus": {status}, "SequenceNumber": {seq}}
+
+	r := httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return r
+}
+
+func TestSigner_Decode_statusEvents(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc   string
+		status string
+		seq    string
+		cause  error
+	}{
+		{desc: "initiated", status: "initiated", seq: "0"},
+		{desc: "ringing", status: "ringing", seq: "1"},
+		{desc: "answered", status: "in-progress", seq: "2"},
+		{desc: "retried event", status: "in-progress", seq: "2", cause: ErrReplayedState},
+		{desc: "completed", status: "completed", seq: "3"},
+	}
+
+	s := testSigner(&now)
+
+	target, err := s.URL("/voice/status", &menuState{Attempts: 1})
+
+	if err != nil {
+		t.Fatalf("URL() unexpected error: %s", err)
+	}
+
+	for _, test := range tests {
+		var out menuState
+
+		err := s.Decode(statusRequest(target, test.status, test.seq), &out)
+
+		if cause := errors.Cause(err); cause != test.cause {
+			t.Errorf("\nDescription: %s\nDecode() error = %v, want cause %v", test.desc, err, test.cause)
+			continue
+		}
+
+		if test.cause == nil && out.Attempts != 1 {
+			t.Errorf("\nDescription: %s\nDecode() Attempts = %d, want 1", test.desc, out.Attempts)
+		}
+	}
+
+	// without event parameters the state can only be decoded once
+	s = testSigner(&now, WithEventParams())
+
+	if target, err = s.URL("/voice/status", &menuState{}); err != nil {
+		t.Fatalf("URL() unexpected error: %s", err)
+	}
+
+	var out menuState
+
+	if err := s.Decode(statusRequest(target, "ringing", "1"), &out); err != nil {
+		t.Fatalf("Decode() unexpected error: %s", err)
+	}
+
+	if err := s.Decode(statusRequest(target, "completed", "2"), &out); errors.Cause(err) != ErrReplayedState {
+		t.Errorf("Decode() of another event without event parameters error = %v, want ErrReplayedState", err)
+	}
+}
+
+func TestSigner_differentKey(t *testing.T) {
+	target, err := NewSigner(testKey, time.Minute).URL("/voice", menuState{})
+
+	if err != nil {
+		t.Fatalf("URL() unexpected error: %s", err)
+	}
+
+	var out menuState
+
+	err = NewSigner([]byte("another key"), time.Minute).Decode(httptest.NewRequest("GET", target, nil), &out)
+
+	if errors.Cause(err) != ErrInvalidState {
+		t.Errorf("Decode() with another key error = %v, want ErrInvalidState", err)
+	}
+}
+
+func TestSigner_types(t *testing.T) {
+	s := NewSigner(testKey, time.Minute)
+
+	if _, err := s.URL("/voice", "not a struct"); err == nil {
+		t.Error("URL() with a string should fail")
+	}
+
+	if _, err := s.URL("/voice", struct{ C chan int }{}); err == nil {
+		t.Error("URL() with an unsupported field type should fail")
+	}
+
+	target, err := s.URL("/voice", menuState{})
+
+	if err != nil {
+		t.Fatalf("URL() unexpected error: %s", err)
+	}
+
+	var out menuState
+
+	if err := s.Decode(httptest.NewRequest("GET", target, nil), out); err == nil {
+		t.Error("Decode() in to a struct value should fail")
+	}
+}
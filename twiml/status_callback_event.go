@@ -4,11 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
 import (
 	"bytes"
 	"encoding/xml"
+	"strings"
 )
 
 // StatusCallbackEvent allows you to specify which events Twilio should webhook
@@ -33,7 +36,8 @@ const (
 
 // StatusCallbackAll is a combination of all StatusCallbackEvents, for endpoints
 // that want to receive a webhook from Twilio for all call status events.
-const StatusCallbackAll = StatusCallbackInitiated | StatusCallbackRinging | StatusCallbackAnswered | StatusCallbackCompleted
+const StatusCallbackAll = StatusCallbackInitiated | StatusCallbackRinging |
+	StatusCallbackAnswered | StatusCallbackCompleted
 
 // MarshalXMLAttr implements the xml.MarshalerAttr interface.
 func (s StatusCallbackEvent) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
@@ -45,6 +49,11 @@ func (s StatusCallbackEvent) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (s *StatusCallbackEvent) UnmarshalXMLAttr(attr xml.Attr) error {
+	return s.Set(attr.Value)
+}
+
 func (s StatusCallbackEvent) String() string {
 	if s == StatusCallbackEvent(0) {
 		return ""
@@ -85,3 +94,64 @@ func (s StatusCallbackEvent) String() string {
 
 	return buf.String()
 }
+
+// StatusCallbackEventValues returns each of the StatusCallbackEvent flags, for
+// listing the valid choices in configuration or help text.
+func StatusCallbackEventValues() []StatusCallbackEvent {
+	return []StatusCallbackEvent{
+		StatusCallbackInitiated,
+		StatusCallbackRinging,
+		StatusCallbackAnswered,
+		StatusCallbackCompleted,
+	}
+}
+
+// ParseStatusCallbackEvent parses a space- or comma-separated list of
+// StatusCallbackEvent flags (e.g., "initiated ringing"), compared
+// case-insensitively, in to a StatusCallbackEvent. This function returns a
+// wrapped error (see package documentation for more info).
+func ParseStatusCallbackEvent(s string) (StatusCallbackEvent, error) {
+	var parsed StatusCallbackEvent
+
+	for _, word := range splitFlags(s) {
+		found := false
+
+		for _, v := range StatusCallbackEventValues() {
+			if strings.EqualFold(v.String(), word) {
+				parsed |= v
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return StatusCallbackEvent(0), unknownValue("StatusCallbackEvent", s)
+		}
+	}
+
+	return parsed, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s StatusCallbackEvent) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *StatusCallbackEvent) UnmarshalText(text []byte) error {
+	return s.Set(string(text))
+}
+
+// Set implements the flag.Value interface. The parsed value replaces the
+// current one, rather than being combined with it.
+func (s *StatusCallbackEvent) Set(value string) error {
+	parsed, err := ParseStatusCallbackEvent(value)
+
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
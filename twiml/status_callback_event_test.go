@@ -9,6 +9,8 @@ package twiml
 import (
 	"encoding/xml"
 	"testing"
+
+	"github.com/pkg/errors"
 )
 
 func TestStatusCallbackEvent_String(t *testing.T) {
@@ -83,3 +85,38 @@ func TestStatusCallbackEvent_MarshalXMLAttr(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStatusCallbackEvent(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		out  StatusCallbackEvent
+		err  error
+	}{
+		{"Empty string should be the zero value", "", StatusCallbackEvent(0), nil},
+		{"Single event should be parsed", "ringing", StatusCallbackRinging, nil},
+		{"Space-separated events should be combined", "ringing answered", StatusCallbackRinging | StatusCallbackAnswered, nil},
+		{"Comma-separated events should be combined", "initiated, completed", StatusCallbackInitiated | StatusCallbackCompleted, nil},
+		{"Events should be case-insensitive", "RINGING", StatusCallbackRinging, nil},
+		{"String() of StatusCallbackAll should round-trip", StatusCallbackAll.String(), StatusCallbackAll, nil},
+		{"Unknown events should be rejected", "ringing bacon", StatusCallbackEvent(0), ErrUnknownValue},
+	}
+
+	for _, test := range tests {
+		out, err := ParseStatusCallbackEvent(test.in)
+
+		if errors.Cause(err) != test.err {
+			t.Errorf(
+				"\nDescription: %s\nParseStatusCallbackEvent(%q) error = %v; want cause %v",
+				test.desc, test.in, err, test.err,
+			)
+		}
+
+		if out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nParseStatusCallbackEvent(%q) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
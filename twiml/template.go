@@ -0,0 +1,288 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMissingTemplateValue is the cause of errors returned when rendering a
+// Template without a value for one of its placeholders.
+var ErrMissingTemplateValue = errors.New("missing template value")
+
+// varKind is the type of value a template placeholder accepts.
+type varKind byte
+
+const (
+	varText        varKind = 't'
+	varPhoneNumber varKind = 'p'
+	varDTMF        varKind = 'd'
+)
+
+// varNonce makes the placeholder markers unique to this process, so that text
+// which happens to look like a marker isn't treated as one.
+var varNonce = func() string {
+	b := make([]byte, 6)
+
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	return hex.EncodeToString(b)
+}()
+
+// varPattern matches the markers returned by Var, PhoneNumberVar, and DTMFVar
+// once they've been rendered to XML. The characters used survive XML escaping
+// unchanged.
+var varPattern = regexp.MustCompile(`⦃` + varNonce + `:([tpd]):([^⦄]*)⦄`)
+
+var validVarName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+func varMarker(kind varKind, name string) string {
+	return "⦃" + varNonce + ":" + string(kind) + ":" + name + "⦄"
+}
+
+// Var returns a placeholder for the named value, to be used in any string
+// field of a verb or noun that's compiled in to a Template. Names may only
+// contain letters, digits, '_', '.', and '-'. Any string is accepted as the
+// value when rendering.
+func Var(name string) string {
+	return varMarker(varText, name)
+}
+
+// PhoneNumberVar returns a placeholder for the named value, to be used in a
+// PhoneNumber field of a verb or noun that's compiled in to a Template. The
+// value must be a valid E.164 number when rendering.
+func PhoneNumberVar(name string) PhoneNumber {
+	return PhoneNumber(varMarker(varPhoneNumber, name))
+}
+
+// DTMFVar returns a placeholder for the named value, to be used in a DTMF
+// field of a verb or noun that's compiled in to a Template. The value must be a
+// valid DTMF sequence when rendering.
+func DTMFVar(name string) DTMF {
+	return DTMF(varMarker(varDTMF, name))
+}
+
+// TemplateValues are the values to substitute for the placeholders in a
+// Template, keyed by placeholder name.
+type TemplateValues map[string]string
+
+type templateSegment struct {
+	literal []byte
+	name    string
+	kind    varKind
+}
+
+// Template is a *Response that has been rendered once, with its placeholders
+// left as gaps that are filled in each time it's rendered. This avoids
+// building the verb structs and encoding them for every request, when most of
+// the document doesn't change. A Template is safe for concurrent use.
+//
+// Values are XML-escaped when they are substituted. Unlike MarshalResponse, a
+// placeholder in an omitempty attribute always renders the attribute, even
+// when the value is empty.
+type Template struct {
+	segments []templateSegment
+	vars     []string
+	etag     string
+}
+
+// CompileTemplate renders the *Response, which uses Var, PhoneNumberVar, or
+// DTMFVar for the values that change between requests, in to a Template. This
+// function returns a wrapped error (see package documentation for more
+// info).
+func CompileTemplate(r *Response) (*Template, error) {
+	doc, err := MarshalResponse(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "compiling template failed")
+	}
+
+	t := &Template{}
+	seen := make(map[string]varKind)
+	last := 0
+
+	for _, m := range varPattern.FindAllSubmatchIndex(doc, -1) {
+		kind := varKind(doc[m[2]])
+		name := string(doc[m[4]:m[5]])
+
+		if !validVarName.MatchString(name) {
+			return nil, errors.Errorf("compiling template failed: invalid placeholder name %q", name)
+		}
+
+		if k, ok := seen[name]; ok && k != kind {
+			return nil, errors.Errorf("compiling template failed: placeholder %q used with different types", name)
+		} else if !ok {
+			seen[name] = kind
+			t.vars = append(t.vars, name)
+		}
+
+		if m[0] > last {
+			t.segments = append(t.segments, templateSegment{literal: doc[last:m[0]]})
+		}
+
+		t.segments = append(t.segments, templateSegment{name: name, kind: kind})
+		last = m[1]
+	}
+
+	if last < len(doc) {
+		t.segments = append(t.segments, templateSegment{literal: doc[last:]})
+	}
+
+	if len(t.vars) == 0 {
+		sum := sha256.Sum256(doc)
+		t.etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+
+	return t, nil
+}
+
+// MustCompileTemplate is like CompileTemplate, but panics if the template
+// can't be compiled. It's meant for initializing package-level variables.
+func MustCompileTemplate(r *Response) *Template {
+	t, err := CompileTemplate(r)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Vars returns the names of the placeholders in the Template, in the order
+// they first appear in the document.
+func (t *Template) Vars() []string {
+	out := make([]string, len(t.vars))
+	copy(out, t.vars)
+
+	return out
+}
+
+// Static returns whether the Template has no placeholders, meaning it renders
+// the same document every time.
+func (t *Template) Static() bool {
+	return len(t.vars) == 0
+}
+
+// ETag returns a quoted entity tag, derived from a hash of the document, for a
+// static Template. This can be used for the ETag HTTP header. An empty string
+// is returned if the Template has placeholders.
+func (t *Template) ETag() string {
+	return t.etag
+}
+
+// Execute renders the Template with the values substituted for its
+// placeholders, writing it to w. Nothing is written if a value is missing or
+// invalid. This function returns a wrapped error (see package documentation
+// for more info).
+func (t *Template) Execute(w io.Writer, values TemplateValues) error {
+	if err := t.checkValues(values); err != nil {
+		return err
+	}
+
+	if len(t.segments) == 1 && t.segments[0].literal != nil {
+		if _, err := w.Write(t.segments[0].literal); err != nil {
+			return errors.Wrap(err, "writing template failed")
+		}
+
+		return nil
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	t.render(buf, values)
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "writing template failed")
+	}
+
+	return nil
+}
+
+// Render renders the Template with the values substituted for its
+// placeholders, returning the document. This function returns a wrapped error
+// (see package documentation for more info).
+func (t *Template) Render(values TemplateValues) ([]byte, error) {
+	if err := t.checkValues(values); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, t.size(values)))
+
+	t.render(buf, values)
+
+	return buf.Bytes(), nil
+}
+
+func (t *Template) checkValues(values TemplateValues) error {
+	for _, s := range t.segments {
+		if s.literal != nil {
+			continue
+		}
+
+		v, ok := values[s.name]
+
+		if !ok {
+			return errors.Wrapf(ErrMissingTemplateValue, "rendering template failed: %q", s.name)
+		}
+
+		var err error
+
+		switch s.kind {
+		case varPhoneNumber:
+			err = PhoneNumber(v).Validate()
+		case varDTMF:
+			err = DTMF(v).Validate()
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "rendering template failed: %q", s.name)
+		}
+	}
+
+	return nil
+}
+
+func (t *Template) size(values TemplateValues) int {
+	var n int
+
+	for _, s := range t.segments {
+		if s.literal != nil {
+			n += len(s.literal)
+		} else {
+			n += len(values[s.name])
+		}
+	}
+
+	return n
+}
+
+func (t *Template) render(buf *bytes.Buffer, values TemplateValues) {
+	for _, s := range t.segments {
+		if s.literal != nil {
+			buf.Write(s.literal)
+			continue
+		}
+
+		// writes to a *bytes.Buffer never fail
+		_ = xml.EscapeText(buf, []byte(values[s.name]))
+	}
+}
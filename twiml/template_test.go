@@ -0,0 +1,190 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func templateTestResponse(name string, number PhoneNumber, digits DTMF) *Response {
+	return &Response{
+		Verbs: []interface{}{
+			&Say{Message: "Hello " + name + ", connecting you now.", Voice: VoiceAlice},
+			&Dial{
+				CallerID: "+14155555555",
+				Nouns: []interface{}{
+					&DialNumber{Number: number, SendDigits: digits},
+				},
+			},
+		},
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := CompileTemplate(templateTestResponse(Var("name"), PhoneNumberVar("number"), DTMFVar("ext")))
+
+	if err != nil {
+		t.Fatalf("CompileTemplate() Unexpected Error: %s", err)
+	}
+
+	if tmpl.Static() || tmpl.ETag() != "" {
+		t.Errorf("Template with placeholders should not be static (ETag %q)", tmpl.ETag())
+	}
+
+	if vars := strings.Join(tmpl.Vars(), ","); vars != "name,ext,number" {
+		t.Errorf("Template.Vars() = %q; want %q", vars, "name,ext,number")
+	}
+
+	tests := []struct {
+		desc   string
+		values TemplateValues
+		err    error
+	}{
+		{"Plain values should render", TemplateValues{"name": "Bob", "number": "+14155555656", "ext": "ww123"}, nil},
+		{"Values should be XML-escaped", TemplateValues{"name": `Bob & "Alice" <3`, "number": "+14155555656", "ext": ""}, nil},
+		{"Missing value should fail", TemplateValues{"name": "Bob", "number": "+14155555656"}, ErrMissingTemplateValue},
+		{"Invalid phone number should fail", TemplateValues{"name": "Bob", "number": "555-5555", "ext": ""}, ErrInvalidPhoneNumber},
+		{"Invalid DTMF should fail", TemplateValues{"name": "Bob", "number": "+14155555656", "ext": "1,2"}, ErrInvalidDTMF},
+	}
+
+	for _, test := range tests {
+		out, err := tmpl.Render(test.values)
+
+		if cause := errors.Cause(err); cause != test.err {
+			t.Errorf("\nDescription: %s\nTemplate.Render() error = %v; want cause %v", test.desc, err, test.err)
+			continue
+		}
+
+		if test.err != nil {
+			continue
+		}
+
+		want, err := MarshalResponse(templateTestResponse(
+			test.values["name"], PhoneNumber(test.values["number"]), DTMF(test.values["ext"]),
+		))
+
+		if err != nil {
+			t.Fatalf("MarshalResponse() Unexpected Error: %s", err)
+		}
+
+		// an empty placeholder still renders its attribute
+		if test.values["ext"] == "" {
+			want = bytes.Replace(want, []byte("<Number>"), []byte(`<Number sendDigits="">`), 1)
+		}
+
+		if !bytes.Equal(out, want) {
+			t.Errorf("\nDescription: %s\nTemplate.Render() =\n%s\nwant:\n%s", test.desc, out, want)
+		}
+
+		buf := &bytes.Buffer{}
+
+		if err := tmpl.Execute(buf, test.values); err != nil {
+			t.Errorf("\nDescription: %s\nTemplate.Execute() Unexpected Error: %s", test.desc, err)
+		}
+
+		if !bytes.Equal(buf.Bytes(), out) {
+			t.Errorf("\nDescription: %s\nTemplate.Execute() =\n%s\nwant:\n%s", test.desc, buf.Bytes(), out)
+		}
+	}
+}
+
+func TestTemplate_Static(t *testing.T) {
+	resp := &Response{Verbs: []interface{}{&Say{Message: "We're closed."}, &Hangup{}}}
+
+	tmpl, err := CompileTemplate(resp)
+
+	if err != nil {
+		t.Fatalf("CompileTemplate() Unexpected Error: %s", err)
+	}
+
+	if !tmpl.Static() {
+		t.Error("Template without placeholders should be static")
+	}
+
+	etag := tmpl.ETag()
+
+	if len(etag) != 34 || etag[0] != '"' || etag[33] != '"' {
+		t.Errorf("Template.ETag() = %q; want quoted 32 character hash", etag)
+	}
+
+	if other := MustCompileTemplate(resp).ETag(); other != etag {
+		t.Errorf("ETag of the same document = %q; want %q", other, etag)
+	}
+
+	if other := MustCompileTemplate(&Response{Verbs: []interface{}{&Hangup{}}}).ETag(); other == etag {
+		t.Error("ETag of a different document should be different")
+	}
+
+	out, err := tmpl.Render(nil)
+
+	if err != nil {
+		t.Fatalf("Template.Render() Unexpected Error: %s", err)
+	}
+
+	want, _ := MarshalResponse(resp)
+
+	if !bytes.Equal(out, want) {
+		t.Errorf("Template.Render() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCompileTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *Response
+	}{
+		{"Invalid placeholder name should fail", &Response{Verbs: []interface{}{&Say{Message: Var("a b")}}}},
+		{"Placeholder reused with a different type should fail", &Response{Verbs: []interface{}{
+			&Say{Message: Var("x")}, &Sms{To: PhoneNumberVar("x")},
+		}}},
+	}
+
+	for _, test := range tests {
+		if _, err := CompileTemplate(test.in); err == nil {
+			t.Errorf("\nDescription: %s\nCompileTemplate() should fail", test.desc)
+		}
+	}
+}
+
+func BenchmarkTemplateRender(b *testing.B) {
+	tmpl := MustCompileTemplate(templateTestResponse(Var("name"), PhoneNumberVar("number"), DTMFVar("ext")))
+	values := TemplateValues{"name": "Bob", "number": "+14155555656", "ext": "ww123"}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := tmpl.Render(values); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTemplateRenderStatic(b *testing.B) {
+	tmpl := MustCompileTemplate(templateTestResponse("Bob", "+14155555656", "ww123"))
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := tmpl.Render(nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalResponseTemplateEquivalent(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalResponse(templateTestResponse("Bob", "+14155555656", "ww123")); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
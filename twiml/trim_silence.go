@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // Trim lets you specify whether to trim leading and trailing silence from your
 // audio files.
@@ -31,6 +36,11 @@ func (t Trim) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (t *Trim) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.Set(attr.Value)
+}
+
 func (t Trim) String() string {
 	switch t {
 	case TrimSilence:
@@ -41,3 +51,52 @@ func (t Trim) String() string {
 		return ""
 	}
 }
+
+// TrimValues returns all of the Trim constants that render a value, for listing
+// the valid choices in configuration or help text.
+func TrimValues() []Trim {
+	return []Trim{
+		TrimSilence,
+		DoNotTrimSilence,
+	}
+}
+
+// ParseTrim returns the Trim whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseTrim(s string) (Trim, error) {
+	if s == "" {
+		return Trim(0), nil
+	}
+
+	for _, v := range TrimValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return Trim(0), unknownValue("Trim", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t Trim) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *Trim) UnmarshalText(text []byte) error {
+	return t.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (t *Trim) Set(value string) error {
+	parsed, err := ParseTrim(value)
+
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
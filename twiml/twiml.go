@@ -6,6 +6,8 @@
 
 package twiml
 
+//go:generate go run ./internal/twimlgen -schema schema.json
+
 import (
 	"bytes"
 	"encoding/xml"
@@ -30,10 +32,34 @@ type Response struct {
 	Verbs   []interface{}
 }
 
+// EncodeOption configures optional behavior of EncodeResponse and the other
+// functions that render a *Response.
+type EncodeOption func(*encodeConfig)
+
+type encodeConfig struct {
+	limits *Limits
+	schema bool
+}
+
 // EncodeResponse takes a *Response instance and encodes it, writing it to w.
-// This function returns a wrapped error (see package documentation for more
-// info).
-func EncodeResponse(w io.Writer, r *Response) error {
+// EncodeOptions, such as WithLimits or WithSchemaValidation, can be provided
+// to change how the document is encoded. This function returns a wrapped error
+// (see package documentation for more info).
+func EncodeResponse(w io.Writer, r *Response, opts ...EncodeOption) error {
+	var cfg encodeConfig
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.limits == nil && !cfg.schema {
+		return encodeResponse(w, r)
+	}
+
+	return encodeResponseChecked(w, r, cfg)
+}
+
+func encodeResponse(w io.Writer, r *Response) error {
 	// get a new XML encoder for writing to the buffer
 	// enable indenting of output
 	encoder := xml.NewEncoder(w)
@@ -52,10 +78,46 @@ func EncodeResponse(w io.Writer, r *Response) error {
 	return nil
 }
 
-// MarshalResponse takes a *Response instance and renders it to XML.
-// This function returns a wrapped error (see package documentation for more
-// info).
-func MarshalResponse(r *Response) ([]byte, error) {
+// encodeResponseChecked renders the *Response to a buffer first, so that
+// nothing is written to w if it fails any of the checks in cfg.
+func encodeResponseChecked(w io.Writer, r *Response, cfg encodeConfig) error {
+	if cfg.limits != nil {
+		if err := cfg.limits.Check(r); err != nil {
+			return errors.Wrap(err, "checking response limits failed")
+		}
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	if err := encodeResponse(buf, r); err != nil {
+		return err
+	}
+
+	if l := cfg.limits; l != nil && l.MaxResponseSize > 0 && buf.Len() > l.MaxResponseSize {
+		err := &LimitError{Kind: LimitResponseSize, Size: buf.Len(), Max: l.MaxResponseSize}
+		return errors.Wrap(err, "checking response limits failed")
+	}
+
+	if cfg.schema {
+		if err := ValidateDocument(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := buf.WriteTo(w); err != nil {
+		return errors.Wrap(err, "writing XML document failed")
+	}
+
+	return nil
+}
+
+// MarshalResponse takes a *Response instance and renders it to XML, using the
+// same EncodeOptions as EncodeResponse. This function returns a wrapped error
+// (see package documentation for more info).
+func MarshalResponse(r *Response, opts ...EncodeOption) ([]byte, error) {
 	// get a new *bytes.Buffer from the pool
 	buf := bufferPool.Get().(*bytes.Buffer)
 
@@ -64,7 +126,7 @@ func MarshalResponse(r *Response) ([]byte, error) {
 	defer bufferPool.Put(buf)
 	defer buf.Reset()
 
-	if err := EncodeResponse(buf, r); err != nil {
+	if err := EncodeResponse(buf, r, opts...); err != nil {
 		return nil, errors.Wrap(err, "encoding response failed")
 	}
 
@@ -79,13 +141,13 @@ func MarshalResponse(r *Response) ([]byte, error) {
 // EncodeSlice takes a []inteface{}, allocates a *Response instances, and
 // encodes it to w. This function returns a wrapped error (see package
 // documentation for more info).
-func EncodeSlice(w io.Writer, s []interface{}) error {
-	return EncodeResponse(w, &Response{Verbs: s})
+func EncodeSlice(w io.Writer, s []interface{}, opts ...EncodeOption) error {
+	return EncodeResponse(w, &Response{Verbs: s}, opts...)
 }
 
 // MarshalSlice takes a []interface{}, allocates a *Response instance, and calls
 // MarshalResponse with it. This function returns a wrapped error (see package
 // documentation for more info).
-func MarshalSlice(s []interface{}) ([]byte, error) {
-	return MarshalResponse(&Response{Verbs: s})
+func MarshalSlice(s []interface{}, opts ...EncodeOption) ([]byte, error) {
+	return MarshalResponse(&Response{Verbs: s}, opts...)
 }
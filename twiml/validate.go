@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import "github.com/pkg/errors"
+
+// ErrInvalidNesting is the cause of errors returned by Validate when a verb or
+// noun is placed somewhere TwiML doesn't allow it, such as a Redirect within a
+// Gather.
+var ErrInvalidNesting = errors.New("invalid nesting")
+
+// Validate checks that each verb and noun within the *Response is allowed
+// within its parent, using the rules from the schema the types in this package
+// are generated from. Values of types not defined in this package are never
+// allowed. Attribute values are not checked. This function returns a wrapped
+// error (see package documentation for more info).
+func Validate(r *Response) error {
+	if r == nil {
+		return nil
+	}
+
+	return Walk(r, func(path Path, node interface{}) error {
+		parent := "Response"
+
+		if p := path.Parent(); len(p) > 0 {
+			parent = p[len(p)-1].Name
+		}
+
+		name := path[len(path)-1].Name
+
+		if !childAllowed(parent, name) {
+			return errors.Wrapf(ErrInvalidNesting, "%s: %s is not allowed within %s", path, name, parent)
+		}
+
+		return nil
+	})
+}
+
+func childAllowed(parent, child string) bool {
+	for _, name := range allowedChildren[parent] {
+		if name == child {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   *Response
+		path string
+	}{
+		{
+			desc: "nil Response should be valid",
+			in:   nil,
+		},
+		{
+			desc: "all top-level verbs should be valid",
+			in:   walkTestResponse(),
+		},
+		{
+			desc: "Pay with Prompt and Parameter nouns should be valid",
+			in: &Response{
+				Verbs: []interface{}{
+					&Pay{
+						PaymentConnector: "stripe",
+						Nouns: []interface{}{
+							&Prompt{For: PayPromptCardNumber, NestedVerbs: []interface{}{&Say{Message: "Enter your card."}}},
+							&Parameter{Name: "order", Value: "42"},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "Start with a Stream and Parameter should be valid",
+			in: &Response{
+				Verbs: []interface{}{
+					Start{Nouns: []interface{}{
+						Stream{URL: "wss://example.org/audio", Parameters: []interface{}{Parameter{Name: "a"}}},
+					}},
+				},
+			},
+		},
+		{
+			desc: "Redirect within Gather should be invalid",
+			in: &Response{
+				Verbs: []interface{}{
+					&Gather{NestedVerbs: []interface{}{&Say{}, &Redirect{URL: "/next"}}},
+				},
+			},
+			path: "Gather[0]/Redirect[1]",
+		},
+		{
+			desc: "noun at the top-level should be invalid",
+			in:   &Response{Verbs: []interface{}{&DialNumber{Number: "+14155555555"}}},
+			path: "Number[0]",
+		},
+		{
+			desc: "Room within Dial should be invalid",
+			in: &Response{
+				Verbs: []interface{}{
+					&Dial{Nouns: []interface{}{&DialClient{ClientName: "alice"}, &Room{Name: "lobby"}}},
+				},
+			},
+			path: "Dial[0]/Room[1]",
+		},
+		{
+			desc: "type from outside the package should be invalid",
+			in:   &Response{Verbs: []interface{}{PathElem{}}},
+			path: "PathElem[0]",
+		},
+	}
+
+	for _, test := range tests {
+		err := Validate(test.in)
+
+		if test.path == "" {
+			if err != nil {
+				t.Errorf("\nDescription: %s\nValidate() Unexpected Error: %s", test.desc, err)
+			}
+
+			continue
+		}
+
+		if errors.Cause(err) != ErrInvalidNesting {
+			t.Errorf("\nDescription: %s\nValidate() error = %v; want cause %v", test.desc, err, ErrInvalidNesting)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), test.path+":") {
+			t.Errorf("\nDescription: %s\nValidate() error = %q; want path %s", test.desc, err, test.path)
+		}
+	}
+}
+
+func TestUnmarshal_Attributes(t *testing.T) {
+	const doc = `<Gather input="dtmf speech" language="en-GB" bargeIn="false" finishOnKey="" numDigits="4"></Gather>`
+
+	var g Gather
+
+	if err := xml.Unmarshal([]byte(doc), &g); err != nil {
+		t.Fatalf("xml.Unmarshal() Unexpected Error: %s", err)
+	}
+
+	if g.Input != GatherInputDTMFSpeech {
+		t.Errorf("Gather.Input = %q; want %q", g.Input, GatherInputDTMFSpeech)
+	}
+
+	if g.Language != LangEnglishUK {
+		t.Errorf("Gather.Language = %q; want %q", g.Language, LangEnglishUK)
+	}
+
+	if g.BargeIn != BargeInFalse {
+		t.Errorf("Gather.BargeIn = %q; want %q", g.BargeIn, BargeInFalse)
+	}
+
+	if g.FinishOnKey != FinishKeyNone {
+		t.Errorf("Gather.FinishOnKey = %d; want FinishKeyNone", g.FinishOnKey)
+	}
+
+	if g.NumDigits != 4 {
+		t.Errorf("Gather.NumDigits = %d; want 4", g.NumDigits)
+	}
+
+	if err := xml.Unmarshal([]byte(`<Say voice="robot"></Say>`), &Say{}); errors.Cause(err) != ErrUnknownValue {
+		t.Errorf("xml.Unmarshal() error = %v; want cause %v", err, ErrUnknownValue)
+	}
+}
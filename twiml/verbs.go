@@ -4,11 +4,24 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import (
-	"encoding/xml"
-)
+import "encoding/xml"
+
+// The Connect verb connects the call to another Twilio product, such as a
+// Programmable Video Room or a bidirectional media Stream. Call flow continues
+// with the TwiML from the action URL, if one is provided, once the connection
+// ends.
+type Connect struct {
+	XMLName xml.Name `xml:"Connect"`
+	Action  string   `xml:"action,attr,omitempty"`
+	Method  string   `xml:"method,attr,omitempty"`
+
+	// Nouns within Connect can only contain a single Room or Stream noun.
+	Nouns []interface{}
+}
 
 // The Dial verb connects the current caller to another phone. If the called
 // party picks up, the two parties are connected and can communicate until one
@@ -17,23 +30,27 @@ import (
 //
 // When the dialed call ends, Twilio makes a GET or POST request to the 'action'
 // URL if provided. Call flow will continue using the TwiML received in response
-// to that request.
+// to that request. NewDialResult returns the outcome of the Dial from the
+// parsed request.
 type Dial struct {
-	XMLName                       xml.Name   `xml:"Dial"`
-	Number                        string     `xml:",chardata"`
-	Action                        string     `xml:"action,attr,omitempty"`
-	Method                        string     `xml:"method,attr,omitempty"`
-	Timeout                       uint       `xml:"timeout,attr,omitempty"`
-	HangupOnStar                  bool       `xml:"hangupOnStar,attr"`
-	TimeLimit                     uint       `xml:"timeLimit,attr,omitempty"`
-	CallerID                      string     `xml:"callerId,attr,omitempty"`
-	Record                        DialRecord `xml:"record,attr,omitempty"`
-	Trim                          Trim       `xml:"trim,attr,omitempty"`
-	RecordingStatusCallback       string     `xml:"recordingStatusCallback,attr,omitempty"`
-	RecordingStatusCallbackMethod string     `xml:"recordingStatusCallbackMethod,attr,omitempty"`
-	AnswerOnBridge                bool       `xml:"answerOnBridge,attr"`
-	RingTone                      RingTone   `xml:"ringTone,attr,omitempty"`
-	Nouns                         []interface{}
+	XMLName                       xml.Name    `xml:"Dial"`
+	Number                        PhoneNumber `xml:",chardata"`
+	Action                        string      `xml:"action,attr,omitempty"`
+	Method                        string      `xml:"method,attr,omitempty"`
+	Timeout                       uint        `xml:"timeout,attr,omitempty"`
+	HangupOnStar                  bool        `xml:"hangupOnStar,attr"`
+	TimeLimit                     uint        `xml:"timeLimit,attr,omitempty"`
+	CallerID                      PhoneNumber `xml:"callerId,attr,omitempty"`
+	Record                        DialRecord  `xml:"record,attr,omitempty"`
+	Trim                          Trim        `xml:"trim,attr,omitempty"`
+	RecordingStatusCallback       string      `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string      `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	AnswerOnBridge                bool        `xml:"answerOnBridge,attr"`
+	RingTone                      RingTone    `xml:"ringTone,attr,omitempty"`
+
+	// Nouns within Dial can only contain the Dial nouns: DialClient,
+	// DialConference, DialNumber, DialQueue, DialSIM, and DialSIP.
+	Nouns []interface{}
 }
 
 // The Enqueue verb enqueues the current call in a call queue. Enqueued calls
@@ -58,7 +75,8 @@ type Enqueue struct {
 // The Gather verb collects digits or transcribes speech from a caller, when the
 // caller is done entering digits or speaking, Twilio submits that data to the
 // provided 'action' URL in an HTTP GET or POST request, just like a web browser
-// submits data from an HTML form.
+// submits data from an HTML form. NewGatherResult returns the input from the
+// parsed request.
 type Gather struct {
 	XMLName                     xml.Name    `xml:"Gather"`
 	Input                       GatherInput `xml:"input,attr,omitempty"`
@@ -72,6 +90,10 @@ type Gather struct {
 	Language                    Language    `xml:"language,attr,omitempty"`
 	Hints                       string      `xml:"hints,attr,omitempty"`
 	BargeIn                     BargeIn     `xml:"bargeIn,attr,omitempty"`
+	SpeechTimeout               string      `xml:"speechTimeout,attr,omitempty"`
+	SpeechModel                 string      `xml:"speechModel,attr,omitempty"`
+	Enhanced                    bool        `xml:"enhanced,attr,omitempty"`
+	ActionOnEmptyResult         bool        `xml:"actionOnEmptyResult,attr,omitempty"`
 
 	// NestedVerbs within Gather can only contain these three verb types: Say,
 	// Play, and Pause. Other types may be rejected by the Twilio TwiML parser.
@@ -100,12 +122,41 @@ type Pause struct {
 	Length  uint     `xml:"length,attr,omitempty"`
 }
 
+// The Pay verb collects payment details from the caller over DTMF, in a PCI
+// compliant manner, and hands them to a payment connector for processing. The
+// result is sent to the action URL.
+type Pay struct {
+	XMLName              xml.Name        `xml:"Pay"`
+	Input                GatherInput     `xml:"input,attr,omitempty"`
+	Action               string          `xml:"action,attr,omitempty"`
+	Method               string          `xml:"method,attr,omitempty"`
+	BankAccountType      BankAccountType `xml:"bankAccountType,attr,omitempty"`
+	StatusCallback       string          `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackMethod string          `xml:"statusCallbackMethod,attr,omitempty"`
+	Timeout              uint            `xml:"timeout,attr,omitempty"`
+	MaxAttempts          uint            `xml:"maxAttempts,attr,omitempty"`
+	SecurityCode         PaySecurityCode `xml:"securityCode,attr,omitempty"`
+	PostalCode           string          `xml:"postalCode,attr,omitempty"`
+	MinPostalCodeLength  uint            `xml:"minPostalCodeLength,attr,omitempty"`
+	PaymentConnector     string          `xml:"paymentConnector,attr,omitempty"`
+	PaymentMethod        PaymentMethod   `xml:"paymentMethod,attr,omitempty"`
+	TokenType            PayTokenType    `xml:"tokenType,attr,omitempty"`
+	ChargeAmount         string          `xml:"chargeAmount,attr,omitempty"`
+	Currency             string          `xml:"currency,attr,omitempty"`
+	Description          string          `xml:"description,attr,omitempty"`
+	ValidCardTypes       string          `xml:"validCardTypes,attr,omitempty"`
+	Language             Language        `xml:"language,attr,omitempty"`
+
+	// Nouns within Pay can only contain Prompt and Parameter nouns.
+	Nouns []interface{}
+}
+
 // Play is play
 type Play struct {
 	XMLName xml.Name `xml:"Play"`
 	URL     string   `xml:",chardata"`
 	Loop    uint     `xml:"loop,attr,omitempty"`
-	Digits  string   `xml:"digits,attr,omitempty"`
+	Digits  DTMF     `xml:"digits,attr,omitempty"`
 }
 
 // The Record verb records the caller's voice and returns to you the URL of a
@@ -123,6 +174,7 @@ type Record struct {
 	Trim                          Trim        `xml:"trim,attr,omitempty"`
 	RecordingStatusCallback       string      `xml:"recordingStatusCallback,attr,omitempty"`
 	RecordingStatusCallbackMethod string      `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallbackEvent  string      `xml:"recordingStatusCallbackEvent,attr,omitempty"`
 	Transcribe                    bool        `xml:"transcribe,attr"`
 	TranscribeCallback            string      `xml:"transcribeCallback,attr,omitempty"`
 }
@@ -157,11 +209,32 @@ type Say struct {
 
 // The Sms verb sends an SMS message to a phone number during a phone call.
 type Sms struct {
-	XMLName        xml.Name `xml:"Sms"`
-	Message        string   `xml:",chardata"`
-	To             string   `xml:"to,attr,omitempty"`
-	From           string   `xml:"from,attr,omitempty"`
-	Action         string   `xml:"action,attr,omitempty"`
-	Method         string   `xml:"method,attr,omitempty"`
-	StatusCallback string   `xml:"statusCallback,attr,omitempty"`
+	XMLName        xml.Name    `xml:"Sms"`
+	Message        string      `xml:",chardata"`
+	To             PhoneNumber `xml:"to,attr,omitempty"`
+	From           PhoneNumber `xml:"from,attr,omitempty"`
+	Action         string      `xml:"action,attr,omitempty"`
+	Method         string      `xml:"method,attr,omitempty"`
+	StatusCallback string      `xml:"statusCallback,attr,omitempty"`
+}
+
+// The Start verb starts an asynchronous action, such as a media Stream or a
+// Siprec session, for the rest of the call. Call flow continues with the next
+// verb immediately.
+type Start struct {
+	XMLName xml.Name `xml:"Start"`
+	Action  string   `xml:"action,attr,omitempty"`
+	Method  string   `xml:"method,attr,omitempty"`
+
+	// Nouns within Start can only contain Stream and Siprec nouns.
+	Nouns []interface{}
+}
+
+// The Stop verb stops an asynchronous action started by the Start verb. The
+// Stream or Siprec noun is matched by its Name.
+type Stop struct {
+	XMLName xml.Name `xml:"Stop"`
+
+	// Nouns within Stop can only contain Stream and Siprec nouns.
+	Nouns []interface{}
 }
@@ -4,9 +4,14 @@
 //
 // Copyright (c) 2017 Tim Heckman
 
+// Code generated by twimlgen from schema.json. DO NOT EDIT.
+
 package twiml
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strings"
+)
 
 // Voice is the voices that are available as part of the Twilio Text to Speech
 // engine using in calls. The default voice is Alice as it has better support
@@ -40,6 +45,11 @@ func (v Voice) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return attr, nil
 }
 
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface.
+func (v *Voice) UnmarshalXMLAttr(attr xml.Attr) error {
+	return v.Set(attr.Value)
+}
+
 func (v Voice) String() string {
 	switch v {
 	case VoiceDefault:
@@ -54,3 +64,53 @@ func (v Voice) String() string {
 		return "unknown"
 	}
 }
+
+// VoiceValues returns all of the Voice constants that render a value, for
+// listing the valid choices in configuration or help text.
+func VoiceValues() []Voice {
+	return []Voice{
+		VoiceAlice,
+		VoiceMan,
+		VoiceWoman,
+	}
+}
+
+// ParseVoice returns the Voice whose TwiML value is s, compared
+// case-insensitively. This function returns a wrapped error (see package
+// documentation for more info).
+func ParseVoice(s string) (Voice, error) {
+	if s == "" {
+		return Voice(0), nil
+	}
+
+	for _, v := range VoiceValues() {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return Voice(0), unknownValue("Voice", s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Voice) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (v *Voice) UnmarshalText(text []byte) error {
+	return v.Set(string(text))
+}
+
+// Set implements the flag.Value interface.
+func (v *Voice) Set(value string) error {
+	parsed, err := ParseVoice(value)
+
+	if err != nil {
+		return err
+	}
+
+	*v = parsed
+
+	return nil
+}
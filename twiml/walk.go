@@ -0,0 +1,248 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SkipChildren is used as a return value from a WalkFunc or TransformFunc to
+// indicate that the nested verbs or nouns of the current node should not be
+// visited. It is never returned as an error by Walk or Transform.
+var SkipChildren = errors.New("skip children of this node")
+
+// PathElem is a single step in a Path: the XML element name of a node and its
+// index within the parent's slice of verbs or nouns.
+type PathElem struct {
+	Name  string
+	Index int
+}
+
+// Path is the location of a node within a *Response. The first element is the
+// position within Response.Verbs, and any subsequent elements are positions
+// within Gather.NestedVerbs or Dial.Nouns.
+type Path []PathElem
+
+// Parent returns the Path of the node containing the node at p. The parent of
+// a top-level verb is an empty Path.
+func (p Path) Parent() Path {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return p[:len(p)-1]
+}
+
+// Depth returns how deeply nested the node is, with top-level verbs being at a
+// depth of 1.
+func (p Path) Depth() int {
+	return len(p)
+}
+
+// String renders the Path in the form "Gather[0]/Say[1]".
+func (p Path) String() string {
+	buf := bufferPool.Get().(*bytes.Buffer)
+
+	defer bufferPool.Put(buf)
+	defer buf.Reset()
+
+	for i, e := range p {
+		if i > 0 {
+			buf.WriteString("/")
+		}
+
+		buf.WriteString(e.Name)
+		buf.WriteString("[")
+		buf.WriteString(strconv.Itoa(e.Index))
+		buf.WriteString("]")
+	}
+
+	return buf.String()
+}
+
+// child returns a new Path for the node at index i within the node at p. The
+// capacity is clamped so appending never writes into a sibling's Path.
+func (p Path) child(name string, i int) Path {
+	return append(p[:len(p):len(p)], PathElem{Name: name, Index: i})
+}
+
+// WalkFunc is the type of the function called by Walk for each verb and noun.
+// If the function returns SkipChildren, the nested verbs or nouns of node are
+// not visited. Any other non-nil error stops the walk and is returned by Walk.
+type WalkFunc func(path Path, node interface{}) error
+
+// Walk traverses the *Response depth-first, calling fn for each verb in
+// r.Verbs, for each verb nested within a Gather, and for each noun within a
+// Dial. A parent is always visited before its children.
+func Walk(r *Response, fn WalkFunc) error {
+	if r == nil {
+		return nil
+	}
+
+	return walkSlice(nil, r.Verbs, fn)
+}
+
+func walkSlice(parent Path, nodes []interface{}, fn WalkFunc) error {
+	for i, node := range nodes {
+		path := parent.child(NodeName(node), i)
+
+		if err := fn(path, node); err != nil {
+			if err == SkipChildren {
+				continue
+			}
+
+			return err
+		}
+
+		if err := walkSlice(path, children(node), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TransformFunc is the type of the function called by Transform for each verb
+// and noun. The value returned replaces node in the document, with a nil value
+// removing it. The nested verbs or nouns of the returned value are visited
+// afterwards, unless SkipChildren is returned. Any other non-nil error stops
+// the transformation and is returned by Transform.
+type TransformFunc func(path Path, node interface{}) (interface{}, error)
+
+// Transform traverses the *Response in the same order as Walk, replacing each
+// verb and noun with the value returned by fn. The *Response is modified in
+// place, so use Clone first if the original needs to be preserved. If fn
+// returns an error r may be left partially transformed.
+func Transform(r *Response, fn TransformFunc) error {
+	if r == nil {
+		return nil
+	}
+
+	verbs, err := transformSlice(nil, r.Verbs, fn)
+
+	if err != nil {
+		return err
+	}
+
+	r.Verbs = verbs
+
+	return nil
+}
+
+func transformSlice(parent Path, nodes []interface{}, fn TransformFunc) ([]interface{}, error) {
+	if nodes == nil {
+		return nil, nil
+	}
+
+	out := make([]interface{}, 0, len(nodes))
+
+	for i, node := range nodes {
+		replacement, err := fn(parent.child(NodeName(node), i), node)
+
+		skip := err == SkipChildren
+
+		if err != nil && !skip {
+			return nil, err
+		}
+
+		if replacement == nil {
+			continue
+		}
+
+		if !skip {
+			kids, err := transformSlice(parent.child(NodeName(replacement), i), children(replacement), fn)
+
+			if err != nil {
+				return nil, err
+			}
+
+			replacement = setChildren(replacement, kids)
+		}
+
+		out = append(out, replacement)
+	}
+
+	return out, nil
+}
+
+// Clone returns a deep copy of the *Response. All verbs and nouns defined in
+// this package are copied, including the contents of Gather.NestedVerbs and
+// Dial.Nouns. Values of other types are shallow-copied if they are pointers to
+// structs, and are otherwise shared with the original.
+func Clone(r *Response) *Response {
+	if r == nil {
+		return nil
+	}
+
+	return &Response{
+		XMLName: r.XMLName,
+		Verbs:   cloneSlice(r.Verbs),
+	}
+}
+
+func cloneSlice(nodes []interface{}) []interface{} {
+	if nodes == nil {
+		return nil
+	}
+
+	out := make([]interface{}, len(nodes))
+
+	for i, node := range nodes {
+		out[i] = cloneNode(node)
+	}
+
+	return out
+}
+
+func cloneNode(node interface{}) interface{} {
+	if c, ok := cloneContainer(node); ok {
+		return c
+	}
+
+	// the remaining verbs and nouns only contain value fields, so copying
+	// the struct the pointer refers to is a deep copy
+	v := reflect.ValueOf(node)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return node
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+
+	return c.Interface()
+}
+
+// NodeName returns the XML element name a verb or noun renders as, such as
+// "Say" for *Say or "Number" for *DialNumber. If the value has no XMLName
+// field the name of its type is returned.
+func NodeName(node interface{}) string {
+	t := reflect.TypeOf(node)
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return ""
+	}
+
+	if t.Kind() == reflect.Struct {
+		if f, ok := t.FieldByName("XMLName"); ok {
+			if name := strings.Split(f.Tag.Get("xml"), ",")[0]; name != "" {
+				return name
+			}
+		}
+	}
+
+	return t.Name()
+}
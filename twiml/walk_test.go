@@ -0,0 +1,286 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func walkTestResponse() *Response {
+	return &Response{
+		Verbs: []interface{}{
+			&Say{Message: "Welcome!"},
+			&Gather{
+				Action: "/gather",
+				NestedVerbs: []interface{}{
+					&Say{Message: "Press one."},
+					&Play{URL: "/beep.mp3"},
+				},
+			},
+			&Dial{
+				Action: "/dial",
+				Nouns: []interface{}{
+					&DialNumber{Number: "+14155555555", StatusCallback: "/status"},
+					&DialClient{ClientName: "alice"},
+				},
+			},
+			&Redirect{URL: "/next"},
+		},
+	}
+}
+
+func TestPath_String(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   Path
+		out  string
+	}{
+		{"Empty Path should be empty string", nil, ""},
+		{"Top-level Path should have one element", Path{{"Say", 0}}, "Say[0]"},
+		{"Nested Path should be separated by slashes", Path{{"Gather", 1}, {"Say", 0}}, "Gather[1]/Say[0]"},
+	}
+
+	for _, test := range tests {
+		if out := test.in.String(); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nPath(%#v).String() = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestNodeName(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   interface{}
+		out  string
+	}{
+		{"*Say should be Say", &Say{}, "Say"},
+		{"Say value should be Say", Say{}, "Say"},
+		{"*DialNumber should be Number", &DialNumber{}, "Number"},
+		{"*DialSIP should be Sip", &DialSIP{}, "Sip"},
+		{"Type without XMLName should use its type name", PathElem{}, "PathElem"},
+		{"nil should be empty string", nil, ""},
+	}
+
+	for _, test := range tests {
+		if out := NodeName(test.in); out != test.out {
+			t.Errorf(
+				"\nDescription: %s\nNodeName(%#v) = %q; want %q",
+				test.desc, test.in, out, test.out,
+			)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var visited []string
+
+	err := Walk(walkTestResponse(), func(path Path, node interface{}) error {
+		visited = append(visited, path.String())
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Walk() Unexpected Error: %s", err)
+	}
+
+	want := []string{
+		"Say[0]",
+		"Gather[1]",
+		"Gather[1]/Say[0]",
+		"Gather[1]/Play[1]",
+		"Dial[2]",
+		"Dial[2]/Number[0]",
+		"Dial[2]/Client[1]",
+		"Redirect[3]",
+	}
+
+	if got := strings.Join(visited, ","); got != strings.Join(want, ",") {
+		t.Errorf("Walk() visited %v; want %v", visited, want)
+	}
+}
+
+func TestWalk_SkipChildren(t *testing.T) {
+	var visited []string
+
+	err := Walk(walkTestResponse(), func(path Path, node interface{}) error {
+		visited = append(visited, path.String())
+
+		if _, ok := node.(*Gather); ok {
+			return SkipChildren
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Walk() Unexpected Error: %s", err)
+	}
+
+	for _, v := range visited {
+		if strings.HasPrefix(v, "Gather[1]/") {
+			t.Errorf("Walk() visited %q after SkipChildren was returned", v)
+		}
+	}
+
+	if len(visited) != 6 {
+		t.Errorf("Walk() visited %d nodes; want 6", len(visited))
+	}
+}
+
+func TestWalk_Error(t *testing.T) {
+	stop := errors.New("stop")
+	var count int
+
+	err := Walk(walkTestResponse(), func(path Path, node interface{}) error {
+		count++
+
+		if _, ok := node.(*Play); ok {
+			return stop
+		}
+
+		return nil
+	})
+
+	if err != stop {
+		t.Errorf("Walk() error = %v; want %v", err, stop)
+	}
+
+	if count != 4 {
+		t.Errorf("Walk() visited %d nodes before stopping; want 4", count)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	resp := walkTestResponse()
+
+	// prefix all action and statusCallback URLs, and replace Say with Play
+	err := Transform(resp, func(path Path, node interface{}) (interface{}, error) {
+		switch n := node.(type) {
+		case *Say:
+			return &Play{URL: "/prompts/" + path.String() + ".mp3"}, nil
+		case *Gather:
+			n.Action = "https://staging.example.org" + n.Action
+		case *Dial:
+			n.Action = "https://staging.example.org" + n.Action
+		case *DialNumber:
+			n.StatusCallback = "https://staging.example.org" + n.StatusCallback
+		case *DialClient:
+			return nil, nil
+		}
+
+		return node, nil
+	})
+
+	if err != nil {
+		t.Fatalf("Transform() Unexpected Error: %s", err)
+	}
+
+	if n := len(resp.Verbs); n != 4 {
+		t.Fatalf("len(Verbs) = %d; want 4", n)
+	}
+
+	if p, ok := resp.Verbs[0].(*Play); !ok || p.URL != "/prompts/Say[0].mp3" {
+		t.Errorf("Verbs[0] = %#v; want *Play with URL /prompts/Say[0].mp3", resp.Verbs[0])
+	}
+
+	gather := resp.Verbs[1].(*Gather)
+
+	if gather.Action != "https://staging.example.org/gather" {
+		t.Errorf("Gather.Action = %q; want %q", gather.Action, "https://staging.example.org/gather")
+	}
+
+	if _, ok := gather.NestedVerbs[0].(*Play); !ok {
+		t.Errorf("Gather.NestedVerbs[0] = %#v; want *Play", gather.NestedVerbs[0])
+	}
+
+	dial := resp.Verbs[2].(*Dial)
+
+	if n := len(dial.Nouns); n != 1 {
+		t.Fatalf("len(Dial.Nouns) = %d; want 1", n)
+	}
+
+	if sc := dial.Nouns[0].(*DialNumber).StatusCallback; sc != "https://staging.example.org/status" {
+		t.Errorf("DialNumber.StatusCallback = %q; want %q", sc, "https://staging.example.org/status")
+	}
+}
+
+func TestTransform_Values(t *testing.T) {
+	resp := &Response{
+		Verbs: []interface{}{
+			Gather{NestedVerbs: []interface{}{Say{Message: "a"}, Pause{}}},
+		},
+	}
+
+	err := Transform(resp, func(path Path, node interface{}) (interface{}, error) {
+		if _, ok := node.(Pause); ok {
+			return nil, nil
+		}
+
+		return node, nil
+	})
+
+	if err != nil {
+		t.Fatalf("Transform() Unexpected Error: %s", err)
+	}
+
+	if n := len(resp.Verbs[0].(Gather).NestedVerbs); n != 1 {
+		t.Errorf("len(Gather.NestedVerbs) = %d; want 1", n)
+	}
+}
+
+func TestClone(t *testing.T) {
+	orig := walkTestResponse()
+	clone := Clone(orig)
+
+	clone.Verbs[0].(*Say).Message = "changed"
+	clone.Verbs[1].(*Gather).NestedVerbs[0].(*Say).Message = "changed"
+	clone.Verbs[2].(*Dial).Nouns[0].(*DialNumber).Number = "changed"
+	clone.Verbs[2].(*Dial).Nouns = nil
+
+	if m := orig.Verbs[0].(*Say).Message; m != "Welcome!" {
+		t.Errorf("original Say.Message = %q; want %q", m, "Welcome!")
+	}
+
+	if m := orig.Verbs[1].(*Gather).NestedVerbs[0].(*Say).Message; m != "Press one." {
+		t.Errorf("original nested Say.Message = %q; want %q", m, "Press one.")
+	}
+
+	dial := orig.Verbs[2].(*Dial)
+
+	if n := len(dial.Nouns); n != 2 {
+		t.Fatalf("len(original Dial.Nouns) = %d; want 2", n)
+	}
+
+	if n := dial.Nouns[0].(*DialNumber).Number; n != "+14155555555" {
+		t.Errorf("original DialNumber.Number = %q; want %q", n, "+14155555555")
+	}
+
+	a, err := MarshalResponse(walkTestResponse())
+
+	if err != nil {
+		t.Fatalf("MarshalResponse() Unexpected Error: %s", err)
+	}
+
+	b, err := MarshalResponse(Clone(walkTestResponse()))
+
+	if err != nil {
+		t.Fatalf("MarshalResponse() Unexpected Error: %s", err)
+	}
+
+	if string(a) != string(b) {
+		t.Errorf("Clone() rendered:\n%s\nwant:\n%s", b, a)
+	}
+
+	if Clone(nil) != nil {
+		t.Error("Clone(nil) should be nil")
+	}
+}
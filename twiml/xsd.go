@@ -0,0 +1,130 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/theckman/twilio/twiml/internal/xsd"
+)
+
+// SchemaViolation is a single way in which a rendered TwiML document doesn't
+// conform to the package's TwiML schema.
+type SchemaViolation struct {
+	// Line is the line of the document the offending element starts on,
+	// starting at 1.
+	Line int
+
+	// Element is the name of the offending element.
+	Element string
+
+	// Attribute is the name of the offending attribute, or an empty string
+	// if the violation is about the element itself.
+	Attribute string
+
+	// Message describes the violation.
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	if v.Attribute != "" {
+		return fmt.Sprintf("line %d: <%s %s>: %s", v.Line, v.Element, v.Attribute, v.Message)
+	}
+
+	return fmt.Sprintf("line %d: <%s>: %s", v.Line, v.Element, v.Message)
+}
+
+// SchemaError is the error returned, wrapped, when a TwiML document doesn't
+// conform to the schema. Use errors.Cause() from github.com/pkg/errors to get
+// to it.
+type SchemaError struct {
+	// Violations are all of the violations found, in document order. There
+	// is always at least one.
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	if len(e.Violations) == 1 {
+		return e.Violations[0].String()
+	}
+
+	return fmt.Sprintf("%s (and %d more)", e.Violations[0], len(e.Violations)-1)
+}
+
+// bundledSchema is one of the bundled XSDs, compiled the first time it's used.
+type bundledSchema struct {
+	doc string
+
+	once   sync.Once
+	schema *xsd.Schema
+	err    error
+}
+
+func (b *bundledSchema) validate(doc []byte) error {
+	b.once.Do(func() {
+		b.schema, b.err = xsd.Compile([]byte(b.doc))
+	})
+
+	if b.err != nil {
+		return errors.Wrap(b.err, "loading bundled schema failed")
+	}
+
+	violations, err := b.schema.Validate(doc)
+
+	if err != nil {
+		return errors.Wrap(err, "validating document failed")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	se := &SchemaError{Violations: make([]SchemaViolation, len(violations))}
+
+	for i, v := range violations {
+		se.Violations[i] = SchemaViolation(v)
+	}
+
+	return errors.Wrap(se, "validating document failed")
+}
+
+var (
+	voiceSchema     = &bundledSchema{doc: voiceXSD}
+	messagingSchema = &bundledSchema{doc: messagingXSD}
+)
+
+// ValidateDocument checks a rendered TwiML document for Programmable Voice
+// against the package's XML Schema of TwiML, in xsd/voice.xsd. Unlike
+// Validate(), which only checks nesting, this checks attribute values and text
+// content as well. The schema is written from Twilio's TwiML documentation,
+// and isn't one published by Twilio, so passing it doesn't guarantee Twilio
+// will accept the document. If the document doesn't conform to the schema, the
+// error's cause is a *SchemaError listing each violation with its line number.
+// This function returns a wrapped error (see package documentation for more
+// info).
+func ValidateDocument(doc []byte) error {
+	return voiceSchema.validate(doc)
+}
+
+// ValidateMessagingDocument is like ValidateDocument, but checks a TwiML
+// document for Programmable Messaging against xsd/messaging.xsd. This function
+// returns a wrapped error (see package documentation for more info).
+func ValidateMessagingDocument(doc []byte) error {
+	return messagingSchema.validate(doc)
+}
+
+// WithSchemaValidation makes the encoding fail, instead of producing a document
+// Twilio is likely to reject, if the rendered document doesn't pass
+// ValidateDocument. The cause of the error is a *SchemaError, and nothing is
+// written to the io.Writer.
+func WithSchemaValidation() EncodeOption {
+	return func(c *encodeConfig) {
+		c.schema = true
+	}
+}
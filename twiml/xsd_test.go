@@ -0,0 +1,233 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twiml
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestValidateDocument_Testdata(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.xml"))
+
+	if err != nil {
+		t.Fatalf("filepath.Glob() Unexpected Error: %s", err)
+	}
+
+	if len(files) == 0 {
+		t.Fatal("no golden files found in testdata")
+	}
+
+	for _, file := range files {
+		doc, err := ioutil.ReadFile(file)
+
+		if err != nil {
+			t.Fatalf("reading %s failed: %s", file, err)
+		}
+
+		if err := ValidateDocument(doc); err != nil {
+			t.Errorf("%s does not conform to the voice schema: %s", file, err)
+		}
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		desc       string
+		in         string
+		violations []string
+	}{
+		{
+			desc: "valid document should pass",
+			in: `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+  <Gather input="dtmf speech" numDigits="4" finishOnKey="#">
+    <Say voice="alice" language="en-GB">Enter your PIN</Say>
+  </Gather>
+  <Dial timeout="10"><Number sendDigits="ww1234">+15555550100</Number></Dial>
+</Response>`,
+		},
+		{
+			desc: "invalid attribute values should fail with their lines",
+			in: `<?xml version="1.0" encoding="UTF-8"?>
+<Response>
+  <Say>Hi</Say>
+  <Play loop="-1">https://example.org/a.mp3</Play>
+  <Record method="PUT" trim="sometimes"></Record>
+</Response>`,
+			violations: []string{
+				"line 4: <Play loop>",
+				"line 5: <Record method>",
+				"line 5: <Record trim>",
+			},
+		},
+		{
+			desc: "unknown attribute should fail",
+			in:   "<Response>\n<Hangup reason=\"busy\"/>\n</Response>",
+			violations: []string{
+				"line 2: <Hangup reason>: attribute is not allowed",
+			},
+		},
+		{
+			desc: "misplaced verb should fail",
+			in:   "<Response>\n<Gather>\n<Redirect>https://example.org</Redirect>\n</Gather>\n</Response>",
+			violations: []string{
+				"line 3: <Redirect>: element is not allowed within <Gather>",
+			},
+		},
+		{
+			desc: "missing required attribute should fail",
+			in:   "<Response>\n<Start>\n<Stream url=\"wss://example.org\">\n<Parameter value=\"1\"/>\n</Stream>\n</Start>\n</Response>",
+			violations: []string{
+				"line 4: <Parameter name>: required attribute is missing",
+			},
+		},
+		{
+			desc: "empty Start should fail",
+			in:   "<Response>\n<Start></Start>\n</Response>",
+			violations: []string{
+				"line 2: <Start>: required child elements are missing",
+			},
+		},
+		{
+			desc: "wrong root element should fail",
+			in:   "<Message>Hi</Message>",
+			violations: []string{
+				"line 1: <Message>: is not a valid root element",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateDocument([]byte(test.in))
+
+		if len(test.violations) == 0 {
+			if err != nil {
+				t.Errorf("\nDescription: %s\nValidateDocument() Unexpected Error: %s", test.desc, err)
+			}
+
+			continue
+		}
+
+		se, ok := errors.Cause(err).(*SchemaError)
+
+		if !ok {
+			t.Errorf("\nDescription: %s\nValidateDocument() error cause = %#v, want *SchemaError", test.desc, errors.Cause(err))
+			continue
+		}
+
+		if len(se.Violations) != len(test.violations) {
+			t.Errorf("\nDescription: %s\nValidateDocument() violations = %v, want %d", test.desc, se.Violations, len(test.violations))
+			continue
+		}
+
+		for i, v := range se.Violations {
+			if !strings.HasPrefix(v.String(), test.violations[i]) {
+				t.Errorf("\nDescription: %s\nViolation %d = %q, want prefix %q", test.desc, i, v, test.violations[i])
+			}
+		}
+	}
+}
+
+func TestValidateDocument_Malformed(t *testing.T) {
+	err := ValidateDocument([]byte("<Response><Say></Response>"))
+
+	if err == nil {
+		t.Fatal("ValidateDocument() expected an error for malformed XML")
+	}
+
+	if _, ok := errors.Cause(err).(*SchemaError); ok {
+		t.Errorf("ValidateDocument() error cause is a *SchemaError, want an XML syntax error")
+	}
+}
+
+func TestValidateMessagingDocument(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "message with body and media should pass",
+			in:   "<Response>\n<Message to=\"+15555550100\"><Body>Hi</Body><Media>https://example.org/a.png</Media></Message>\n</Response>",
+		},
+		{
+			desc: "message with plain text should pass",
+			in:   "<Response><Message>Hi</Message><Redirect method=\"GET\">https://example.org</Redirect></Response>",
+		},
+		{
+			desc: "voice verb should fail",
+			in:   "<Response>\n<Message>Hi</Message>\n<Say>Hi</Say>\n</Response>",
+			err:  "line 3: <Say>: element is not allowed within <Response>",
+		},
+		{
+			desc: "long body should fail",
+			in:   "<Response>\n<Message>\n<Body>" + strings.Repeat("a", 1601) + "</Body>\n</Message>\n</Response>",
+			err:  "line 3: <Body>: ",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateMessagingDocument([]byte(test.in))
+
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("\nDescription: %s\nValidateMessagingDocument() Unexpected Error: %s", test.desc, err)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("\nDescription: %s\nValidateMessagingDocument() error = %v, want it to contain %q", test.desc, err, test.err)
+		}
+	}
+}
+
+func TestEncodeResponse_WithSchemaValidation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	valid := &Response{Verbs: []interface{}{&Say{Message: "Hi!"}, &Hangup{}}}
+
+	if err := EncodeResponse(buf, valid, WithSchemaValidation()); err != nil {
+		t.Fatalf("EncodeResponse() Unexpected Error: %s", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Error("EncodeResponse() wrote nothing for a valid response")
+	}
+
+	buf.Reset()
+
+	invalid := &Response{Verbs: []interface{}{&Gather{NestedVerbs: []interface{}{&Redirect{URL: "https://example.org"}}}}}
+	err := EncodeResponse(buf, invalid, WithSchemaValidation(), WithLimits(TwilioLimits))
+
+	if _, ok := errors.Cause(err).(*SchemaError); !ok {
+		t.Errorf("EncodeResponse() error cause = %#v, want *SchemaError", errors.Cause(err))
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("EncodeResponse() wrote %d bytes for an invalid response", buf.Len())
+	}
+}
+
+func TestSchemaError_Error(t *testing.T) {
+	e := &SchemaError{Violations: []SchemaViolation{
+		{Line: 2, Element: "Say", Attribute: "loop", Message: `"x" is not a valid nonNegativeInteger`},
+		{Line: 3, Element: "Hangup", Message: "text content is not allowed"},
+	}}
+
+	want := `line 2: <Say loop>: "x" is not a valid nonNegativeInteger (and 1 more)`
+
+	if got := e.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,238 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sipHeaderPrefix is the prefix of the parameters holding the custom headers
+// of a SIP call.
+const sipHeaderPrefix = "SipHeader_"
+
+// Geo is the location Twilio has for a phone number, which may be partly or
+// entirely empty.
+type Geo struct {
+	City    string
+	State   string
+	Zip     string
+	Country string
+}
+
+// SIP is the details of a call made to a SIP domain.
+type SIP struct {
+	Domain    string
+	DomainSid string
+	Username  string
+	CallID    string
+	SourceIP  string
+
+	// Headers are the custom (X-) headers of the SIP INVITE, keyed by
+	// header name.
+	Headers map[string]string
+}
+
+// VoiceRequest is the parameters of a voice webhook request, such as the one
+// made when a call comes in to a Twilio number or the action of a Gather.
+type VoiceRequest struct {
+	CallSid       string
+	AccountSid    string
+	ParentCallSid string
+	APIVersion    string
+
+	// From and To are the phone numbers, client identifiers (e.g.,
+	// client:alice), or SIP URIs of the caller and callee.
+	From, To       string
+	FromGeo, ToGeo Geo
+
+	CallStatus CallStatus
+	Direction  CallDirection
+
+	// ForwardedFrom is the number the call was forwarded from, if the
+	// carrier provides it.
+	ForwardedFrom string
+
+	// CallerName is the caller ID name, if caller name lookup is enabled.
+	CallerName string
+
+	// CallToken can be used to forward the call with the caller's
+	// original caller ID.
+	CallToken string
+
+	// StirVerstat is the result of the STIR/SHAKEN verification of the
+	// caller ID, if any.
+	StirVerstat string
+
+	// Digits, SpeechResult, and Confidence are the result of a Gather, with
+	// Confidence being between 0 and 1. Digits may also be "hangup" in the
+	// action of a Record.
+	Digits       string
+	SpeechResult string
+	Confidence   float64
+
+	// FinishedOnKey is the key that ended the input of a Gather, if the
+	// caller pressed one of the keys in its FinishOnKey.
+	FinishedOnKey string
+
+	// DialCallStatus, DialCallSid, DialCallDuration, and DialBridged are the
+	// outcome of a Dial, sent to its action once the dialed call ends.
+	DialCallStatus   DialCallStatus
+	DialCallSid      string
+	DialCallDuration time.Duration
+	DialBridged      bool
+
+	// RecordingURL is the recording made by a Dial or Record, sent to its
+	// action.
+	RecordingURL string
+
+	// SIP is the zero value for calls that aren't made to a SIP domain.
+	SIP SIP
+
+	// Params are the parameters that aren't documented webhook parameters,
+	// such as the custom parameters of a call made by the Voice SDK.
+	Params map[string]string
+
+	// Form is all of the parameters of the request.
+	Form url.Values
+}
+
+// voiceParams are the documented parameters of voice webhook requests, which
+// aren't custom parameters.
+var voiceParams = map[string]bool{
+	"AccountSid": true, "AddOns": true, "ApiVersion": true, "CallSid": true,
+	"CallStatus": true, "CallToken": true, "Called": true, "CalledCity": true,
+	"CalledCountry": true, "CalledState": true, "CalledZip": true,
+	"Caller": true, "CallerCity": true, "CallerCountry": true,
+	"CallerName": true, "CallerState": true, "CallerZip": true,
+	"Confidence": true, "DialBridged": true, "DialCallDuration": true,
+	"DialCallSid": true, "DialCallStatus": true, "Digits": true,
+	"Direction": true, "FinishedOnKey": true, "ForwardedFrom": true, "From": true,
+	"FromCity": true, "FromCountry": true, "FromState": true, "FromZip": true,
+	"ParentCallSid": true, "RecordingDuration": true, "RecordingSid": true,
+	"RecordingUrl": true, "SipCallId": true, "SipDomain": true,
+	"SipDomainSid": true, "SipSourceIp": true, "SipUsername": true,
+	"SpeechResult": true, "StirPassportToken": true, "StirVerstat": true,
+	"To": true, "ToCity": true, "ToCountry": true, "ToState": true,
+	"ToZip": true, "msg": true,
+}
+
+// ParseVoiceRequest parses the parameters of a voice webhook request, from
+// its query string and form-encoded body. If any parameters are malformed,
+// the error's cause is a *ParamError listing them, and the *VoiceRequest is
+// still returned with the rest. This function returns a wrapped error (see
+// package documentation for more info).
+func ParseVoiceRequest(r *http.Request) (*VoiceRequest, error) {
+	form, err := parseForm(r)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing voice request failed")
+	}
+
+	return decodeVoiceRequest(form)
+}
+
+func decodeVoiceRequest(form url.Values) (*VoiceRequest, error) {
+	d := &paramDecoder{form: form}
+
+	vr := &VoiceRequest{
+		CallSid:       d.sid("CallSid", "CA"),
+		AccountSid:    d.sid("AccountSid", "AC"),
+		ParentCallSid: d.sid("ParentCallSid", "CA"),
+		APIVersion:    d.string("ApiVersion"),
+		From:          d.string("From"),
+		To:            d.string("To"),
+		FromGeo:       decodeGeo(d, "From"),
+		ToGeo:         decodeGeo(d, "To"),
+		ForwardedFrom: d.string("ForwardedFrom"),
+		CallerName:    d.string("CallerName"),
+		CallToken:     d.string("CallToken"),
+		StirVerstat:   d.string("StirVerstat"),
+		Digits:        d.string("Digits"),
+		SpeechResult:  d.string("SpeechResult"),
+		Confidence:    d.float("Confidence"),
+		FinishedOnKey: d.string("FinishedOnKey"),
+		DialCallSid:   d.sid("DialCallSid", "CA"),
+		DialBridged:   d.bool("DialBridged"),
+		RecordingURL:  d.string("RecordingUrl"),
+		SIP: SIP{
+			Domain:    d.string("SipDomain"),
+			DomainSid: d.sid("SipDomainSid", "SD"),
+			Username:  d.string("SipUsername"),
+			CallID:    d.string("SipCallId"),
+			SourceIP:  d.string("SipSourceIp"),
+		},
+		Form: form,
+	}
+
+	d.text("CallStatus", &vr.CallStatus)
+	d.text("Direction", &vr.Direction)
+	d.text("DialCallStatus", &vr.DialCallStatus)
+
+	if vr.DialCallDuration = d.seconds("DialCallDuration"); vr.DialCallDuration < 0 {
+		d.fail("DialCallDuration", form.Get("DialCallDuration"), errors.New("not a number of seconds"))
+	}
+
+	if vr.Confidence < 0 || vr.Confidence > 1 {
+		d.fail("Confidence", form.Get("Confidence"), errors.New("not between 0 and 1"))
+	}
+
+	if !validDigits(vr.Digits) {
+		d.fail("Digits", vr.Digits, errors.New("not DTMF digits"))
+	}
+
+	if k := vr.FinishedOnKey; k != "" && (len(k) != 1 || !validDigits(k)) {
+		d.fail("FinishedOnKey", k, errors.New("not a single key"))
+	}
+
+	for name := range form {
+		switch {
+		case strings.HasPrefix(name, sipHeaderPrefix):
+			if vr.SIP.Headers == nil {
+				vr.SIP.Headers = make(map[string]string)
+			}
+
+			vr.SIP.Headers[strings.TrimPrefix(name, sipHeaderPrefix)] = form.Get(name)
+		case !voiceParams[name]:
+			if vr.Params == nil {
+				vr.Params = make(map[string]string)
+			}
+
+			vr.Params[name] = form.Get(name)
+		}
+	}
+
+	return vr, d.err("voice request")
+}
+
+func decodeGeo(d *paramDecoder, prefix string) Geo {
+	return Geo{
+		City:    d.string(prefix + "City"),
+		State:   d.string(prefix + "State"),
+		Zip:     d.string(prefix + "Zip"),
+		Country: d.string(prefix + "Country"),
+	}
+}
+
+// validDigits returns whether s is the Digits of a Gather or Record action.
+func validDigits(s string) bool {
+	if s == "hangup" {
+		return true
+	}
+
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c == '*' || c == '#') {
+			return false
+		}
+	}
+
+	return true
+}
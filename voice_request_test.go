@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public License,
+// v. 2.0. If a copy of the MPL was not distributed with this file, you can
+// obtain one at https://mozilla.org/MPL/2.0/.
+//
+// Copyright (c) 2017 Tim Heckman
+
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	testCallSid    = "CA0123456789abcdef0123456789abcdef"
+	testAccountSid = "AC0123456789abcdef0123456789abcdef"
+)
+
+func formRequest(params url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "http://example.com/voice", strings.NewReader(params.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestParseVoiceRequest(t *testing.T) {
+	params := url.Values{
+		"CallSid":            {testCallSid},
+		"AccountSid":         {testAccountSid},
+		"ApiVersion":         {"2010-04-01"},
+		"From":               {"+15555550100"},
+		"To":                 {"+15555550199"},
+		"FromCity":           {"SAN FRANCISCO"},
+		"FromState":          {"CA"},
+		"FromZip":            {"94105"},
+		"FromCountry":        {"US"},
+		"ToCountry":          {"US"},
+		"CallStatus":         {"in-progress"},
+		"Direction":          {"inbound"},
+		"ForwardedFrom":      {"+15555550123"},
+		"CallerName":         {"ALICE"},
+		"Digits":             {"12#"},
+		"SpeechResult":       {"billing"},
+		"Confidence":         {"0.92"},
+		"FinishedOnKey":      {"#"},
+		"DialCallStatus":     {"completed"},
+		"DialCallSid":        {testCallSid},
+		"DialCallDuration":   {"65"},
+		"DialBridged":        {"true"},
+		"RecordingUrl":       {"https://api.twilio.com/rec"},
+		"SipDomain":          {"example.sip.twilio.com"},
+		"SipUsername":        {"alice"},
+		"SipCallId":          {"abc@10.0.0.1"},
+		"SipHeader_X-Ticket": {"42"},
+		"customer_tier":      {"gold"},
+	}
+
+	vr, err := ParseVoiceRequest(formRequest(params))
+
+	if err != nil {
+		t.Fatalf("ParseVoiceRequest() unexpected error: %s", err)
+	}
+
+	want := &VoiceRequest{
+		CallSid:          testCallSid,
+		AccountSid:       testAccountSid,
+		APIVersion:       "2010-04-01",
+		From:             "+15555550100",
+		To:               "+15555550199",
+		FromGeo:          Geo{City: "SAN FRANCISCO", State: "CA", Zip: "94105", Country: "US"},
+		ToGeo:            Geo{Country: "US"},
+		CallStatus:       CallStatusInProgress,
+		Direction:        CallDirectionInbound,
+		ForwardedFrom:    "+15555550123",
+		CallerName:       "ALICE",
+		Digits:           "12#",
+		SpeechResult:     "billing",
+		Confidence:       0.92,
+		FinishedOnKey:    "#",
+		DialCallStatus:   DialCallStatusCompleted,
+		DialCallSid:      testCallSid,
+		DialCallDuration: 65 * time.Second,
+		DialBridged:      true,
+		RecordingURL:     "https://api.twilio.com/rec",
+		SIP: SIP{
+			Domain:   "example.sip.twilio.com",
+			Username: "alice",
+			CallID:   "abc@10.0.0.1",
+			Headers:  map[string]string{"X-Ticket": "42"},
+		},
+		Params: map[string]string{"customer_tier": "gold"},
+		Form:   params,
+	}
+
+	if !reflect.DeepEqual(vr, want) {
+		t.Errorf("ParseVoiceRequest() =\n%+v\nwant\n%+v", vr, want)
+	}
+}
+
+func TestParseVoiceRequest_query(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/voice?CallSid="+testCallSid+"&CallStatus=ringing", nil)
+
+	vr, err := ParseVoiceRequest(r)
+
+	if err != nil {
+		t.Fatalf("ParseVoiceRequest() unexpected error: %s", err)
+	}
+
+	if vr.CallSid != testCallSid || vr.CallStatus != CallStatusRinging {
+		t.Errorf("ParseVoiceRequest() = %+v, want CallSid %s and CallStatus ringing", vr, testCallSid)
+	}
+}
+
+func TestParseVoiceRequest_malformed(t *testing.T) {
+	tests := []struct {
+		desc   string
+		params url.Values
+		names  []string
+	}{
+		{"bad CallSid", url.Values{"CallSid": {"CA123"}}, []string{"CallSid"}},
+		{"SID of the wrong type", url.Values{"AccountSid": {testCallSid}}, []string{"AccountSid"}},
+		{"unknown CallStatus", url.Values{"CallStatus": {"on-hold"}}, []string{"CallStatus"}},
+		{"unknown Direction", url.Values{"Direction": {"sideways"}}, []string{"Direction"}},
+		{"Confidence that isn't a number", url.Values{"Confidence": {"high"}}, []string{"Confidence"}},
+		{"Confidence out of range", url.Values{"Confidence": {"1.5"}}, []string{"Confidence"}},
+		{"Digits that aren't DTMF", url.Values{"Digits": {"12a"}}, []string{"Digits"}},
+		{"more than one FinishedOnKey", url.Values{"FinishedOnKey": {"*#"}}, []string{"FinishedOnKey"}},
+		{"FinishedOnKey that isn't a key", url.Values{"FinishedOnKey": {"A"}}, []string{"FinishedOnKey"}},
+		{"unknown DialCallStatus", url.Values{"DialCallStatus": {"ringing"}}, []string{"DialCallStatus"}},
+		{"negative DialCallDuration", url.Values{"DialCallDuration": {"-1"}}, []string{"DialCallDuration"}},
+		{"DialBridged that isn't a bool", url.Values{"DialBridged": {"maybe"}}, []string{"DialBridged"}},
+		{
+			"all malformed parameters should be reported",
+			url.Values{"CallSid": {"nope"}, "CallStatus": {"nope"}},
+			[]string{"CallSid", "CallStatus"},
+		},
+	}
+
+	for _, test := range tests {
+		vr, err := ParseVoiceRequest(formRequest(test.params))
+
+		pe, ok := errors.Cause(err).(*ParamError)
+
+		if !ok {
+			t.Errorf("\nDescription: %s\nParseVoiceRequest() error = %v, want a *ParamError", test.desc, err)
+			continue
+		}
+
+		var names []string
+
+		for _, p := range pe.Params {
+			names = append(names, p.Name)
+		}
+
+		if !reflect.DeepEqual(names, test.names) {
+			t.Errorf("\nDescription: %s\nmalformed parameters = %v, want %v", test.desc, names, test.names)
+		}
+
+		if vr == nil {
+			t.Errorf("\nDescription: %s\nParseVoiceRequest() should return the request with the error", test.desc)
+		}
+	}
+}
+
+func TestParamError_Error(t *testing.T) {
+	pe := &ParamError{Params: []MalformedParam{
+		{Name: "CallStatus", Value: "nope", Err: ErrUnknownValue},
+		{Name: "Digits", Value: "x", Err: errors.New("not DTMF digits")},
+	}}
+
+	if want := `parameter CallStatus "nope": unknown value (and 1 more)`; pe.Error() != want {
+		t.Errorf("Error() = %q, want %q", pe.Error(), want)
+	}
+
+	pe.Params = pe.Params[1:]
+
+	if want := `parameter Digits "x": not DTMF digits`; pe.Error() != want {
+		t.Errorf("Error() = %q, want %q", pe.Error(), want)
+	}
+}